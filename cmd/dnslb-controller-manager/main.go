@@ -4,23 +4,82 @@
 package main
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
 	_ "github.com/gardener/dnslb-controller-manager/pkg/dnslb/endpoint"
 	_ "github.com/gardener/dnslb-controller-manager/pkg/dnslb/endpoint/sources/ingress"
 	_ "github.com/gardener/dnslb-controller-manager/pkg/dnslb/endpoint/sources/service"
-	_ "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/h2c"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/healthstate"
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/mappings"
+	"github.com/gardener/controller-manager-library/pkg/ctxutil"
+	"github.com/gardener/controller-manager-library/pkg/logger"
 )
 
+const NAME = "dnslb-controller-manager"
+
 func main() {
 	cluster.Configure("dnstarget", "dnstarget", "target cluster for dns entries").
 		Fallback(source.TARGET_CLUSTER).
 		Register()
-	mappings.Configure().ForController("dnslb-loadbalancer").
-		Map(cluster.DEFAULT, source.TARGET_CLUSTER).
-		Map(source.TARGET_CLUSTER, "dnstarget").Register()
-	controllermanager.Start("dnslb-controller-manager", "dns load balancer controller manager", "nothing")
+	for _, name := range []string{lb.CONTROLLER, lb.CONTROLLER_SHARDS} {
+		mappings.Configure().ForController(name).
+			Map(cluster.DEFAULT, source.TARGET_CLUSTER).
+			Map(source.TARGET_CLUSTER, "dnstarget").Register()
+	}
+	mappings.Configure().ForController(lb.CONTROLLER_WEBHOOK).
+		Map(cluster.DEFAULT, source.TARGET_CLUSTER).Register()
+	start("dns load balancer controller manager")
+}
+
+// start runs the controller manager like controllermanager.Start. The HTTP
+// server port is served here instead of by the library, because its server
+// does not support HTTP/2 without TLS required to serve the gRPC health
+// state API on the same port.
+func start(short string) {
+	var (
+		ctx = ctxutil.CancelContext(ctxutil.SyncContext(context.Background()))
+		c   = make(chan os.Signal, 2)
+	)
+	signal.Notify(c, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	go func() {
+		<-c
+		logger.Infof("process is being terminated")
+		ctxutil.Cancel(ctx)
+		<-c
+		logger.Infof("process is aborted immediately")
+		os.Exit(0)
+	}()
+
+	def := controllermanager.DefaultDefinition(NAME, "nothing")
+	command := controllermanager.NewCommand(ctx, NAME, short, def.GetDescription(), def)
+	run := command.RunE
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		port, _ := cmd.Flags().GetInt("server-port-http")
+		if port > 0 {
+			cmd.Flags().Set("server-port-http", "0")
+			h2c.Start(ctx, port, h2c.Handler(http.DefaultServeMux, healthstate.DefaultGRPCServer()))
+		}
+		return run(cmd, args)
+	}
+	if err := command.Execute(); err != nil {
+		os.Exit(1)
+	}
+
+	gracePeriod := 120 * time.Second
+	logger.Infof("waiting for everything to shutdown (max. %d seconds)", gracePeriod/time.Second)
+	ctxutil.SyncPointWait(ctx, gracePeriod)
+	logger.Infof("%s exits.", NAME)
 }
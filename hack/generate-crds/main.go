@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// generate-crds writes the apiextensions.k8s.io/v1 custom resource
+// definitions of the load balancer API group as YAML to stdout.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/crds"
+)
+
+const header = `# SPDX-FileCopyrightText: 2018 SAP SE or an SAP affiliate company and Gardener contributors
+#
+# SPDX-License-Identifier: Apache-2.0
+
+# generated by hack/update-crds.sh, do not edit
+`
+
+func main() {
+	fmt.Print(header)
+	for _, crd := range crds.CRDs {
+		data, err := yaml.Marshal(crd.V1(nil))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot marshal %s: %s\n", crd.Name(), err)
+			os.Exit(1)
+		}
+		fmt.Printf("---\n%s", data)
+	}
+}
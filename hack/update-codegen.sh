@@ -20,7 +20,7 @@ CODEGEN_PKG=${CODEGEN_PKG:-$(cd ${SCRIPT_ROOT}; ls -d -1 ./vendor/k8s.io/code-ge
 ${CODEGEN_PKG}/generate-groups.sh "deepcopy,client,informer,lister" \
   $PKGPATH/pkg/client \
   $PKGPATH/pkg/apis \
-  loadbalancer:v1beta1 \
+  loadbalancer:v1beta1,v1 \
   --go-header-file ${SCRIPT_ROOT}/hack/custom-boilerplate.go.txt
 
 # To use your own boilerplate text use:
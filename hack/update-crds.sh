@@ -0,0 +1,14 @@
+#!/usr/bin/env bash
+
+# SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+#
+# SPDX-License-Identifier: Apache-2.0
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(readlink -f "$(dirname ${0})/..")"
+
+cd "$SCRIPT_ROOT"
+go run ./hack/generate-crds > example/crds.yaml
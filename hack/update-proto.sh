@@ -0,0 +1,17 @@
+#!/usr/bin/env bash
+
+# SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+#
+# SPDX-License-Identifier: Apache-2.0
+
+# Generates the gRPC health state API. Requires protoc and
+# protoc-gen-go v1.3.0 (github.com/golang/protobuf/protoc-gen-go).
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(readlink -f "$(dirname ${0})/..")"
+
+cd "$SCRIPT_ROOT/pkg/server/healthstate/v1"
+protoc --go_out=plugins=grpc,paths=source_relative:. healthstate.proto
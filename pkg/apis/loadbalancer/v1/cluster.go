@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSLoadBalancerClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSLoadBalancerCluster `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSLoadBalancerCluster describes a source cluster hosting endpoints
+// for load balancers. It is maintained by the endpoint controller
+// running for this cluster.
+type DNSLoadBalancerCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSLoadBalancerClusterSpec   `json:"spec"`
+	Status            DNSLoadBalancerClusterStatus `json:"status"`
+}
+
+type DNSLoadBalancerClusterSpec struct {
+	ClusterId string            `json:"clusterId"`
+	Region    string            `json:"region,omitempty"`
+	Zone      string            `json:"zone,omitempty"`
+	Provider  string            `json:"provider,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type DNSLoadBalancerClusterStatus struct {
+	// Deprecated: LastHeartbeatTime is not maintained anymore, the
+	// liveness of a cluster is reported by its heartbeat lease only.
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConditionStatus string
+
+const (
+	CONDITION_TRUE    ConditionStatus = "True"
+	CONDITION_FALSE   ConditionStatus = "False"
+	CONDITION_UNKNOWN ConditionStatus = "Unknown"
+)
+
+// load balancer conditions
+
+const (
+	CONDITION_READY           = "Ready"          // dns name published and healthy
+	CONDITION_RESOLVABLE      = "Resolvable"     // dns name can be resolved
+	CONDITION_HEALTHY         = "Healthy"        // health check of dns name succeeded
+	CONDITION_DNS_PUBLISHED   = "DNSPublished"   // dns records are published
+	CONDITION_ENDPOINTS_VALID = "EndpointsValid" // healthy endpoints are available
+	CONDITION_DEGRADED        = "Degraded"       // fewer healthy endpoints than required
+	CONDITION_ENDPOINT_NAMES  = "EndpointNames"  // endpoint names of the endpoint name template are valid
+)
+
+// endpoint conditions (additionally to Ready and Healthy)
+
+const CONDITION_ACTIVE = "Active" // endpoint is used by its load balancer
+
+// Condition describes one aspect of the state of a resource.
+type Condition struct {
+	// Type of the condition
+	Type string `json:"type"`
+	// Status of the condition (True, False or Unknown)
+	Status ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation the condition has been set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the time the status changed last
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason is a CamelCase reason for the last transition
+	Reason string `json:"reason"`
+	// Message is a human readable message for the last transition
+	Message string `json:"message,omitempty"`
+}
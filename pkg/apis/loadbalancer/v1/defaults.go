@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+const DEFAULT_STATUS_CODE = 200
+const DEFAULT_HEALTH_PATH = "/"
+
+// SetDefaultsDNSLoadBalancerSpec fills in the defaults of a load balancer
+// spec.
+func SetDefaultsDNSLoadBalancerSpec(spec *DNSLoadBalancerSpec) {
+	if spec.Type == "" {
+		spec.Type = LBTYPE_BALANCED
+	}
+	if spec.HealthCheck.StatusCode == 0 {
+		spec.HealthCheck.StatusCode = DEFAULT_STATUS_CODE
+	}
+	if spec.HealthCheck.Path == "" {
+		spec.HealthCheck.Path = DEFAULT_HEALTH_PATH
+	}
+}
+
+const DEFAULT_HEALTHCHECK_THRESHOLD = 1
+
+// SetDefaultsDNSHealthCheckPolicySpec fills in the defaults of a health
+// check policy spec.
+func SetDefaultsDNSHealthCheckPolicySpec(spec *DNSHealthCheckPolicySpec) {
+	if spec.Type == "" {
+		spec.Type = HEALTHCHECK_HTTPS
+	}
+	if spec.Port == 0 {
+		switch spec.Type {
+		case HEALTHCHECK_HTTPS:
+			spec.Port = 443
+		case HEALTHCHECK_HTTP:
+			spec.Port = 80
+		}
+	}
+	if spec.Type != HEALTHCHECK_TCP {
+		if spec.StatusCode == 0 {
+			spec.StatusCode = DEFAULT_STATUS_CODE
+		}
+		if spec.Path == "" {
+			spec.Path = DEFAULT_HEALTH_PATH
+		}
+	}
+	if spec.HealthyThreshold == 0 {
+		spec.HealthyThreshold = DEFAULT_HEALTHCHECK_THRESHOLD
+	}
+	if spec.UnhealthyThreshold == 0 {
+		spec.UnhealthyThreshold = DEFAULT_HEALTHCHECK_THRESHOLD
+	}
+}
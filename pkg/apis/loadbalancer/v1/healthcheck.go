@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSHealthCheckPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSHealthCheckPolicy `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSHealthCheckPolicy describes a health check shared by load balancers
+// referring to it with spec.healthCheckPolicyRef.
+type DNSHealthCheckPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSHealthCheckPolicySpec `json:"spec"`
+}
+
+type DNSHealthCheckPolicySpec struct {
+	// Type is the probe type (HTTPS, HTTP or TCP, default HTTPS)
+	Type DNSHealthCheckType `json:"type,omitempty"`
+	// Port is the probed port (default 443 for HTTPS, 80 for HTTP, required for TCP)
+	Port int `json:"port,omitempty"`
+	// Path is the path of the health check url (default /)
+	Path string `json:"path,omitempty"`
+	// StatusCode is the expected http status code (default 200)
+	StatusCode int `json:"statusCode,omitempty"`
+	// Headers are additional http request headers
+	Headers map[string]string `json:"headers,omitempty"`
+	// HealthyThreshold is the number of consecutive successful probes
+	// required for an unhealthy endpoint to become healthy (default 1)
+	HealthyThreshold int `json:"healthyThreshold,omitempty"`
+	// UnhealthyThreshold is the number of consecutive failed probes
+	// required for a healthy endpoint to become unhealthy (default 1)
+	UnhealthyThreshold int `json:"unhealthyThreshold,omitempty"`
+	// Interval is the period between probes (default is the resync period of the controller)
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Timeout is the timeout of a single probe (default 10s)
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// TLS configures the tls handshake of HTTPS probes
+	TLS *DNSHealthCheckTLS `json:"tls,omitempty"`
+}
+
+// DNSHealthCheckTLS configures the tls handshake of HTTPS probes.
+// Without verification any certificate is accepted.
+type DNSHealthCheckTLS struct {
+	// Verify enables the verification of the server certificate
+	Verify bool `json:"verify,omitempty"`
+	// ServerName is used for SNI and the verification (default is the dns name of the load balancer)
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// DNSHealthCheckType selects the protocol of a health check.
+type DNSHealthCheckType string
+
+const (
+	HEALTHCHECK_HTTPS DNSHealthCheckType = "HTTPS" // http request via tls
+	HEALTHCHECK_HTTP  DNSHealthCheckType = "HTTP"  // plain http request
+	HEALTHCHECK_TCP   DNSHealthCheckType = "TCP"   // tcp connect only
+)
+
+// HEALTHCHECK_TYPES are the supported health check types.
+var HEALTHCHECK_TYPES = []DNSHealthCheckType{HEALTHCHECK_HTTPS, HEALTHCHECK_HTTP, HEALTHCHECK_TCP}
+
+// MAX_HEALTHCHECK_THRESHOLD is the maximum healthy and unhealthy threshold,
+// limited by the probe history kept in the endpoint status.
+const MAX_HEALTHCHECK_THRESHOLD = 5
+
+// DNSHealthCheckPolicyRef refers to a health check policy. Without a
+// namespace the namespace of the load balancer is used.
+type DNSHealthCheckPolicyRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const maxLabelValueLength = 63
+
+// LABEL_HASH_LENGTH is the length of the hash suffix of label values
+// mapped from strings not being valid label values.
+const LABEL_HASH_LENGTH = 10
+
+// LabelValue maps an arbitrary string to a valid label value. It is used
+// for example for the cluster ids found in the origin labels of endpoints,
+// so different strings must not be mapped to the same value: valid label
+// values are kept, for all other strings unsupported characters are
+// replaced by a dot and a hash of the original string is appended.
+func LabelValue(s string) string {
+	if IsLabelValue(s) {
+		return s
+	}
+	v := []byte(s)
+	for i, c := range v {
+		if !isLabelChar(c) {
+			v[i] = '.'
+		}
+	}
+	hash := Hash(s)
+	prefix := strings.Trim(string(v), "-_.")
+	if max := maxLabelValueLength - len(hash) - 1; len(prefix) > max {
+		prefix = strings.TrimRight(prefix[:max], "-_.")
+	}
+	if prefix == "" {
+		return hash
+	}
+	return prefix + "-" + hash
+}
+
+// IsLabelValue checks whether a string is a valid label value.
+func IsLabelValue(s string) bool {
+	if len(s) > maxLabelValueLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isLabelChar(s[i]) {
+			return false
+		}
+	}
+	return s == "" || (isAlphaNumeric(s[0]) && isAlphaNumeric(s[len(s)-1]))
+}
+
+// Hash provides a short hash of a string usable in label values and object
+// names.
+func Hash(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])[:LABEL_HASH_LENGTH]
+}
+
+func isLabelChar(c byte) bool {
+	return isAlphaNumeric(c) || c == '-' || c == '_' || c == '.'
+}
+
+func isAlphaNumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,300 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSLoadBalancerList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSLoadBalancer `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSLoadBalancer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSLoadBalancerSpec   `json:"spec"`
+	Status            DNSLoadBalancerStatus `json:"status"`
+}
+
+type DNSLoadBalancerSpec struct {
+	DNSName     string                     `json:"dnsname"`
+	Type        DNSLoadBalancerType        `json:"type,omitempty"`
+	HealthCheck DNSLoadBalancerHealthCheck `json:"healthCheck,omitempty"`
+	// HealthCheckPolicyRef refers to a shared health check policy replacing
+	// the health check of the load balancer
+	HealthCheckPolicyRef     *DNSHealthCheckPolicyRef        `json:"healthCheckPolicyRef,omitempty"`
+	TTL                      *int64                          `json:"ttl,omitempty"`
+	EndpointValidityInterval *metav1.Duration                `json:"endpointValidityInterval,omitempty"`
+	Access                   *DNSLoadBalancerAccess          `json:"access,omitempty"`
+	Geo                      *DNSLoadBalancerGeo             `json:"geo,omitempty"`
+	CNameFlattening          *DNSLoadBalancerCNameFlattening `json:"cnameFlattening,omitempty"`
+	Backend                  string                          `json:"backend,omitempty"`
+	SRV                      *DNSLoadBalancerSRV             `json:"srv,omitempty"`
+	EndpointNameTemplate     string                          `json:"endpointNameTemplate,omitempty"`
+	AdaptiveTTL              *DNSLoadBalancerAdaptiveTTL     `json:"adaptiveTTL,omitempty"`
+	ChangeBudget             *DNSLoadBalancerChangeBudget    `json:"changeBudget,omitempty"`
+	MaxUnavailable           *intstr.IntOrString             `json:"maxUnavailable,omitempty"`
+	MinHealthy               *intstr.IntOrString             `json:"minHealthy,omitempty"`
+}
+
+// DNSLoadBalancerHealthCheck describes the http health check of the
+// endpoints and the DNS name of a load balancer.
+type DNSLoadBalancerHealthCheck struct {
+	// Path is the path of the health check url (default /)
+	Path string `json:"path,omitempty"`
+	// StatusCode is the expected http status code (default 200)
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+// DNSLoadBalancerChangeBudget limits the number of changes of the published
+// targets of a load balancer. Changes exceeding the budget are deferred.
+type DNSLoadBalancerChangeBudget struct {
+	// MaxChanges is the maximum number of changes within the window
+	MaxChanges int `json:"maxChanges"`
+	// Window is the period the changes are counted for (default 1h)
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// DNSLoadBalancerAdaptiveTTL configures a short TTL while the published
+// targets of a load balancer are changing. After the targets have been
+// stable for the settle window, the steady TTL is used again.
+type DNSLoadBalancerAdaptiveTTL struct {
+	// Steady is the TTL used for stable targets (default is the ttl of the load balancer)
+	Steady *int64 `json:"steady,omitempty"`
+	// Transition is the TTL used while targets are changing
+	Transition int64 `json:"transition"`
+	// SettleWindow is the period targets have to be stable to switch back to the steady TTL (default 5m)
+	SettleWindow *metav1.Duration `json:"settleWindow,omitempty"`
+}
+
+// DNSLoadBalancerSRV configures the publishing of an SRV record for the
+// healthy endpoints of a load balancer. It is published for the name
+// _<service>._<protocol>.<dnsname>.
+type DNSLoadBalancerSRV struct {
+	// Service is the symbolic name of the service (without leading underscore)
+	Service string `json:"service"`
+	// Protocol is the transport protocol, e.g. tcp or udp (without leading underscore)
+	Protocol string `json:"protocol"`
+	// Port is used for endpoints without a port
+	Port int `json:"port,omitempty"`
+	// PortName selects the port of a source Service used for its endpoints
+	PortName string `json:"portName,omitempty"`
+}
+
+// DNSLoadBalancerCNameFlattening configures the resolution of host name
+// endpoints to their addresses. It is required to mix host name and
+// ip address endpoints for one DNS name.
+type DNSLoadBalancerCNameFlattening struct {
+	Enabled bool `json:"enabled"`
+	// LookupInterval is the period for refreshing the addresses (default 2m)
+	LookupInterval *metav1.Duration `json:"lookupInterval,omitempty"`
+}
+
+// DNSLoadBalancerAccess restricts the origin of endpoints accepted
+// for a load balancer. Without an access section all endpoints are accepted.
+type DNSLoadBalancerAccess struct {
+	// Scope for source namespaces (Cluster, Namespace or Selected)
+	Scope string `json:"scope,omitempty"`
+	// Namespaces is the list of accepted source namespaces for scope Selected
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Clusters is the list of accepted source cluster ids, if empty all clusters are accepted
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// DNSLoadBalancerGeo configures the region handling of a load balancer
+// of type Geo.
+type DNSLoadBalancerGeo struct {
+	// Regions lists regions to publish even if no endpoint is located in the region
+	Regions []string `json:"regions,omitempty"`
+	// Fallbacks maps a region to the ordered list of regions used if no endpoint of the region is healthy
+	Fallbacks map[string][]string `json:"fallbacks,omitempty"`
+}
+
+// DNSLoadBalancerType selects how endpoints are published.
+type DNSLoadBalancerType string
+
+const (
+	LBTYPE_BALANCED  DNSLoadBalancerType = "Balanced"  // all active endpoints are selected
+	LBTYPE_EXCLUSIVE DNSLoadBalancerType = "Exclusive" // singleton dnsname entry (one active endpoint is selected)
+	LBTYPE_GEO       DNSLoadBalancerType = "Geo"       // additional region specific dnsname entries
+)
+
+// LBTYPES are the supported load balancer types.
+var LBTYPES = []DNSLoadBalancerType{LBTYPE_BALANCED, LBTYPE_EXCLUSIVE, LBTYPE_GEO}
+
+const (
+	BACKEND_DNSENTRY = "DNSEntry" // DNS records are published by DNSEntry objects
+	BACKEND_RFC2136  = "RFC2136"  // DNS records are published by dynamic updates
+	BACKEND_EMBEDDED = "Embedded" // DNS names are served by the embedded DNS server
+)
+
+const (
+	BUDGET_LOADBALANCER = "LoadBalancer" // change budget of a single load balancer
+	BUDGET_GLOBAL       = "Global"       // change budget of all load balancers
+)
+
+const (
+	SCOPE_CLUSTER   = "Cluster"   // endpoints from all source namespaces are accepted
+	SCOPE_NAMESPACE = "Namespace" // only endpoints from the namespace of the load balancer are accepted
+	SCOPE_SELECTED  = "Selected"  // only endpoints from explicitly listed namespaces are accepted
+)
+
+type DNSLoadBalancerStatus struct {
+	State   *string                 `json:"state,omitempty"`
+	Message *string                 `json:"message,omitempty"`
+	Active  []DNSLoadBalancerActive `json:"active,omitempty"`
+	Regions []DNSLoadBalancerRegion `json:"regions,omitempty"`
+	// TTL is the TTL currently used for the published records
+	TTL *int64 `json:"ttl,omitempty"`
+	// LastChange is the time of the last change of the published targets
+	// or the health of an endpoint
+	LastChange *metav1.Time `json:"lastChange,omitempty"`
+	// Frozen is set while DNS changes are suspended for the load balancer
+	Frozen *DNSLoadBalancerFreeze `json:"frozen,omitempty"`
+	// Throttled is set while a change is deferred by a change budget
+	Throttled *DNSLoadBalancerThrottle `json:"throttled,omitempty"`
+	// Changes are the times of the recent changes of the published DNS
+	// records, counted by the change budgets
+	Changes []metav1.Time `json:"changes,omitempty"`
+	// RecordsHash identifies the published DNS records of all DNS names
+	// of the load balancer to detect changes
+	RecordsHash string `json:"recordsHash,omitempty"`
+	// ObservedGeneration is the generation of the last handled spec
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the state of the load balancer
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// DNSLoadBalancerThrottle describes a change deferred by a change budget.
+type DNSLoadBalancerThrottle struct {
+	// Budget is the exhausted budget (LoadBalancer or Global)
+	Budget string `json:"budget"`
+	// Until is the earliest time the deferred change will be published
+	Until metav1.Time `json:"until"`
+	// Targets are the targets that will be published
+	Targets []string `json:"targets,omitempty"`
+}
+
+// DNSLoadBalancerFreeze describes a suspension of DNS changes.
+type DNSLoadBalancerFreeze struct {
+	Reason string `json:"reason"`
+	// Targets are the targets that would be published without the freeze,
+	// if they differ from the published targets
+	Targets []string `json:"targets,omitempty"`
+}
+
+type DNSLoadBalancerActive struct {
+	Endpoint  string   `json:"endpoint"`
+	IPAddress string   `json:"ipaddress,omitempty"`
+	CName     string   `json:"cname,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+	Cluster   string   `json:"cluster,omitempty"`
+	Region    string   `json:"region,omitempty"`
+	Zone      string   `json:"zone,omitempty"`
+}
+
+// DNSLoadBalancerRegion describes the published targets for a region of
+// a load balancer of type Geo.
+type DNSLoadBalancerRegion struct {
+	Region   string   `json:"region"`
+	DNSName  string   `json:"dnsname"`
+	Targets  []string `json:"targets,omitempty"`
+	Fallback string   `json:"fallback,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSLoadBalancerEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSLoadBalancerEndpoint `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSLoadBalancerEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSLoadBalancerEndpointSpec   `json:"spec"`
+	Status            DNSLoadBalancerEndpointStatus `json:"status"`
+}
+
+// labels maintained by the endpoint controller for generated endpoints
+const (
+	LABEL_CONTROLLER = "controller"
+	LABEL_SOURCE     = "source"
+	LABEL_CLUSTER    = "cluster"
+	LABEL_NAMESPACE  = "namespace"
+)
+
+type DNSLoadBalancerEndpointSpec struct {
+	LoadBalancer string `json:"loadbalancer"`
+	// Addresses are the IP addresses of the endpoint
+	Addresses []string `json:"addresses,omitempty"`
+	CName     string   `json:"cname,omitempty"`
+	Region    string   `json:"region,omitempty"`
+	// Port, Priority and Weight are used for SRV records
+	Port     int `json:"port,omitempty"`
+	Priority int `json:"priority,omitempty"`
+	Weight   int `json:"weight,omitempty"`
+}
+
+type DNSLoadBalancerEndpointStatus struct {
+	State              *string      `json:"state,omitempty"`
+	Message            *string      `json:"message,omitempty"`
+	Healthy            bool         `json:"healthy"`
+	ValidUntil         *metav1.Time `json:"validUntil,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+	Conditions         []Condition  `json:"conditions,omitempty"`
+	// LastProbeTime is the time of the last health check written to the status,
+	// it is refreshed at most once per minute while the health is unchanged
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the time the health of the endpoint changed last
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// LastProbeLatency is the duration of the health check of LastProbeTime
+	LastProbeLatency *metav1.Duration `json:"lastProbeLatency,omitempty"`
+	// LastFailureReason describes the failed health check making the endpoint unhealthy
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+	// RecentProbes lists the outcomes of the recent health checks up to
+	// LastProbeTime (oldest first)
+	RecentProbes []DNSLoadBalancerProbe `json:"recentProbes,omitempty"`
+	// InactiveReason explains why the endpoint is not used by its load balancer
+	InactiveReason string `json:"inactiveReason,omitempty"`
+}
+
+// DNSLoadBalancerProbe describes the outcome of a health check.
+type DNSLoadBalancerProbe struct {
+	Time    metav1.Time `json:"time"`
+	Healthy bool        `json:"healthy"`
+	Reason  string      `json:"reason,omitempty"`
+}
+
+const (
+	PROBE_TIMEOUT           = "Timeout"              // no response in time
+	PROBE_TLS_ERROR         = "TLSError"             // tls handshake failed
+	PROBE_CONNECTION_FAILED = "ConnectionFailed"     // request failed
+	PROBE_STATUS_CODE       = "UnexpectedStatusCode" // response with unexpected status code
+)
+const (
+	INACTIVE_UNHEALTHY = "Unhealthy" // health check failed
+	INACTIVE_STANDBY   = "Standby"   // healthy, but another endpoint is selected
+	INACTIVE_FROZEN    = "Frozen"    // not published because of a freeze
+	INACTIVE_THROTTLED = "Throttled" // not published because of an exhausted change budget
+	INACTIVE_EXPIRED   = "Expired"   // validity or heartbeat lease expired
+)
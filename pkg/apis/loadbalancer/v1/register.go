@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer"
+)
+
+const (
+	Version   = "v1"
+	GroupName = loadbalancer.GroupName
+
+	LoadBalancerResourceKind   = "DNSLoadBalancer"
+	LoadBalancerResourcePlural = "dnsloadbalancers"
+
+	LoadBalancerEndpointResourceKind   = "DNSLoadBalancerEndpoint"
+	LoadBalancerEndpointResourcePlural = "dnsloadbalancerendpoints"
+
+	LoadBalancerClusterResourceKind   = "DNSLoadBalancerCluster"
+	LoadBalancerClusterResourcePlural = "dnsloadbalancerclusters"
+
+	HealthCheckPolicyResourceKind   = "DNSHealthCheckPolicy"
+	HealthCheckPolicyResourcePlural = "dnshealthcheckpolicies"
+)
+
+var (
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+
+	SchemeGroupVersion          = schema.GroupVersion{Group: loadbalancer.GroupName, Version: Version}
+	LoadBalancerCRDName         = LoadBalancerResourcePlural + "." + loadbalancer.GroupName
+	LoadBalancerEndpointCRDName = LoadBalancerEndpointResourcePlural + "." + loadbalancer.GroupName
+	LoadBalancerClusterCRDName  = LoadBalancerClusterResourcePlural + "." + loadbalancer.GroupName
+	HealthCheckPolicyCRDName    = HealthCheckPolicyResourcePlural + "." + loadbalancer.GroupName
+)
+
+var (
+	LoadBalancerGroupKind         = schema.GroupKind{Group: GroupName, Kind: LoadBalancerResourceKind}
+	LoadBalancerEndpointGroupKind = schema.GroupKind{Group: GroupName, Kind: LoadBalancerEndpointResourceKind}
+	LoadBalancerClusterGroupKind  = schema.GroupKind{Group: GroupName, Kind: LoadBalancerClusterResourceKind}
+	HealthCheckPolicyGroupKind    = schema.GroupKind{Group: GroupName, Kind: HealthCheckPolicyResourceKind}
+)
+
+// Resource gets an LoadBalancer GroupResource for a specified resource
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+// addKnownTypes adds the set of types defined in this package to the supplied scheme.
+func addKnownTypes(s *runtime.Scheme) error {
+	s.AddKnownTypes(SchemeGroupVersion,
+		&DNSLoadBalancer{},
+		&DNSLoadBalancerList{},
+		&DNSLoadBalancerEndpoint{},
+		&DNSLoadBalancerEndpointList{},
+		&DNSLoadBalancerCluster{},
+		&DNSLoadBalancerClusterList{},
+		&DNSHealthCheckPolicy{},
+		&DNSHealthCheckPolicyList{},
+	)
+	metav1.AddToGroupVersion(s, SchemeGroupVersion)
+	return nil
+}
+
+func init() {
+
+	resources.Register(SchemeBuilder)
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2018 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scope
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/gardener/controller-manager-library/pkg/utils"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+type AccessControl interface {
+	ValidFor(obj metav1.Object) bool
+}
+
+type accessControl struct {
+	localcluster string
+	namespaces   StringSet
+	clusters     StringSet
+}
+
+// ValidFor checks whether an endpoint object originates from an accepted
+// source cluster and namespace.
+func (this *accessControl) ValidFor(obj metav1.Object) bool {
+	cluster, namespace := Origin(obj, this.localcluster)
+	if this.clusters != nil && !this.clusters.Contains(cluster) {
+		return false
+	}
+	if this.namespaces != nil && !this.namespaces.Contains(namespace) {
+		return false
+	}
+	return true
+}
+
+// Eval determines the access control for a load balancer. The local cluster
+// is the id of the cluster hosting the load balancer. Cluster ids are
+// compared by their label value (see LabelValue), the form used for the
+// origin labels.
+func Eval(obj metav1.Object, access *DNSLoadBalancerAccess, localcluster string) (AccessControl, error) {
+	var err error
+	control := &accessControl{localcluster: localcluster}
+
+	if access == nil {
+		return control, nil
+	}
+	if len(access.Clusters) > 0 {
+		control.clusters = StringSet{}
+		for _, c := range access.Clusters {
+			control.clusters.Add(LabelValue(c))
+		}
+	}
+	switch access.Scope {
+	case "", SCOPE_CLUSTER:
+	case SCOPE_NAMESPACE:
+		control.namespaces = NewStringSet(obj.GetNamespace())
+	case SCOPE_SELECTED:
+		control.namespaces = NewStringSetByArray(access.Namespaces)
+	default:
+		err = fmt.Errorf("invalid access scope '%s'", access.Scope)
+	}
+	return control, err
+}
+
+// Origin provides the source cluster, in the form of a label value, and
+// the source namespace of an endpoint. They are taken from the labels maintained by the endpoint controller.
+// Endpoints without origin labels are considered to be local to the
+// cluster hosting the load balancer.
+//
+// The labels can be set by everybody permitted to write endpoints in the
+// cluster hosting the load balancer. The access control therefore relies
+// on write access to endpoints being restricted to the endpoint
+// controllers, or on the admission webhook rejecting origin labels set by
+// other users (see OriginLabelsModified).
+func Origin(obj metav1.Object, localcluster string) (cluster, namespace string) {
+	labels := obj.GetLabels()
+	cluster = labels[LABEL_CLUSTER]
+	if cluster == "" {
+		cluster = localcluster
+	}
+	cluster = LabelValue(cluster)
+	namespace = labels[LABEL_NAMESPACE]
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	return cluster, namespace
+}
+
+// OriginLabelsModified checks whether the origin labels of an endpoint are
+// set or changed compared to its old version. The old version is nil for
+// new endpoints.
+func OriginLabelsModified(obj, old metav1.Object) bool {
+	for _, l := range []string{LABEL_CLUSTER, LABEL_NAMESPACE} {
+		value := obj.GetLabels()[l]
+		if old == nil {
+			if value != "" {
+				return true
+			}
+			continue
+		}
+		if value != old.GetLabels()[l] {
+			return true
+		}
+	}
+	return false
+}
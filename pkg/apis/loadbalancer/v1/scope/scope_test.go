@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scope_test
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/scope"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("access control", func() {
+	const local = "garden/local"
+
+	lb := &api.DNSLoadBalancer{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "lb"}}
+
+	endpoint := func(cluster, namespace string) *api.DNSLoadBalancerEndpoint {
+		ep := &api.DNSLoadBalancerEndpoint{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "ep", Labels: map[string]string{}}}
+		if cluster != "" {
+			ep.Labels[api.LABEL_CLUSTER] = cluster
+		}
+		if namespace != "" {
+			ep.Labels[api.LABEL_NAMESPACE] = namespace
+		}
+		return ep
+	}
+
+	eval := func(access *api.DNSLoadBalancerAccess) AccessControl {
+		control, err := Eval(lb, access, local)
+		Expect(err).NotTo(HaveOccurred())
+		return control
+	}
+
+	It("accepts all endpoints without access section", func() {
+		control := eval(nil)
+		Expect(control.ValidFor(endpoint("", ""))).To(BeTrue())
+		Expect(control.ValidFor(endpoint("eu", "other"))).To(BeTrue())
+	})
+
+	It("rejects an invalid scope", func() {
+		_, err := Eval(lb, &api.DNSLoadBalancerAccess{Scope: "Any"}, local)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("namespace scope", func() {
+		valid := func(scope string, namespaces []string, namespace string) bool {
+			return eval(&api.DNSLoadBalancerAccess{Scope: scope, Namespaces: namespaces}).ValidFor(endpoint("eu", namespace))
+		}
+
+		It("accepts all namespaces for cluster scope", func() {
+			Expect(valid(api.SCOPE_CLUSTER, nil, "other")).To(BeTrue())
+		})
+
+		It("accepts the namespace of the load balancer for namespace scope", func() {
+			Expect(valid(api.SCOPE_NAMESPACE, nil, "acme")).To(BeTrue())
+			Expect(valid(api.SCOPE_NAMESPACE, nil, "")).To(BeTrue())
+			Expect(valid(api.SCOPE_NAMESPACE, nil, "other")).To(BeFalse())
+		})
+
+		It("accepts the selected namespaces for selected scope", func() {
+			Expect(valid(api.SCOPE_SELECTED, []string{"other"}, "other")).To(BeTrue())
+			Expect(valid(api.SCOPE_SELECTED, []string{"other"}, "acme")).To(BeFalse())
+		})
+	})
+
+	Context("clusters", func() {
+		valid := func(clusters []string, cluster string) bool {
+			return eval(&api.DNSLoadBalancerAccess{Clusters: clusters}).ValidFor(endpoint(cluster, ""))
+		}
+
+		It("accepts listed clusters only", func() {
+			Expect(valid([]string{"eu", "us"}, "us")).To(BeTrue())
+			Expect(valid([]string{"eu"}, "us")).To(BeFalse())
+		})
+
+		It("accepts the local cluster if listed", func() {
+			Expect(valid([]string{local}, "")).To(BeTrue())
+			Expect(valid([]string{api.LabelValue(local)}, "")).To(BeTrue())
+			Expect(valid([]string{"eu"}, "")).To(BeFalse())
+		})
+
+		It("compares cluster ids by their label value", func() {
+			Expect(valid([]string{"eu/garden"}, api.LabelValue("eu/garden"))).To(BeTrue())
+			Expect(valid([]string{"eu.garden"}, "eu.garden")).To(BeTrue())
+		})
+
+		It("distinguishes cluster ids with the same characters replaced", func() {
+			Expect(valid([]string{"eu.garden"}, api.LabelValue("eu/garden"))).To(BeFalse())
+			Expect(valid([]string{"eu/garden"}, "eu.garden")).To(BeFalse())
+			Expect(valid([]string{"garden.local"}, "")).To(BeFalse())
+		})
+	})
+
+	Context("origin labels", func() {
+		It("detects labels of new endpoints", func() {
+			Expect(OriginLabelsModified(endpoint("", ""), nil)).To(BeFalse())
+			Expect(OriginLabelsModified(endpoint("eu", ""), nil)).To(BeTrue())
+			Expect(OriginLabelsModified(endpoint("", "acme"), nil)).To(BeTrue())
+		})
+
+		It("detects changed labels", func() {
+			Expect(OriginLabelsModified(endpoint("eu", "acme"), endpoint("eu", "acme"))).To(BeFalse())
+			Expect(OriginLabelsModified(endpoint("us", "acme"), endpoint("eu", "acme"))).To(BeTrue())
+			Expect(OriginLabelsModified(endpoint("eu", ""), endpoint("eu", "acme"))).To(BeTrue())
+		})
+	})
+})
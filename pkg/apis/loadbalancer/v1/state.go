@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// common states
+
+const STATE_ERROR = "Error"
+const STATE_INVALID = "Invalid"
+const STATE_PENDING = "Pending"
+
+// load balancer states
+
+const STATE_UNREACHABLE = "Unreachable"
+const STATE_HEALTHY = "Healthy"
+const STATE_DEGRADED = "Degraded"
+
+// endpoint states
+
+const STATE_ACTIVE = "Active"
+const STATE_INACTIVE = "Inactive"
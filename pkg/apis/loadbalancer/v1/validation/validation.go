@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// NormalizeDNSName provides the lower case form of a DNS name without
+// trailing dot.
+func NormalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// IsSubDomain checks whether a DNS name is a proper subdomain of a domain.
+func IsSubDomain(name, domain string) bool {
+	return strings.HasSuffix(NormalizeDNSName(name), "."+NormalizeDNSName(domain))
+}
+
+// ValidateDNSName checks a DNS name.
+func ValidateDNSName(name string, path *field.Path) field.ErrorList {
+	if name == "" {
+		return field.ErrorList{field.Required(path, "dns name required")}
+	}
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Subdomain(NormalizeDNSName(name)) {
+		allErrs = append(allErrs, field.Invalid(path, name, msg))
+	}
+	return allErrs
+}
+
+// ValidateLoadBalancerSpec checks the spec of a load balancer on its own.
+func ValidateLoadBalancerSpec(spec *DNSLoadBalancerSpec, path *field.Path) field.ErrorList {
+	allErrs := ValidateDNSName(spec.DNSName, path.Child("dnsname"))
+
+	switch spec.Type {
+	case "", LBTYPE_BALANCED, LBTYPE_EXCLUSIVE, LBTYPE_GEO:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("type"), spec.Type, []string{string(LBTYPE_BALANCED), string(LBTYPE_EXCLUSIVE), string(LBTYPE_GEO)}))
+	}
+
+	switch spec.Backend {
+	case "", BACKEND_DNSENTRY, BACKEND_RFC2136, BACKEND_EMBEDDED:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("backend"), spec.Backend, []string{BACKEND_DNSENTRY, BACKEND_RFC2136, BACKEND_EMBEDDED}))
+	}
+	if spec.TTL != nil && *spec.TTL <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("ttl"), *spec.TTL, "must be positive"))
+	}
+	if code := spec.HealthCheck.StatusCode; code != 0 && (code < 100 || code > 599) {
+		allErrs = append(allErrs, field.Invalid(path.Child("healthCheck", "statusCode"), code, "must be a valid http status code"))
+	}
+	if p := spec.HealthCheck.Path; p != "" && !strings.HasPrefix(p, "/") {
+		allErrs = append(allErrs, field.Invalid(path.Child("healthCheck", "path"), p, "must start with /"))
+	}
+	if ref := spec.HealthCheckPolicyRef; ref != nil {
+		allErrs = append(allErrs, validateHealthCheckPolicyRef(ref, path.Child("healthCheckPolicyRef"))...)
+	}
+	if srv := spec.SRV; srv != nil {
+		if srv.Service == "" {
+			allErrs = append(allErrs, field.Required(path.Child("srv", "service"), "service required"))
+		}
+		if srv.Protocol == "" {
+			allErrs = append(allErrs, field.Required(path.Child("srv", "protocol"), "protocol required"))
+		}
+		if srv.Port < 0 || srv.Port > 65535 {
+			allErrs = append(allErrs, field.Invalid(path.Child("srv", "port"), srv.Port, "must be a valid port"))
+		}
+	}
+	if spec.EndpointNameTemplate != "" {
+		allErrs = append(allErrs, validateEndpointNameTemplate(spec.EndpointNameTemplate, spec.DNSName, path.Child("endpointNameTemplate"))...)
+	}
+	if a := spec.AdaptiveTTL; a != nil && a.Transition <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("adaptiveTTL", "transition"), a.Transition, "must be positive"))
+	}
+	if b := spec.ChangeBudget; b != nil && b.MaxChanges <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("changeBudget", "maxChanges"), b.MaxChanges, "must be positive"))
+	}
+	allErrs = append(allErrs, validateIntOrPercent(spec.MaxUnavailable, path.Child("maxUnavailable"))...)
+	if v := spec.MaxUnavailable; v != nil {
+		if n, err := intstr.GetValueFromIntOrPercent(v, 100, false); err == nil && n == 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxUnavailable"), v.String(), "must be positive"))
+		}
+	}
+	allErrs = append(allErrs, validateIntOrPercent(spec.MinHealthy, path.Child("minHealthy"))...)
+	return allErrs
+}
+
+// endpointNameSample are the values used to check an endpoint name
+// template, the controller maps the actual values to valid DNS labels.
+var endpointNameSample = map[string]string{
+	"endpoint": "endpoint",
+	"cluster":  "cluster",
+	"region":   "region",
+	"zone":     "zone",
+	"provider": "provider",
+}
+
+// validateEndpointNameTemplate renders an endpoint name template with
+// sample values. The result must be a subdomain of the DNS name.
+func validateEndpointNameTemplate(tmplsrc string, dnsname string, path *field.Path) field.ErrorList {
+	tmpl, err := template.New("endpoint").Option("missingkey=error").Parse(tmplsrc)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, tmplsrc, err.Error())}
+	}
+	values := map[string]string{"dnsname": dnsname}
+	for k, v := range endpointNameSample {
+		values[k] = v
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, values); err != nil {
+		return field.ErrorList{field.Invalid(path, tmplsrc, err.Error())}
+	}
+	name := NormalizeDNSName(buf.String())
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return field.ErrorList{field.Invalid(path, tmplsrc, fmt.Sprintf("endpoint name %q: %s", name, strings.Join(msgs, ", ")))}
+	}
+	if !IsSubDomain(name, dnsname) {
+		return field.ErrorList{field.Invalid(path, tmplsrc, fmt.Sprintf("endpoint name %q must be a subdomain of the dns name", name))}
+	}
+	return nil
+}
+
+func validateHealthCheckPolicyRef(ref *DNSHealthCheckPolicyRef, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), "policy name required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			allErrs = append(allErrs, field.Invalid(path.Child("name"), ref.Name, msg))
+		}
+	}
+	if ref.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(ref.Namespace) {
+			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), ref.Namespace, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateIntOrPercent(value *intstr.IntOrString, path *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	v, err := intstr.GetValueFromIntOrPercent(value, 100, false)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value.String(), "must be a number or a percentage")}
+	}
+	if v < 0 {
+		return field.ErrorList{field.Invalid(path, value.String(), "must not be negative")}
+	}
+	return nil
+}
+
+// ValidateLoadBalancerEndpointSpec checks the spec of an endpoint on its own.
+func ValidateLoadBalancerEndpointSpec(spec *DNSLoadBalancerEndpointSpec, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.LoadBalancer == "" {
+		allErrs = append(allErrs, field.Required(path.Child("loadbalancer"), "load balancer required"))
+	}
+	switch {
+	case len(spec.Addresses) > 0 && spec.CName != "":
+		allErrs = append(allErrs, field.Forbidden(path.Child("cname"), "either addresses or cname must be specified"))
+	case len(spec.Addresses) > 0:
+		found := map[string]bool{}
+		for i, a := range spec.Addresses {
+			ip := net.ParseIP(a)
+			switch {
+			case ip == nil:
+				allErrs = append(allErrs, field.Invalid(path.Child("addresses").Index(i), a, "must be a valid ip address"))
+			case found[ip.String()]:
+				allErrs = append(allErrs, field.Duplicate(path.Child("addresses").Index(i), a))
+			default:
+				found[ip.String()] = true
+			}
+		}
+	case spec.CName != "":
+		if net.ParseIP(spec.CName) != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("cname"), spec.CName, "must be a host name, use addresses for ip addresses"))
+		} else {
+			allErrs = append(allErrs, ValidateDNSName(spec.CName, path.Child("cname"))...)
+		}
+	default:
+		allErrs = append(allErrs, field.Required(path.Child("addresses"), "addresses or cname required"))
+	}
+	if spec.Port < 0 || spec.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(path.Child("port"), spec.Port, "must be a valid port"))
+	}
+	if spec.Priority < 0 || spec.Priority > 65535 {
+		allErrs = append(allErrs, field.Invalid(path.Child("priority"), spec.Priority, "must be between 0 and 65535"))
+	}
+	if spec.Weight < 0 || spec.Weight > 65535 {
+		allErrs = append(allErrs, field.Invalid(path.Child("weight"), spec.Weight, "must be between 0 and 65535"))
+	}
+	return allErrs
+}
+
+// ValidateHealthCheckPolicySpec checks the spec of a health check policy.
+func ValidateHealthCheckPolicySpec(spec *DNSHealthCheckPolicySpec, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch spec.Type {
+	case "", HEALTHCHECK_HTTPS, HEALTHCHECK_HTTP:
+		if code := spec.StatusCode; code != 0 && (code < 100 || code > 599) {
+			allErrs = append(allErrs, field.Invalid(path.Child("statusCode"), code, "must be a valid http status code"))
+		}
+		if p := spec.Path; p != "" && !strings.HasPrefix(p, "/") {
+			allErrs = append(allErrs, field.Invalid(path.Child("path"), p, "must start with /"))
+		}
+		for name := range spec.Headers {
+			for _, msg := range validation.IsHTTPHeaderName(name) {
+				allErrs = append(allErrs, field.Invalid(path.Child("headers").Key(name), name, msg))
+			}
+		}
+	case HEALTHCHECK_TCP:
+		if spec.Port == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("port"), "port required for type TCP"))
+		}
+		if spec.Path != "" || spec.StatusCode != 0 || len(spec.Headers) > 0 || spec.TLS != nil {
+			allErrs = append(allErrs, field.Forbidden(path, "path, statusCode, headers and tls not supported for type TCP"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("type"), spec.Type, []string{string(HEALTHCHECK_HTTPS), string(HEALTHCHECK_HTTP), string(HEALTHCHECK_TCP)}))
+	}
+	if spec.Port < 0 || spec.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(path.Child("port"), spec.Port, "must be a valid port"))
+	}
+	if spec.TLS != nil && spec.Type == HEALTHCHECK_HTTP {
+		allErrs = append(allErrs, field.Forbidden(path.Child("tls"), "tls not supported for type HTTP"))
+	}
+	allErrs = append(allErrs, validateThreshold(spec.HealthyThreshold, path.Child("healthyThreshold"))...)
+	allErrs = append(allErrs, validateThreshold(spec.UnhealthyThreshold, path.Child("unhealthyThreshold"))...)
+	if spec.Interval != nil && spec.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("interval"), spec.Interval.Duration.String(), "must be positive"))
+	}
+	if spec.Timeout != nil && spec.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), spec.Timeout.Duration.String(), "must be positive"))
+	}
+	return allErrs
+}
+
+func validateThreshold(value int, path *field.Path) field.ErrorList {
+	if value < 0 || value > MAX_HEALTHCHECK_THRESHOLD {
+		return field.ErrorList{field.Invalid(path, value, fmt.Sprintf("must be between 1 and %d", MAX_HEALTHCHECK_THRESHOLD))}
+	}
+	return nil
+}
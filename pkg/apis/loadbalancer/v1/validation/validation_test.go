@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validation", func() {
+	path := field.NewPath("spec")
+	fields := func(errs field.ErrorList) []string {
+		var result []string
+		for _, e := range errs {
+			result = append(result, e.Field)
+		}
+		return result
+	}
+
+	Context("load balancer", func() {
+		var spec *api.DNSLoadBalancerSpec
+		BeforeEach(func() {
+			spec = &api.DNSLoadBalancerSpec{DNSName: "lb.example.org", Type: api.LBTYPE_BALANCED, HealthCheck: api.DNSLoadBalancerHealthCheck{Path: "/healthz"}}
+		})
+
+		It("accepts a valid spec", func() {
+			Expect(ValidateLoadBalancerSpec(spec, path)).To(BeEmpty())
+		})
+
+		It("accepts upper case dns names with trailing dot", func() {
+			spec.DNSName = "LB.Example.org."
+			Expect(ValidateLoadBalancerSpec(spec, path)).To(BeEmpty())
+		})
+
+		It("rejects malformed dns names", func() {
+			spec.DNSName = "lb_1.example.org"
+			Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.dnsname"))
+			spec.DNSName = ""
+			Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.dnsname"))
+		})
+
+		It("rejects unknown types", func() {
+			spec.Type = "RoundRobin"
+			Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.type"))
+		})
+
+		It("rejects invalid health checks", func() {
+			spec.HealthCheck = api.DNSLoadBalancerHealthCheck{Path: "healthz", StatusCode: 99}
+			Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.healthCheck.path", "spec.healthCheck.statusCode"))
+		})
+
+		It("rejects invalid health check policy references", func() {
+			spec.HealthCheckPolicyRef = &api.DNSHealthCheckPolicyRef{Namespace: "Probes"}
+			Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.healthCheckPolicyRef.name", "spec.healthCheckPolicyRef.namespace"))
+			spec.HealthCheckPolicyRef = &api.DNSHealthCheckPolicyRef{Name: "https-healthz", Namespace: "probes"}
+			Expect(ValidateLoadBalancerSpec(spec, path)).To(BeEmpty())
+		})
+
+		It("rejects invalid settings", func() {
+			ttl := int64(0)
+			max := intstr.FromString("ten")
+			spec.TTL = &ttl
+			spec.MaxUnavailable = &max
+			spec.ChangeBudget = &api.DNSLoadBalancerChangeBudget{}
+			spec.Backend = "Route53"
+			Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf(
+				"spec.ttl", "spec.maxUnavailable", "spec.changeBudget.maxChanges", "spec.backend"))
+		})
+	})
+
+	Context("maxUnavailable", func() {
+		It("accepts positive numbers and percentages", func() {
+			for _, v := range []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("25%")} {
+				spec := &api.DNSLoadBalancerSpec{DNSName: "lb.example.org", MaxUnavailable: &v}
+				Expect(ValidateLoadBalancerSpec(spec, path)).To(BeEmpty())
+			}
+		})
+
+		It("rejects zero and negative values", func() {
+			for _, v := range []intstr.IntOrString{intstr.FromInt(0), intstr.FromString("0%"), intstr.FromInt(-1)} {
+				spec := &api.DNSLoadBalancerSpec{DNSName: "lb.example.org", MaxUnavailable: &v}
+				Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.maxUnavailable"), v.String())
+			}
+		})
+	})
+
+	Context("endpoint name template", func() {
+		var spec *api.DNSLoadBalancerSpec
+		BeforeEach(func() {
+			spec = &api.DNSLoadBalancerSpec{DNSName: "lb.example.org", Type: api.LBTYPE_BALANCED}
+		})
+
+		It("accepts subdomains of the dns name", func() {
+			spec.EndpointNameTemplate = "{{.endpoint}}.{{.dnsname}}"
+			Expect(ValidateLoadBalancerSpec(spec, path)).To(BeEmpty())
+			spec.EndpointNameTemplate = "{{.region}}-{{.cluster}}.LB.example.org."
+			Expect(ValidateLoadBalancerSpec(spec, path)).To(BeEmpty())
+		})
+
+		It("rejects names outside of the dns name", func() {
+			for _, t := range []string{"{{.endpoint}}.example.org", "{{.dnsname}}", "{{.endpoint}}-{{.dnsname}}", "{{.endpoint}}.other.org"} {
+				spec.EndpointNameTemplate = t
+				Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.endpointNameTemplate"), t)
+			}
+		})
+
+		It("rejects bad templates and names", func() {
+			for _, t := range []string{"{{.endpoint}.{{.dnsname}}", "{{.unknown}}.{{.dnsname}}", "{{.endpoint}}_1.{{.dnsname}}"} {
+				spec.EndpointNameTemplate = t
+				Expect(fields(ValidateLoadBalancerSpec(spec, path))).To(ConsistOf("spec.endpointNameTemplate"), t)
+			}
+		})
+	})
+
+	Context("endpoint", func() {
+		It("accepts ip addresses and host names", func() {
+			Expect(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", Addresses: []string{"10.0.0.1"}}, path)).To(BeEmpty())
+			Expect(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", Addresses: []string{"2001:db8::1", "10.0.0.1"}}, path)).To(BeEmpty())
+			Expect(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", CName: "ingress.example.org"}, path)).To(BeEmpty())
+		})
+
+		It("rejects bad ip addresses and cnames", func() {
+			Expect(fields(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", Addresses: []string{"10.0.0.1", "10.0.0.300"}}, path))).To(ConsistOf("spec.addresses[1]"))
+			Expect(fields(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", Addresses: []string{"10.0.0.1", "10.0.0.1"}}, path))).To(ConsistOf("spec.addresses[1]"))
+			Expect(fields(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", CName: "10.0.0.1"}, path))).To(ConsistOf("spec.cname"))
+			Expect(fields(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", CName: "-bad.example.org"}, path))).To(ConsistOf("spec.cname"))
+		})
+
+		It("requires exactly one target and a load balancer", func() {
+			Expect(fields(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{}, path))).To(ConsistOf("spec.loadbalancer", "spec.addresses"))
+			Expect(fields(ValidateLoadBalancerEndpointSpec(&api.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", Addresses: []string{"10.0.0.1"}, CName: "a.example.org"}, path))).To(ConsistOf("spec.cname"))
+		})
+	})
+
+	Context("health check policy", func() {
+		It("accepts valid specs", func() {
+			interval := metav1.Duration{Duration: 30 * time.Second}
+			Expect(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{}, path)).To(BeEmpty())
+			Expect(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{
+				Type: api.HEALTHCHECK_HTTPS, Path: "/healthz", StatusCode: 204, Headers: map[string]string{"X-Probe": "dnslb"},
+				HealthyThreshold: 2, UnhealthyThreshold: 3, Interval: &interval, TLS: &api.DNSHealthCheckTLS{Verify: true},
+			}, path)).To(BeEmpty())
+			Expect(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{Type: api.HEALTHCHECK_TCP, Port: 5432}, path)).To(BeEmpty())
+		})
+
+		It("rejects invalid http settings", func() {
+			Expect(fields(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{
+				Path: "healthz", StatusCode: 600, Headers: map[string]string{"X Probe": "dnslb"}, HealthyThreshold: 6, UnhealthyThreshold: -1,
+			}, path))).To(ConsistOf("spec.path", "spec.statusCode", "spec.headers[X Probe]", "spec.healthyThreshold", "spec.unhealthyThreshold"))
+			Expect(fields(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{Type: api.HEALTHCHECK_HTTP, TLS: &api.DNSHealthCheckTLS{}}, path))).To(ConsistOf("spec.tls"))
+			Expect(fields(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{Type: "UDP"}, path))).To(ConsistOf("spec.type"))
+		})
+
+		It("requires a port and forbids http settings for tcp", func() {
+			Expect(fields(ValidateHealthCheckPolicySpec(&api.DNSHealthCheckPolicySpec{Type: api.HEALTHCHECK_TCP, Path: "/"}, path))).To(ConsistOf("spec.port", "spec"))
+		})
+	})
+})
@@ -0,0 +1,876 @@
+// +build !ignore_autogenerated
+
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckPolicy) DeepCopyInto(out *DNSHealthCheckPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckPolicy.
+func (in *DNSHealthCheckPolicy) DeepCopy() *DNSHealthCheckPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHealthCheckPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckPolicyList) DeepCopyInto(out *DNSHealthCheckPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSHealthCheckPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckPolicyList.
+func (in *DNSHealthCheckPolicyList) DeepCopy() *DNSHealthCheckPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHealthCheckPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckPolicyRef) DeepCopyInto(out *DNSHealthCheckPolicyRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckPolicyRef.
+func (in *DNSHealthCheckPolicyRef) DeepCopy() *DNSHealthCheckPolicyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckPolicyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckPolicySpec) DeepCopyInto(out *DNSHealthCheckPolicySpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DNSHealthCheckTLS)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckPolicySpec.
+func (in *DNSHealthCheckPolicySpec) DeepCopy() *DNSHealthCheckPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckTLS) DeepCopyInto(out *DNSHealthCheckTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckTLS.
+func (in *DNSHealthCheckTLS) DeepCopy() *DNSHealthCheckTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancer) DeepCopyInto(out *DNSLoadBalancer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancer.
+func (in *DNSLoadBalancer) DeepCopy() *DNSLoadBalancer {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerAccess) DeepCopyInto(out *DNSLoadBalancerAccess) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerAccess.
+func (in *DNSLoadBalancerAccess) DeepCopy() *DNSLoadBalancerAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerActive) DeepCopyInto(out *DNSLoadBalancerActive) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerActive.
+func (in *DNSLoadBalancerActive) DeepCopy() *DNSLoadBalancerActive {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerActive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerAdaptiveTTL) DeepCopyInto(out *DNSLoadBalancerAdaptiveTTL) {
+	*out = *in
+	if in.Steady != nil {
+		in, out := &in.Steady, &out.Steady
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SettleWindow != nil {
+		in, out := &in.SettleWindow, &out.SettleWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerAdaptiveTTL.
+func (in *DNSLoadBalancerAdaptiveTTL) DeepCopy() *DNSLoadBalancerAdaptiveTTL {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerAdaptiveTTL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerCNameFlattening) DeepCopyInto(out *DNSLoadBalancerCNameFlattening) {
+	*out = *in
+	if in.LookupInterval != nil {
+		in, out := &in.LookupInterval, &out.LookupInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerCNameFlattening.
+func (in *DNSLoadBalancerCNameFlattening) DeepCopy() *DNSLoadBalancerCNameFlattening {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerCNameFlattening)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerChangeBudget) DeepCopyInto(out *DNSLoadBalancerChangeBudget) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerChangeBudget.
+func (in *DNSLoadBalancerChangeBudget) DeepCopy() *DNSLoadBalancerChangeBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerChangeBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerCluster) DeepCopyInto(out *DNSLoadBalancerCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerCluster.
+func (in *DNSLoadBalancerCluster) DeepCopy() *DNSLoadBalancerCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerClusterList) DeepCopyInto(out *DNSLoadBalancerClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSLoadBalancerCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerClusterList.
+func (in *DNSLoadBalancerClusterList) DeepCopy() *DNSLoadBalancerClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerClusterSpec) DeepCopyInto(out *DNSLoadBalancerClusterSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerClusterSpec.
+func (in *DNSLoadBalancerClusterSpec) DeepCopy() *DNSLoadBalancerClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerClusterStatus) DeepCopyInto(out *DNSLoadBalancerClusterStatus) {
+	*out = *in
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerClusterStatus.
+func (in *DNSLoadBalancerClusterStatus) DeepCopy() *DNSLoadBalancerClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerEndpoint) DeepCopyInto(out *DNSLoadBalancerEndpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerEndpoint.
+func (in *DNSLoadBalancerEndpoint) DeepCopy() *DNSLoadBalancerEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerEndpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerEndpointList) DeepCopyInto(out *DNSLoadBalancerEndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSLoadBalancerEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerEndpointList.
+func (in *DNSLoadBalancerEndpointList) DeepCopy() *DNSLoadBalancerEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerEndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerEndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerEndpointSpec) DeepCopyInto(out *DNSLoadBalancerEndpointSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerEndpointSpec.
+func (in *DNSLoadBalancerEndpointSpec) DeepCopy() *DNSLoadBalancerEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerEndpointStatus) DeepCopyInto(out *DNSLoadBalancerEndpointStatus) {
+	*out = *in
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastProbeLatency != nil {
+		in, out := &in.LastProbeLatency, &out.LastProbeLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RecentProbes != nil {
+		in, out := &in.RecentProbes, &out.RecentProbes
+		*out = make([]DNSLoadBalancerProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerEndpointStatus.
+func (in *DNSLoadBalancerEndpointStatus) DeepCopy() *DNSLoadBalancerEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerFreeze) DeepCopyInto(out *DNSLoadBalancerFreeze) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerFreeze.
+func (in *DNSLoadBalancerFreeze) DeepCopy() *DNSLoadBalancerFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerGeo) DeepCopyInto(out *DNSLoadBalancerGeo) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerGeo.
+func (in *DNSLoadBalancerGeo) DeepCopy() *DNSLoadBalancerGeo {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerGeo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerHealthCheck) DeepCopyInto(out *DNSLoadBalancerHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerHealthCheck.
+func (in *DNSLoadBalancerHealthCheck) DeepCopy() *DNSLoadBalancerHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerList) DeepCopyInto(out *DNSLoadBalancerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSLoadBalancer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerList.
+func (in *DNSLoadBalancerList) DeepCopy() *DNSLoadBalancerList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerProbe) DeepCopyInto(out *DNSLoadBalancerProbe) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerProbe.
+func (in *DNSLoadBalancerProbe) DeepCopy() *DNSLoadBalancerProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerRegion) DeepCopyInto(out *DNSLoadBalancerRegion) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerRegion.
+func (in *DNSLoadBalancerRegion) DeepCopy() *DNSLoadBalancerRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerSRV) DeepCopyInto(out *DNSLoadBalancerSRV) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerSRV.
+func (in *DNSLoadBalancerSRV) DeepCopy() *DNSLoadBalancerSRV {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerSRV)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerSpec) DeepCopyInto(out *DNSLoadBalancerSpec) {
+	*out = *in
+	out.HealthCheck = in.HealthCheck
+	if in.HealthCheckPolicyRef != nil {
+		in, out := &in.HealthCheckPolicyRef, &out.HealthCheckPolicyRef
+		*out = new(DNSHealthCheckPolicyRef)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EndpointValidityInterval != nil {
+		in, out := &in.EndpointValidityInterval, &out.EndpointValidityInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(DNSLoadBalancerAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Geo != nil {
+		in, out := &in.Geo, &out.Geo
+		*out = new(DNSLoadBalancerGeo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CNameFlattening != nil {
+		in, out := &in.CNameFlattening, &out.CNameFlattening
+		*out = new(DNSLoadBalancerCNameFlattening)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SRV != nil {
+		in, out := &in.SRV, &out.SRV
+		*out = new(DNSLoadBalancerSRV)
+		**out = **in
+	}
+	if in.AdaptiveTTL != nil {
+		in, out := &in.AdaptiveTTL, &out.AdaptiveTTL
+		*out = new(DNSLoadBalancerAdaptiveTTL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChangeBudget != nil {
+		in, out := &in.ChangeBudget, &out.ChangeBudget
+		*out = new(DNSLoadBalancerChangeBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinHealthy != nil {
+		in, out := &in.MinHealthy, &out.MinHealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerSpec.
+func (in *DNSLoadBalancerSpec) DeepCopy() *DNSLoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerStatus) DeepCopyInto(out *DNSLoadBalancerStatus) {
+	*out = *in
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]DNSLoadBalancerActive, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]DNSLoadBalancerRegion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastChange != nil {
+		in, out := &in.LastChange, &out.LastChange
+		*out = (*in).DeepCopy()
+	}
+	if in.Frozen != nil {
+		in, out := &in.Frozen, &out.Frozen
+		*out = new(DNSLoadBalancerFreeze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Throttled != nil {
+		in, out := &in.Throttled, &out.Throttled
+		*out = new(DNSLoadBalancerThrottle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerStatus.
+func (in *DNSLoadBalancerStatus) DeepCopy() *DNSLoadBalancerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerThrottle) DeepCopyInto(out *DNSLoadBalancerThrottle) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerThrottle.
+func (in *DNSLoadBalancerThrottle) DeepCopy() *DNSLoadBalancerThrottle {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerThrottle)
+	in.DeepCopyInto(out)
+	return out
+}
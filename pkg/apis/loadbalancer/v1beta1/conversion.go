@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// ANNOTATION_ADDRESSES keeps the addresses of a v1 endpoint that cannot be
+// represented by the single ipaddress of a v1beta1 endpoint.
+const ANNOTATION_ADDRESSES = GroupName + "/addresses"
+
+// ANNOTATION_HEALTHCHECK_POLICY keeps the health check policy reference
+// (<namespace>/<name> or <name>) of a v1 load balancer, which is unknown
+// to v1beta1.
+const ANNOTATION_HEALTHCHECK_POLICY = GroupName + "/healthcheck-policy"
+
+// Convert converts the JSON representation of a resource of the API
+// group to the given api version (v1beta1 or v1).
+func Convert(data []byte, apiVersion string) ([]byte, error) {
+	meta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, err
+	}
+	if meta.APIVersion == apiVersion {
+		return data, nil
+	}
+
+	var out interface{}
+	var err error
+	switch meta.APIVersion + "/" + apiVersion {
+	case SchemeGroupVersion.String() + "/" + v1.SchemeGroupVersion.String():
+		out, err = toV1(meta.Kind, data)
+	case v1.SchemeGroupVersion.String() + "/" + SchemeGroupVersion.String():
+		out, err = fromV1(meta.Kind, data)
+	default:
+		return nil, fmt.Errorf("cannot convert %s from %s to %s", meta.Kind, meta.APIVersion, apiVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+func toV1(kind string, data []byte) (interface{}, error) {
+	switch kind {
+	case LoadBalancerResourceKind:
+		in := &DNSLoadBalancer{}
+		if err := json.Unmarshal(data, in); err != nil {
+			return nil, err
+		}
+		return ConvertLoadBalancerToV1(in)
+	case LoadBalancerEndpointResourceKind:
+		in := &DNSLoadBalancerEndpoint{}
+		if err := json.Unmarshal(data, in); err != nil {
+			return nil, err
+		}
+		return ConvertEndpointToV1(in)
+	case LoadBalancerClusterResourceKind:
+		out := &v1.DNSLoadBalancerCluster{}
+		return out, convert(data, out, v1.SchemeGroupVersion.WithKind(kind))
+	}
+	return nil, fmt.Errorf("unknown kind %q", kind)
+}
+
+func fromV1(kind string, data []byte) (interface{}, error) {
+	switch kind {
+	case LoadBalancerResourceKind:
+		in := &v1.DNSLoadBalancer{}
+		if err := json.Unmarshal(data, in); err != nil {
+			return nil, err
+		}
+		return ConvertLoadBalancerFromV1(in)
+	case LoadBalancerEndpointResourceKind:
+		in := &v1.DNSLoadBalancerEndpoint{}
+		if err := json.Unmarshal(data, in); err != nil {
+			return nil, err
+		}
+		return ConvertEndpointFromV1(in)
+	case LoadBalancerClusterResourceKind:
+		out := &DNSLoadBalancerCluster{}
+		return out, convert(data, out, SchemeGroupVersion.WithKind(kind))
+	}
+	return nil, fmt.Errorf("unknown kind %q", kind)
+}
+
+// ConvertLoadBalancerToV1 converts a v1beta1 load balancer to v1.
+// The deprecated singleton flag is migrated to the type.
+func ConvertLoadBalancerToV1(in *DNSLoadBalancer) (*v1.DNSLoadBalancer, error) {
+	out := &v1.DNSLoadBalancer{}
+	if err := convert(in, out, v1.SchemeGroupVersion.WithKind(v1.LoadBalancerResourceKind)); err != nil {
+		return nil, err
+	}
+	if in.Spec.Type == "" && in.Spec.Singleton != nil && *in.Spec.Singleton {
+		out.Spec.Type = v1.LBTYPE_EXCLUSIVE
+	}
+	out.Spec.HealthCheck = v1.DNSLoadBalancerHealthCheck{
+		Path:       in.Spec.HealthPath,
+		StatusCode: in.Spec.StatusCode,
+	}
+	if kept, ok := out.Annotations[ANNOTATION_HEALTHCHECK_POLICY]; ok {
+		if kept != "" {
+			out.Spec.HealthCheckPolicyRef = &v1.DNSHealthCheckPolicyRef{Name: kept}
+			if parts := strings.SplitN(kept, "/", 2); len(parts) == 2 {
+				out.Spec.HealthCheckPolicyRef = &v1.DNSHealthCheckPolicyRef{Namespace: parts[0], Name: parts[1]}
+			}
+		}
+		removeAnnotation(&out.ObjectMeta, ANNOTATION_HEALTHCHECK_POLICY)
+	}
+	return out, nil
+}
+
+// ConvertLoadBalancerFromV1 converts a v1 load balancer to v1beta1.
+func ConvertLoadBalancerFromV1(in *v1.DNSLoadBalancer) (*DNSLoadBalancer, error) {
+	out := &DNSLoadBalancer{}
+	if err := convert(in, out, SchemeGroupVersion.WithKind(LoadBalancerResourceKind)); err != nil {
+		return nil, err
+	}
+	out.Spec.HealthPath = in.Spec.HealthCheck.Path
+	out.Spec.StatusCode = in.Spec.HealthCheck.StatusCode
+	if ref := in.Spec.HealthCheckPolicyRef; ref != nil {
+		kept := ref.Name
+		if ref.Namespace != "" {
+			kept = ref.Namespace + "/" + ref.Name
+		}
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[ANNOTATION_HEALTHCHECK_POLICY] = kept
+	}
+	return out, nil
+}
+
+// ConvertEndpointToV1 converts a v1beta1 endpoint to v1. Addresses kept
+// by a former conversion from v1 are restored, as long as the ipaddress
+// has not been changed.
+func ConvertEndpointToV1(in *DNSLoadBalancerEndpoint) (*v1.DNSLoadBalancerEndpoint, error) {
+	out := &v1.DNSLoadBalancerEndpoint{}
+	if err := convert(in, out, v1.SchemeGroupVersion.WithKind(v1.LoadBalancerEndpointResourceKind)); err != nil {
+		return nil, err
+	}
+	if in.Spec.IPAddress != "" {
+		out.Spec.Addresses = []string{in.Spec.IPAddress}
+		if kept := strings.Split(in.GetAnnotations()[ANNOTATION_ADDRESSES], ","); len(kept) > 1 && kept[0] == in.Spec.IPAddress {
+			out.Spec.Addresses = kept
+		}
+	}
+	removeAnnotation(&out.ObjectMeta, ANNOTATION_ADDRESSES)
+	return out, nil
+}
+
+// ConvertEndpointFromV1 converts a v1 endpoint to v1beta1. The ipaddress
+// is the first address, multiple addresses are kept in an annotation.
+func ConvertEndpointFromV1(in *v1.DNSLoadBalancerEndpoint) (*DNSLoadBalancerEndpoint, error) {
+	out := &DNSLoadBalancerEndpoint{}
+	if err := convert(in, out, SchemeGroupVersion.WithKind(LoadBalancerEndpointResourceKind)); err != nil {
+		return nil, err
+	}
+	if len(in.Spec.Addresses) > 0 {
+		out.Spec.IPAddress = in.Spec.Addresses[0]
+	}
+	if len(in.Spec.Addresses) > 1 {
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[ANNOTATION_ADDRESSES] = strings.Join(in.Spec.Addresses, ",")
+	}
+	return out, nil
+}
+
+// removeAnnotation removes an annotation kept by a former conversion.
+func removeAnnotation(meta *metav1.ObjectMeta, key string) {
+	if _, ok := meta.Annotations[key]; ok {
+		delete(meta.Annotations, key)
+		if len(meta.Annotations) == 0 {
+			meta.Annotations = nil
+		}
+	}
+}
+
+// convert copies the fields with identical JSON representation and sets
+// the type of the target object.
+func convert(in interface{}, out runtime.Object, gvk schema.GroupVersionKind) error {
+	data, ok := in.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return err
+	}
+	out.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
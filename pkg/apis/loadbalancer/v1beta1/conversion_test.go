@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1_test
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("conversion", func() {
+	meta := metav1.ObjectMeta{Name: "a", Namespace: "default"}
+
+	Context("load balancer", func() {
+		It("migrates singleton and health check", func() {
+			singleton := true
+			in := &DNSLoadBalancer{ObjectMeta: meta, Spec: DNSLoadBalancerSpec{DNSName: "lb.example.org", HealthPath: "/healthz", StatusCode: 204, Singleton: &singleton}}
+			out, err := ConvertLoadBalancerToV1(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.APIVersion).To(Equal("loadbalancer.gardener.cloud/v1"))
+			Expect(out.Name).To(Equal("a"))
+			Expect(out.Spec.DNSName).To(Equal("lb.example.org"))
+			Expect(out.Spec.Type).To(Equal(v1.LBTYPE_EXCLUSIVE))
+			Expect(out.Spec.HealthCheck).To(Equal(v1.DNSLoadBalancerHealthCheck{Path: "/healthz", StatusCode: 204}))
+		})
+
+		It("converts back", func() {
+			in := &v1.DNSLoadBalancer{ObjectMeta: meta, Spec: v1.DNSLoadBalancerSpec{DNSName: "lb.example.org", Type: v1.LBTYPE_GEO, HealthCheck: v1.DNSLoadBalancerHealthCheck{Path: "/healthz", StatusCode: 204}}}
+			out, err := ConvertLoadBalancerFromV1(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.APIVersion).To(Equal("loadbalancer.gardener.cloud/v1beta1"))
+			Expect(out.Spec).To(Equal(DNSLoadBalancerSpec{DNSName: "lb.example.org", Type: LBTYPE_GEO, HealthPath: "/healthz", StatusCode: 204}))
+		})
+
+		It("keeps the health check policy reference in a round trip", func() {
+			in := &v1.DNSLoadBalancer{ObjectMeta: meta, Spec: v1.DNSLoadBalancerSpec{DNSName: "lb.example.org", HealthCheckPolicyRef: &v1.DNSHealthCheckPolicyRef{Namespace: "probes", Name: "https"}}}
+			beta, err := ConvertLoadBalancerFromV1(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(beta.Annotations).To(HaveKeyWithValue(ANNOTATION_HEALTHCHECK_POLICY, "probes/https"))
+
+			out, err := ConvertLoadBalancerToV1(beta)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Spec.HealthCheckPolicyRef).To(Equal(in.Spec.HealthCheckPolicyRef))
+			Expect(out.Annotations).To(BeNil())
+		})
+	})
+
+	Context("endpoint", func() {
+		It("keeps multiple addresses in a round trip", func() {
+			in := &v1.DNSLoadBalancerEndpoint{ObjectMeta: meta, Spec: v1.DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", Addresses: []string{"10.0.0.1", "10.0.0.2"}}}
+			beta, err := ConvertEndpointFromV1(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(beta.Spec.IPAddress).To(Equal("10.0.0.1"))
+			Expect(beta.Annotations).To(HaveKeyWithValue(ANNOTATION_ADDRESSES, "10.0.0.1,10.0.0.2"))
+
+			out, err := ConvertEndpointToV1(beta)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Spec.Addresses).To(Equal(in.Spec.Addresses))
+			Expect(out.Annotations).To(BeNil())
+		})
+
+		It("drops kept addresses if the ipaddress has been changed", func() {
+			in := &DNSLoadBalancerEndpoint{ObjectMeta: meta, Spec: DNSLoadBalancerEndpointSpec{LoadBalancer: "lb", IPAddress: "10.0.0.3"}}
+			in.Annotations = map[string]string{ANNOTATION_ADDRESSES: "10.0.0.1,10.0.0.2", "other": "x"}
+			out, err := ConvertEndpointToV1(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Spec.Addresses).To(Equal([]string{"10.0.0.3"}))
+			Expect(out.Annotations).To(Equal(map[string]string{"other": "x"}))
+		})
+	})
+
+	Context("json", func() {
+		It("converts between versions", func() {
+			data := []byte(`{"apiVersion":"loadbalancer.gardener.cloud/v1beta1","kind":"DNSLoadBalancerEndpoint","metadata":{"name":"a"},"spec":{"loadbalancer":"lb","ipaddress":"10.0.0.1"}}`)
+			conv, err := Convert(data, "loadbalancer.gardener.cloud/v1")
+			Expect(err).NotTo(HaveOccurred())
+			out := &v1.DNSLoadBalancerEndpoint{}
+			Expect(json.Unmarshal(conv, out)).To(Succeed())
+			Expect(out.Kind).To(Equal("DNSLoadBalancerEndpoint"))
+			Expect(out.APIVersion).To(Equal("loadbalancer.gardener.cloud/v1"))
+			Expect(out.Spec.Addresses).To(Equal([]string{"10.0.0.1"}))
+		})
+
+		It("keeps objects of the desired version", func() {
+			data := []byte(`{"apiVersion":"loadbalancer.gardener.cloud/v1","kind":"DNSLoadBalancerCluster"}`)
+			Expect(Convert(data, "loadbalancer.gardener.cloud/v1")).To(Equal(data))
+		})
+
+		It("rejects unknown versions and kinds", func() {
+			_, err := Convert([]byte(`{"apiVersion":"loadbalancer.gardener.cloud/v2","kind":"DNSLoadBalancer"}`), "loadbalancer.gardener.cloud/v1")
+			Expect(err).To(HaveOccurred())
+			_, err = Convert([]byte(`{"apiVersion":"loadbalancer.gardener.cloud/v1","kind":"Other"}`), "loadbalancer.gardener.cloud/v1beta1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+const DEFAULT_STATUS_CODE = 200
+const DEFAULT_HEALTH_PATH = "/"
+
+// SetDefaultsDNSLoadBalancerSpec fills in the defaults of a load balancer
+// spec. The deprecated singleton flag is migrated to the type.
+func SetDefaultsDNSLoadBalancerSpec(spec *DNSLoadBalancerSpec) {
+	if spec.Type == "" {
+		if spec.Singleton != nil && *spec.Singleton {
+			spec.Type = LBTYPE_EXCLUSIVE
+		} else {
+			spec.Type = LBTYPE_BALANCED
+		}
+		spec.Singleton = nil
+	}
+	if spec.StatusCode == 0 {
+		spec.StatusCode = DEFAULT_STATUS_CODE
+	}
+	if spec.HealthPath == "" {
+		spec.HealthPath = DEFAULT_HEALTH_PATH
+	}
+}
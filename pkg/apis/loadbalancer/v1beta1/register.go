@@ -22,6 +22,9 @@ const (
 
 	LoadBalancerEndpointResourceKind   = "DNSLoadBalancerEndpoint"
 	LoadBalancerEndpointResourcePlural = "dnsloadbalancerendpoints"
+
+	LoadBalancerClusterResourceKind   = "DNSLoadBalancerCluster"
+	LoadBalancerClusterResourcePlural = "dnsloadbalancerclusters"
 )
 
 var (
@@ -31,11 +34,13 @@ var (
 	SchemeGroupVersion          = schema.GroupVersion{Group: loadbalancer.GroupName, Version: Version}
 	LoadBalancerCRDName         = LoadBalancerResourcePlural + "." + loadbalancer.GroupName
 	LoadBalancerEndpointCRDName = LoadBalancerEndpointResourcePlural + "." + loadbalancer.GroupName
+	LoadBalancerClusterCRDName  = LoadBalancerClusterResourcePlural + "." + loadbalancer.GroupName
 )
 
 var (
-	LoadBalancerGroupKind         = schema.GroupKind{GroupName, LoadBalancerResourceKind}
-	LoadBalancerEndpointGroupKind = schema.GroupKind{GroupName, LoadBalancerEndpointResourceKind}
+	LoadBalancerGroupKind         = schema.GroupKind{Group: GroupName, Kind: LoadBalancerResourceKind}
+	LoadBalancerEndpointGroupKind = schema.GroupKind{Group: GroupName, Kind: LoadBalancerEndpointResourceKind}
+	LoadBalancerClusterGroupKind  = schema.GroupKind{Group: GroupName, Kind: LoadBalancerClusterResourceKind}
 )
 
 // Resource gets an LoadBalancer GroupResource for a specified resource
@@ -50,6 +55,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 		&DNSLoadBalancerList{},
 		&DNSLoadBalancerEndpoint{},
 		&DNSLoadBalancerEndpointList{},
+		&DNSLoadBalancerCluster{},
+		&DNSLoadBalancerClusterList{},
 	)
 	metav1.AddToGroupVersion(s, SchemeGroupVersion)
 	return nil
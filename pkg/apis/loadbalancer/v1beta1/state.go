@@ -14,6 +14,7 @@ const STATE_PENDING = "Pending"
 
 const STATE_UNREACHABLE = "Unreachable"
 const STATE_HEALTHY = "Healthy"
+const STATE_DEGRADED = "Degraded"
 
 // endpoint states
 
@@ -12,8 +12,26 @@ package v1beta1
 import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSLoadBalancer) DeepCopyInto(out *DNSLoadBalancer) {
 	*out = *in
@@ -42,9 +60,40 @@ func (in *DNSLoadBalancer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerAccess) DeepCopyInto(out *DNSLoadBalancerAccess) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerAccess.
+func (in *DNSLoadBalancerAccess) DeepCopy() *DNSLoadBalancerAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSLoadBalancerActive) DeepCopyInto(out *DNSLoadBalancerActive) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -58,6 +107,178 @@ func (in *DNSLoadBalancerActive) DeepCopy() *DNSLoadBalancerActive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerAdaptiveTTL) DeepCopyInto(out *DNSLoadBalancerAdaptiveTTL) {
+	*out = *in
+	if in.Steady != nil {
+		in, out := &in.Steady, &out.Steady
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SettleWindow != nil {
+		in, out := &in.SettleWindow, &out.SettleWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerAdaptiveTTL.
+func (in *DNSLoadBalancerAdaptiveTTL) DeepCopy() *DNSLoadBalancerAdaptiveTTL {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerAdaptiveTTL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerCNameFlattening) DeepCopyInto(out *DNSLoadBalancerCNameFlattening) {
+	*out = *in
+	if in.LookupInterval != nil {
+		in, out := &in.LookupInterval, &out.LookupInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerCNameFlattening.
+func (in *DNSLoadBalancerCNameFlattening) DeepCopy() *DNSLoadBalancerCNameFlattening {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerCNameFlattening)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerChangeBudget) DeepCopyInto(out *DNSLoadBalancerChangeBudget) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerChangeBudget.
+func (in *DNSLoadBalancerChangeBudget) DeepCopy() *DNSLoadBalancerChangeBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerChangeBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerCluster) DeepCopyInto(out *DNSLoadBalancerCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerCluster.
+func (in *DNSLoadBalancerCluster) DeepCopy() *DNSLoadBalancerCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerClusterList) DeepCopyInto(out *DNSLoadBalancerClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSLoadBalancerCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerClusterList.
+func (in *DNSLoadBalancerClusterList) DeepCopy() *DNSLoadBalancerClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLoadBalancerClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerClusterSpec) DeepCopyInto(out *DNSLoadBalancerClusterSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerClusterSpec.
+func (in *DNSLoadBalancerClusterSpec) DeepCopy() *DNSLoadBalancerClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerClusterStatus) DeepCopyInto(out *DNSLoadBalancerClusterStatus) {
+	*out = *in
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerClusterStatus.
+func (in *DNSLoadBalancerClusterStatus) DeepCopy() *DNSLoadBalancerClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSLoadBalancerEndpoint) DeepCopyInto(out *DNSLoadBalancerEndpoint) {
 	*out = *in
@@ -152,6 +373,33 @@ func (in *DNSLoadBalancerEndpointStatus) DeepCopyInto(out *DNSLoadBalancerEndpoi
 		in, out := &in.ValidUntil, &out.ValidUntil
 		*out = (*in).DeepCopy()
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastProbeLatency != nil {
+		in, out := &in.LastProbeLatency, &out.LastProbeLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RecentProbes != nil {
+		in, out := &in.RecentProbes, &out.RecentProbes
+		*out = make([]DNSLoadBalancerProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -165,6 +413,63 @@ func (in *DNSLoadBalancerEndpointStatus) DeepCopy() *DNSLoadBalancerEndpointStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerFreeze) DeepCopyInto(out *DNSLoadBalancerFreeze) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerFreeze.
+func (in *DNSLoadBalancerFreeze) DeepCopy() *DNSLoadBalancerFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerGeo) DeepCopyInto(out *DNSLoadBalancerGeo) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerGeo.
+func (in *DNSLoadBalancerGeo) DeepCopy() *DNSLoadBalancerGeo {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerGeo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSLoadBalancerList) DeepCopyInto(out *DNSLoadBalancerList) {
 	*out = *in
@@ -198,6 +503,60 @@ func (in *DNSLoadBalancerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerProbe) DeepCopyInto(out *DNSLoadBalancerProbe) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerProbe.
+func (in *DNSLoadBalancerProbe) DeepCopy() *DNSLoadBalancerProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerRegion) DeepCopyInto(out *DNSLoadBalancerRegion) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerRegion.
+func (in *DNSLoadBalancerRegion) DeepCopy() *DNSLoadBalancerRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerSRV) DeepCopyInto(out *DNSLoadBalancerSRV) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerSRV.
+func (in *DNSLoadBalancerSRV) DeepCopy() *DNSLoadBalancerSRV {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerSRV)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSLoadBalancerSpec) DeepCopyInto(out *DNSLoadBalancerSpec) {
 	*out = *in
@@ -216,6 +575,46 @@ func (in *DNSLoadBalancerSpec) DeepCopyInto(out *DNSLoadBalancerSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(DNSLoadBalancerAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Geo != nil {
+		in, out := &in.Geo, &out.Geo
+		*out = new(DNSLoadBalancerGeo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CNameFlattening != nil {
+		in, out := &in.CNameFlattening, &out.CNameFlattening
+		*out = new(DNSLoadBalancerCNameFlattening)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SRV != nil {
+		in, out := &in.SRV, &out.SRV
+		*out = new(DNSLoadBalancerSRV)
+		**out = **in
+	}
+	if in.AdaptiveTTL != nil {
+		in, out := &in.AdaptiveTTL, &out.AdaptiveTTL
+		*out = new(DNSLoadBalancerAdaptiveTTL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChangeBudget != nil {
+		in, out := &in.ChangeBudget, &out.ChangeBudget
+		*out = new(DNSLoadBalancerChangeBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinHealthy != nil {
+		in, out := &in.MinHealthy, &out.MinHealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -245,7 +644,49 @@ func (in *DNSLoadBalancerStatus) DeepCopyInto(out *DNSLoadBalancerStatus) {
 	if in.Active != nil {
 		in, out := &in.Active, &out.Active
 		*out = make([]DNSLoadBalancerActive, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]DNSLoadBalancerRegion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastChange != nil {
+		in, out := &in.LastChange, &out.LastChange
+		*out = (*in).DeepCopy()
+	}
+	if in.Frozen != nil {
+		in, out := &in.Frozen, &out.Frozen
+		*out = new(DNSLoadBalancerFreeze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Throttled != nil {
+		in, out := &in.Throttled, &out.Throttled
+		*out = new(DNSLoadBalancerThrottle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -259,3 +700,25 @@ func (in *DNSLoadBalancerStatus) DeepCopy() *DNSLoadBalancerStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLoadBalancerThrottle) DeepCopyInto(out *DNSLoadBalancerThrottle) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLoadBalancerThrottle.
+func (in *DNSLoadBalancerThrottle) DeepCopy() *DNSLoadBalancerThrottle {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLoadBalancerThrottle)
+	in.DeepCopyInto(out)
+	return out
+}
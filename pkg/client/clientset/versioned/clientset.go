@@ -8,6 +8,7 @@ SPDX-License-Identifier: Apache-2.0
 package versioned
 
 import (
+	loadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/typed/loadbalancer/v1"
 	loadbalancerv1beta1 "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/typed/loadbalancer/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -17,8 +18,9 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	LoadbalancerV1beta1() loadbalancerv1beta1.LoadbalancerV1beta1Interface
+	LoadbalancerV1() loadbalancerv1.LoadbalancerV1Interface
 	// Deprecated: please explicitly pick a version if possible.
-	Loadbalancer() loadbalancerv1beta1.LoadbalancerV1beta1Interface
+	Loadbalancer() loadbalancerv1.LoadbalancerV1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -26,6 +28,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	loadbalancerV1beta1 *loadbalancerv1beta1.LoadbalancerV1beta1Client
+	loadbalancerV1      *loadbalancerv1.LoadbalancerV1Client
 }
 
 // LoadbalancerV1beta1 retrieves the LoadbalancerV1beta1Client
@@ -33,10 +36,15 @@ func (c *Clientset) LoadbalancerV1beta1() loadbalancerv1beta1.LoadbalancerV1beta
 	return c.loadbalancerV1beta1
 }
 
+// LoadbalancerV1 retrieves the LoadbalancerV1Client
+func (c *Clientset) LoadbalancerV1() loadbalancerv1.LoadbalancerV1Interface {
+	return c.loadbalancerV1
+}
+
 // Deprecated: Loadbalancer retrieves the default version of LoadbalancerClient.
 // Please explicitly pick a version.
-func (c *Clientset) Loadbalancer() loadbalancerv1beta1.LoadbalancerV1beta1Interface {
-	return c.loadbalancerV1beta1
+func (c *Clientset) Loadbalancer() loadbalancerv1.LoadbalancerV1Interface {
+	return c.loadbalancerV1
 }
 
 // Discovery retrieves the DiscoveryClient
@@ -59,6 +67,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.loadbalancerV1, err = loadbalancerv1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -72,6 +84,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.loadbalancerV1beta1 = loadbalancerv1beta1.NewForConfigOrDie(c)
+	cs.loadbalancerV1 = loadbalancerv1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -81,6 +94,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.loadbalancerV1beta1 = loadbalancerv1beta1.New(c)
+	cs.loadbalancerV1 = loadbalancerv1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -9,6 +9,8 @@ package fake
 
 import (
 	clientset "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned"
+	loadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/typed/loadbalancer/v1"
+	fakeloadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/typed/loadbalancer/v1/fake"
 	loadbalancerv1beta1 "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/typed/loadbalancer/v1beta1"
 	fakeloadbalancerv1beta1 "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/typed/loadbalancer/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -65,7 +67,12 @@ func (c *Clientset) LoadbalancerV1beta1() loadbalancerv1beta1.LoadbalancerV1beta
 	return &fakeloadbalancerv1beta1.FakeLoadbalancerV1beta1{Fake: &c.Fake}
 }
 
-// Loadbalancer retrieves the LoadbalancerV1beta1Client
-func (c *Clientset) Loadbalancer() loadbalancerv1beta1.LoadbalancerV1beta1Interface {
-	return &fakeloadbalancerv1beta1.FakeLoadbalancerV1beta1{Fake: &c.Fake}
+// LoadbalancerV1 retrieves the LoadbalancerV1Client
+func (c *Clientset) LoadbalancerV1() loadbalancerv1.LoadbalancerV1Interface {
+	return &fakeloadbalancerv1.FakeLoadbalancerV1{Fake: &c.Fake}
+}
+
+// Loadbalancer retrieves the LoadbalancerV1Client
+func (c *Clientset) Loadbalancer() loadbalancerv1.LoadbalancerV1Interface {
+	return &fakeloadbalancerv1.FakeLoadbalancerV1{Fake: &c.Fake}
 }
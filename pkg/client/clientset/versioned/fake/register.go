@@ -8,6 +8,7 @@ SPDX-License-Identifier: Apache-2.0
 package fake
 
 import (
+	loadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	loadbalancerv1beta1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -21,6 +22,7 @@ var codecs = serializer.NewCodecFactory(scheme)
 var parameterCodec = runtime.NewParameterCodec(scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
 	loadbalancerv1beta1.AddToScheme,
+	loadbalancerv1.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
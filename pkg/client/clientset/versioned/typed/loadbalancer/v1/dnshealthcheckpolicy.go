@@ -0,0 +1,146 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	scheme "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// DNSHealthCheckPoliciesGetter has a method to return a DNSHealthCheckPolicyInterface.
+// A group's client should implement this interface.
+type DNSHealthCheckPoliciesGetter interface {
+	DNSHealthCheckPolicies(namespace string) DNSHealthCheckPolicyInterface
+}
+
+// DNSHealthCheckPolicyInterface has methods to work with DNSHealthCheckPolicy resources.
+type DNSHealthCheckPolicyInterface interface {
+	Create(*v1.DNSHealthCheckPolicy) (*v1.DNSHealthCheckPolicy, error)
+	Update(*v1.DNSHealthCheckPolicy) (*v1.DNSHealthCheckPolicy, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.DNSHealthCheckPolicy, error)
+	List(opts metav1.ListOptions) (*v1.DNSHealthCheckPolicyList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.DNSHealthCheckPolicy, err error)
+	DNSHealthCheckPolicyExpansion
+}
+
+// dNSHealthCheckPolicies implements DNSHealthCheckPolicyInterface
+type dNSHealthCheckPolicies struct {
+	client rest.Interface
+	ns     string
+}
+
+// newDNSHealthCheckPolicies returns a DNSHealthCheckPolicies
+func newDNSHealthCheckPolicies(c *LoadbalancerV1Client, namespace string) *dNSHealthCheckPolicies {
+	return &dNSHealthCheckPolicies{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the dNSHealthCheckPolicy, and returns the corresponding dNSHealthCheckPolicy object, and an error if there is any.
+func (c *dNSHealthCheckPolicies) Get(name string, options metav1.GetOptions) (result *v1.DNSHealthCheckPolicy, err error) {
+	result = &v1.DNSHealthCheckPolicy{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DNSHealthCheckPolicies that match those selectors.
+func (c *dNSHealthCheckPolicies) List(opts metav1.ListOptions) (result *v1.DNSHealthCheckPolicyList, err error) {
+	result = &v1.DNSHealthCheckPolicyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested dNSHealthCheckPolicies.
+func (c *dNSHealthCheckPolicies) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a dNSHealthCheckPolicy and creates it.  Returns the server's representation of the dNSHealthCheckPolicy, and an error, if there is any.
+func (c *dNSHealthCheckPolicies) Create(dNSHealthCheckPolicy *v1.DNSHealthCheckPolicy) (result *v1.DNSHealthCheckPolicy, err error) {
+	result = &v1.DNSHealthCheckPolicy{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		Body(dNSHealthCheckPolicy).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a dNSHealthCheckPolicy and updates it. Returns the server's representation of the dNSHealthCheckPolicy, and an error, if there is any.
+func (c *dNSHealthCheckPolicies) Update(dNSHealthCheckPolicy *v1.DNSHealthCheckPolicy) (result *v1.DNSHealthCheckPolicy, err error) {
+	result = &v1.DNSHealthCheckPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		Name(dNSHealthCheckPolicy.Name).
+		Body(dNSHealthCheckPolicy).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the dNSHealthCheckPolicy and deletes it. Returns an error if one occurs.
+func (c *dNSHealthCheckPolicies) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *dNSHealthCheckPolicies) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched dNSHealthCheckPolicy.
+func (c *dNSHealthCheckPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.DNSHealthCheckPolicy, err error) {
+	result = &v1.DNSHealthCheckPolicy{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("dnshealthcheckpolicies").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,117 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	loadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDNSHealthCheckPolicies implements DNSHealthCheckPolicyInterface
+type FakeDNSHealthCheckPolicies struct {
+	Fake *FakeLoadbalancerV1
+	ns   string
+}
+
+var dnshealthcheckpoliciesResource = schema.GroupVersionResource{Group: "loadbalancer.gardener.cloud", Version: "v1", Resource: "dnshealthcheckpolicies"}
+
+var dnshealthcheckpoliciesKind = schema.GroupVersionKind{Group: "loadbalancer.gardener.cloud", Version: "v1", Kind: "DNSHealthCheckPolicy"}
+
+// Get takes name of the dNSHealthCheckPolicy, and returns the corresponding dNSHealthCheckPolicy object, and an error if there is any.
+func (c *FakeDNSHealthCheckPolicies) Get(name string, options v1.GetOptions) (result *loadbalancerv1.DNSHealthCheckPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(dnshealthcheckpoliciesResource, c.ns, name), &loadbalancerv1.DNSHealthCheckPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSHealthCheckPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of DNSHealthCheckPolicies that match those selectors.
+func (c *FakeDNSHealthCheckPolicies) List(opts v1.ListOptions) (result *loadbalancerv1.DNSHealthCheckPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(dnshealthcheckpoliciesResource, dnshealthcheckpoliciesKind, c.ns, opts), &loadbalancerv1.DNSHealthCheckPolicyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &loadbalancerv1.DNSHealthCheckPolicyList{ListMeta: obj.(*loadbalancerv1.DNSHealthCheckPolicyList).ListMeta}
+	for _, item := range obj.(*loadbalancerv1.DNSHealthCheckPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dNSHealthCheckPolicies.
+func (c *FakeDNSHealthCheckPolicies) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(dnshealthcheckpoliciesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a dNSHealthCheckPolicy and creates it.  Returns the server's representation of the dNSHealthCheckPolicy, and an error, if there is any.
+func (c *FakeDNSHealthCheckPolicies) Create(dNSHealthCheckPolicy *loadbalancerv1.DNSHealthCheckPolicy) (result *loadbalancerv1.DNSHealthCheckPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(dnshealthcheckpoliciesResource, c.ns, dNSHealthCheckPolicy), &loadbalancerv1.DNSHealthCheckPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSHealthCheckPolicy), err
+}
+
+// Update takes the representation of a dNSHealthCheckPolicy and updates it. Returns the server's representation of the dNSHealthCheckPolicy, and an error, if there is any.
+func (c *FakeDNSHealthCheckPolicies) Update(dNSHealthCheckPolicy *loadbalancerv1.DNSHealthCheckPolicy) (result *loadbalancerv1.DNSHealthCheckPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(dnshealthcheckpoliciesResource, c.ns, dNSHealthCheckPolicy), &loadbalancerv1.DNSHealthCheckPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSHealthCheckPolicy), err
+}
+
+// Delete takes name of the dNSHealthCheckPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeDNSHealthCheckPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(dnshealthcheckpoliciesResource, c.ns, name), &loadbalancerv1.DNSHealthCheckPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDNSHealthCheckPolicies) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(dnshealthcheckpoliciesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &loadbalancerv1.DNSHealthCheckPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dNSHealthCheckPolicy.
+func (c *FakeDNSHealthCheckPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *loadbalancerv1.DNSHealthCheckPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnshealthcheckpoliciesResource, c.ns, name, data, subresources...), &loadbalancerv1.DNSHealthCheckPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSHealthCheckPolicy), err
+}
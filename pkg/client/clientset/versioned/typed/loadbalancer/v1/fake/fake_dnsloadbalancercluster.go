@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	loadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDNSLoadBalancerClusters implements DNSLoadBalancerClusterInterface
+type FakeDNSLoadBalancerClusters struct {
+	Fake *FakeLoadbalancerV1
+}
+
+var dnsloadbalancerclustersResource = schema.GroupVersionResource{Group: "loadbalancer.gardener.cloud", Version: "v1", Resource: "dnsloadbalancerclusters"}
+
+var dnsloadbalancerclustersKind = schema.GroupVersionKind{Group: "loadbalancer.gardener.cloud", Version: "v1", Kind: "DNSLoadBalancerCluster"}
+
+// Get takes name of the dNSLoadBalancerCluster, and returns the corresponding dNSLoadBalancerCluster object, and an error if there is any.
+func (c *FakeDNSLoadBalancerClusters) Get(name string, options v1.GetOptions) (result *loadbalancerv1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(dnsloadbalancerclustersResource, name), &loadbalancerv1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSLoadBalancerCluster), err
+}
+
+// List takes label and field selectors, and returns the list of DNSLoadBalancerClusters that match those selectors.
+func (c *FakeDNSLoadBalancerClusters) List(opts v1.ListOptions) (result *loadbalancerv1.DNSLoadBalancerClusterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(dnsloadbalancerclustersResource, dnsloadbalancerclustersKind, opts), &loadbalancerv1.DNSLoadBalancerClusterList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &loadbalancerv1.DNSLoadBalancerClusterList{ListMeta: obj.(*loadbalancerv1.DNSLoadBalancerClusterList).ListMeta}
+	for _, item := range obj.(*loadbalancerv1.DNSLoadBalancerClusterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dNSLoadBalancerClusters.
+func (c *FakeDNSLoadBalancerClusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(dnsloadbalancerclustersResource, opts))
+}
+
+// Create takes the representation of a dNSLoadBalancerCluster and creates it.  Returns the server's representation of the dNSLoadBalancerCluster, and an error, if there is any.
+func (c *FakeDNSLoadBalancerClusters) Create(dNSLoadBalancerCluster *loadbalancerv1.DNSLoadBalancerCluster) (result *loadbalancerv1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(dnsloadbalancerclustersResource, dNSLoadBalancerCluster), &loadbalancerv1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSLoadBalancerCluster), err
+}
+
+// Update takes the representation of a dNSLoadBalancerCluster and updates it. Returns the server's representation of the dNSLoadBalancerCluster, and an error, if there is any.
+func (c *FakeDNSLoadBalancerClusters) Update(dNSLoadBalancerCluster *loadbalancerv1.DNSLoadBalancerCluster) (result *loadbalancerv1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(dnsloadbalancerclustersResource, dNSLoadBalancerCluster), &loadbalancerv1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSLoadBalancerCluster), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDNSLoadBalancerClusters) UpdateStatus(dNSLoadBalancerCluster *loadbalancerv1.DNSLoadBalancerCluster) (*loadbalancerv1.DNSLoadBalancerCluster, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(dnsloadbalancerclustersResource, "status", dNSLoadBalancerCluster), &loadbalancerv1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSLoadBalancerCluster), err
+}
+
+// Delete takes name of the dNSLoadBalancerCluster and deletes it. Returns an error if one occurs.
+func (c *FakeDNSLoadBalancerClusters) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(dnsloadbalancerclustersResource, name), &loadbalancerv1.DNSLoadBalancerCluster{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDNSLoadBalancerClusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(dnsloadbalancerclustersResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &loadbalancerv1.DNSLoadBalancerClusterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dNSLoadBalancerCluster.
+func (c *FakeDNSLoadBalancerClusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *loadbalancerv1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(dnsloadbalancerclustersResource, name, data, subresources...), &loadbalancerv1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*loadbalancerv1.DNSLoadBalancerCluster), err
+}
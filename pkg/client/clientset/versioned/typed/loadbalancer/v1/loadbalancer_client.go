@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/scheme"
+	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	rest "k8s.io/client-go/rest"
+)
+
+type LoadbalancerV1Interface interface {
+	RESTClient() rest.Interface
+	DNSHealthCheckPoliciesGetter
+	DNSLoadBalancersGetter
+	DNSLoadBalancerClustersGetter
+	DNSLoadBalancerEndpointsGetter
+}
+
+// LoadbalancerV1Client is used to interact with features provided by the loadbalancer.gardener.cloud group.
+type LoadbalancerV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *LoadbalancerV1Client) DNSHealthCheckPolicies(namespace string) DNSHealthCheckPolicyInterface {
+	return newDNSHealthCheckPolicies(c, namespace)
+}
+
+func (c *LoadbalancerV1Client) DNSLoadBalancers(namespace string) DNSLoadBalancerInterface {
+	return newDNSLoadBalancers(c, namespace)
+}
+
+func (c *LoadbalancerV1Client) DNSLoadBalancerClusters() DNSLoadBalancerClusterInterface {
+	return newDNSLoadBalancerClusters(c)
+}
+
+func (c *LoadbalancerV1Client) DNSLoadBalancerEndpoints(namespace string) DNSLoadBalancerEndpointInterface {
+	return newDNSLoadBalancerEndpoints(c, namespace)
+}
+
+// NewForConfig creates a new LoadbalancerV1Client for the given config.
+func NewForConfig(c *rest.Config) (*LoadbalancerV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &LoadbalancerV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new LoadbalancerV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *LoadbalancerV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new LoadbalancerV1Client for the given RESTClient.
+func New(c rest.Interface) *LoadbalancerV1Client {
+	return &LoadbalancerV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: scheme.Codecs}
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *LoadbalancerV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
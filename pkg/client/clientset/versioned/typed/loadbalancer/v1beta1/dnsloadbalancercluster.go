@@ -0,0 +1,152 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	scheme "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// DNSLoadBalancerClustersGetter has a method to return a DNSLoadBalancerClusterInterface.
+// A group's client should implement this interface.
+type DNSLoadBalancerClustersGetter interface {
+	DNSLoadBalancerClusters() DNSLoadBalancerClusterInterface
+}
+
+// DNSLoadBalancerClusterInterface has methods to work with DNSLoadBalancerCluster resources.
+type DNSLoadBalancerClusterInterface interface {
+	Create(*v1beta1.DNSLoadBalancerCluster) (*v1beta1.DNSLoadBalancerCluster, error)
+	Update(*v1beta1.DNSLoadBalancerCluster) (*v1beta1.DNSLoadBalancerCluster, error)
+	UpdateStatus(*v1beta1.DNSLoadBalancerCluster) (*v1beta1.DNSLoadBalancerCluster, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1beta1.DNSLoadBalancerCluster, error)
+	List(opts v1.ListOptions) (*v1beta1.DNSLoadBalancerClusterList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.DNSLoadBalancerCluster, err error)
+	DNSLoadBalancerClusterExpansion
+}
+
+// dNSLoadBalancerClusters implements DNSLoadBalancerClusterInterface
+type dNSLoadBalancerClusters struct {
+	client rest.Interface
+}
+
+// newDNSLoadBalancerClusters returns a DNSLoadBalancerClusters
+func newDNSLoadBalancerClusters(c *LoadbalancerV1beta1Client) *dNSLoadBalancerClusters {
+	return &dNSLoadBalancerClusters{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the dNSLoadBalancerCluster, and returns the corresponding dNSLoadBalancerCluster object, and an error if there is any.
+func (c *dNSLoadBalancerClusters) Get(name string, options v1.GetOptions) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	result = &v1beta1.DNSLoadBalancerCluster{}
+	err = c.client.Get().
+		Resource("dnsloadbalancerclusters").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DNSLoadBalancerClusters that match those selectors.
+func (c *dNSLoadBalancerClusters) List(opts v1.ListOptions) (result *v1beta1.DNSLoadBalancerClusterList, err error) {
+	result = &v1beta1.DNSLoadBalancerClusterList{}
+	err = c.client.Get().
+		Resource("dnsloadbalancerclusters").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested dNSLoadBalancerClusters.
+func (c *dNSLoadBalancerClusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("dnsloadbalancerclusters").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a dNSLoadBalancerCluster and creates it.  Returns the server's representation of the dNSLoadBalancerCluster, and an error, if there is any.
+func (c *dNSLoadBalancerClusters) Create(dNSLoadBalancerCluster *v1beta1.DNSLoadBalancerCluster) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	result = &v1beta1.DNSLoadBalancerCluster{}
+	err = c.client.Post().
+		Resource("dnsloadbalancerclusters").
+		Body(dNSLoadBalancerCluster).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a dNSLoadBalancerCluster and updates it. Returns the server's representation of the dNSLoadBalancerCluster, and an error, if there is any.
+func (c *dNSLoadBalancerClusters) Update(dNSLoadBalancerCluster *v1beta1.DNSLoadBalancerCluster) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	result = &v1beta1.DNSLoadBalancerCluster{}
+	err = c.client.Put().
+		Resource("dnsloadbalancerclusters").
+		Name(dNSLoadBalancerCluster.Name).
+		Body(dNSLoadBalancerCluster).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *dNSLoadBalancerClusters) UpdateStatus(dNSLoadBalancerCluster *v1beta1.DNSLoadBalancerCluster) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	result = &v1beta1.DNSLoadBalancerCluster{}
+	err = c.client.Put().
+		Resource("dnsloadbalancerclusters").
+		Name(dNSLoadBalancerCluster.Name).
+		SubResource("status").
+		Body(dNSLoadBalancerCluster).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the dNSLoadBalancerCluster and deletes it. Returns an error if one occurs.
+func (c *dNSLoadBalancerClusters) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("dnsloadbalancerclusters").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *dNSLoadBalancerClusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("dnsloadbalancerclusters").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched dNSLoadBalancerCluster.
+func (c *dNSLoadBalancerClusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	result = &v1beta1.DNSLoadBalancerCluster{}
+	err = c.client.Patch(pt).
+		Resource("dnsloadbalancerclusters").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
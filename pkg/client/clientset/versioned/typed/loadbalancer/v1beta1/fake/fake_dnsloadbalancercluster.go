@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDNSLoadBalancerClusters implements DNSLoadBalancerClusterInterface
+type FakeDNSLoadBalancerClusters struct {
+	Fake *FakeLoadbalancerV1beta1
+}
+
+var dnsloadbalancerclustersResource = schema.GroupVersionResource{Group: "loadbalancer.gardener.cloud", Version: "v1beta1", Resource: "dnsloadbalancerclusters"}
+
+var dnsloadbalancerclustersKind = schema.GroupVersionKind{Group: "loadbalancer.gardener.cloud", Version: "v1beta1", Kind: "DNSLoadBalancerCluster"}
+
+// Get takes name of the dNSLoadBalancerCluster, and returns the corresponding dNSLoadBalancerCluster object, and an error if there is any.
+func (c *FakeDNSLoadBalancerClusters) Get(name string, options v1.GetOptions) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(dnsloadbalancerclustersResource, name), &v1beta1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.DNSLoadBalancerCluster), err
+}
+
+// List takes label and field selectors, and returns the list of DNSLoadBalancerClusters that match those selectors.
+func (c *FakeDNSLoadBalancerClusters) List(opts v1.ListOptions) (result *v1beta1.DNSLoadBalancerClusterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(dnsloadbalancerclustersResource, dnsloadbalancerclustersKind, opts), &v1beta1.DNSLoadBalancerClusterList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.DNSLoadBalancerClusterList{ListMeta: obj.(*v1beta1.DNSLoadBalancerClusterList).ListMeta}
+	for _, item := range obj.(*v1beta1.DNSLoadBalancerClusterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dNSLoadBalancerClusters.
+func (c *FakeDNSLoadBalancerClusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(dnsloadbalancerclustersResource, opts))
+}
+
+// Create takes the representation of a dNSLoadBalancerCluster and creates it.  Returns the server's representation of the dNSLoadBalancerCluster, and an error, if there is any.
+func (c *FakeDNSLoadBalancerClusters) Create(dNSLoadBalancerCluster *v1beta1.DNSLoadBalancerCluster) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(dnsloadbalancerclustersResource, dNSLoadBalancerCluster), &v1beta1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.DNSLoadBalancerCluster), err
+}
+
+// Update takes the representation of a dNSLoadBalancerCluster and updates it. Returns the server's representation of the dNSLoadBalancerCluster, and an error, if there is any.
+func (c *FakeDNSLoadBalancerClusters) Update(dNSLoadBalancerCluster *v1beta1.DNSLoadBalancerCluster) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(dnsloadbalancerclustersResource, dNSLoadBalancerCluster), &v1beta1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.DNSLoadBalancerCluster), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDNSLoadBalancerClusters) UpdateStatus(dNSLoadBalancerCluster *v1beta1.DNSLoadBalancerCluster) (*v1beta1.DNSLoadBalancerCluster, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(dnsloadbalancerclustersResource, "status", dNSLoadBalancerCluster), &v1beta1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.DNSLoadBalancerCluster), err
+}
+
+// Delete takes name of the dNSLoadBalancerCluster and deletes it. Returns an error if one occurs.
+func (c *FakeDNSLoadBalancerClusters) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(dnsloadbalancerclustersResource, name), &v1beta1.DNSLoadBalancerCluster{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDNSLoadBalancerClusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(dnsloadbalancerclustersResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.DNSLoadBalancerClusterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dNSLoadBalancerCluster.
+func (c *FakeDNSLoadBalancerClusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.DNSLoadBalancerCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(dnsloadbalancerclustersResource, name, data, subresources...), &v1beta1.DNSLoadBalancerCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.DNSLoadBalancerCluster), err
+}
@@ -21,6 +21,10 @@ func (c *FakeLoadbalancerV1beta1) DNSLoadBalancers(namespace string) v1beta1.DNS
 	return &FakeDNSLoadBalancers{c, namespace}
 }
 
+func (c *FakeLoadbalancerV1beta1) DNSLoadBalancerClusters() v1beta1.DNSLoadBalancerClusterInterface {
+	return &FakeDNSLoadBalancerClusters{c}
+}
+
 func (c *FakeLoadbalancerV1beta1) DNSLoadBalancerEndpoints(namespace string) v1beta1.DNSLoadBalancerEndpointInterface {
 	return &FakeDNSLoadBalancerEndpoints{c, namespace}
 }
@@ -9,4 +9,6 @@ package v1beta1
 
 type DNSLoadBalancerExpansion interface{}
 
+type DNSLoadBalancerClusterExpansion interface{}
+
 type DNSLoadBalancerEndpointExpansion interface{}
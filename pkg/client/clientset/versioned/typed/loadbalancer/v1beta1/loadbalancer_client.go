@@ -17,6 +17,7 @@ import (
 type LoadbalancerV1beta1Interface interface {
 	RESTClient() rest.Interface
 	DNSLoadBalancersGetter
+	DNSLoadBalancerClustersGetter
 	DNSLoadBalancerEndpointsGetter
 }
 
@@ -29,6 +30,10 @@ func (c *LoadbalancerV1beta1Client) DNSLoadBalancers(namespace string) DNSLoadBa
 	return newDNSLoadBalancers(c, namespace)
 }
 
+func (c *LoadbalancerV1beta1Client) DNSLoadBalancerClusters() DNSLoadBalancerClusterInterface {
+	return newDNSLoadBalancerClusters(c)
+}
+
 func (c *LoadbalancerV1beta1Client) DNSLoadBalancerEndpoints(namespace string) DNSLoadBalancerEndpointInterface {
 	return newDNSLoadBalancerEndpoints(c, namespace)
 }
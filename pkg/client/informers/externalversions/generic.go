@@ -10,6 +10,7 @@ package externalversions
 import (
 	"fmt"
 
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	v1beta1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
@@ -41,9 +42,21 @@ func (f *genericInformer) Lister() cache.GenericLister {
 // TODO extend this to unknown resources with a client pool
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
-	// Group=loadbalancer.gardener.cloud, Version=v1beta1
+	// Group=loadbalancer.gardener.cloud, Version=v1
+	case v1.SchemeGroupVersion.WithResource("dnshealthcheckpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1().DNSHealthCheckPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("dnsloadbalancers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1().DNSLoadBalancers().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("dnsloadbalancerclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1().DNSLoadBalancerClusters().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("dnsloadbalancerendpoints"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1().DNSLoadBalancerEndpoints().Informer()}, nil
+
+		// Group=loadbalancer.gardener.cloud, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("dnsloadbalancers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1beta1().DNSLoadBalancers().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("dnsloadbalancerclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1beta1().DNSLoadBalancerClusters().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("dnsloadbalancerendpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Loadbalancer().V1beta1().DNSLoadBalancerEndpoints().Informer()}, nil
 
@@ -0,0 +1,77 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	loadbalancerv1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	versioned "github.com/gardener/dnslb-controller-manager/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/gardener/dnslb-controller-manager/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/client/listers/loadbalancer/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DNSLoadBalancerClusterInformer provides access to a shared informer and lister for
+// DNSLoadBalancerClusters.
+type DNSLoadBalancerClusterInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.DNSLoadBalancerClusterLister
+}
+
+type dNSLoadBalancerClusterInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewDNSLoadBalancerClusterInformer constructs a new informer for DNSLoadBalancerCluster type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDNSLoadBalancerClusterInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDNSLoadBalancerClusterInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDNSLoadBalancerClusterInformer constructs a new informer for DNSLoadBalancerCluster type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDNSLoadBalancerClusterInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LoadbalancerV1().DNSLoadBalancerClusters().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LoadbalancerV1().DNSLoadBalancerClusters().Watch(options)
+			},
+		},
+		&loadbalancerv1.DNSLoadBalancerCluster{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *dNSLoadBalancerClusterInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDNSLoadBalancerClusterInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *dNSLoadBalancerClusterInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&loadbalancerv1.DNSLoadBalancerCluster{}, f.defaultInformer)
+}
+
+func (f *dNSLoadBalancerClusterInformer) Lister() v1.DNSLoadBalancerClusterLister {
+	return v1.NewDNSLoadBalancerClusterLister(f.Informer().GetIndexer())
+}
@@ -15,6 +15,8 @@ import (
 type Interface interface {
 	// DNSLoadBalancers returns a DNSLoadBalancerInformer.
 	DNSLoadBalancers() DNSLoadBalancerInformer
+	// DNSLoadBalancerClusters returns a DNSLoadBalancerClusterInformer.
+	DNSLoadBalancerClusters() DNSLoadBalancerClusterInformer
 	// DNSLoadBalancerEndpoints returns a DNSLoadBalancerEndpointInformer.
 	DNSLoadBalancerEndpoints() DNSLoadBalancerEndpointInformer
 }
@@ -35,6 +37,11 @@ func (v *version) DNSLoadBalancers() DNSLoadBalancerInformer {
 	return &dNSLoadBalancerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSLoadBalancerClusters returns a DNSLoadBalancerClusterInformer.
+func (v *version) DNSLoadBalancerClusters() DNSLoadBalancerClusterInformer {
+	return &dNSLoadBalancerClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSLoadBalancerEndpoints returns a DNSLoadBalancerEndpointInformer.
 func (v *version) DNSLoadBalancerEndpoints() DNSLoadBalancerEndpointInformer {
 	return &dNSLoadBalancerEndpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
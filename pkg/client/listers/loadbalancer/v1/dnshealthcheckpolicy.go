@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DNSHealthCheckPolicyLister helps list DNSHealthCheckPolicies.
+type DNSHealthCheckPolicyLister interface {
+	// List lists all DNSHealthCheckPolicies in the indexer.
+	List(selector labels.Selector) (ret []*v1.DNSHealthCheckPolicy, err error)
+	// DNSHealthCheckPolicies returns an object that can list and get DNSHealthCheckPolicies.
+	DNSHealthCheckPolicies(namespace string) DNSHealthCheckPolicyNamespaceLister
+	DNSHealthCheckPolicyListerExpansion
+}
+
+// dNSHealthCheckPolicyLister implements the DNSHealthCheckPolicyLister interface.
+type dNSHealthCheckPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewDNSHealthCheckPolicyLister returns a new DNSHealthCheckPolicyLister.
+func NewDNSHealthCheckPolicyLister(indexer cache.Indexer) DNSHealthCheckPolicyLister {
+	return &dNSHealthCheckPolicyLister{indexer: indexer}
+}
+
+// List lists all DNSHealthCheckPolicies in the indexer.
+func (s *dNSHealthCheckPolicyLister) List(selector labels.Selector) (ret []*v1.DNSHealthCheckPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.DNSHealthCheckPolicy))
+	})
+	return ret, err
+}
+
+// DNSHealthCheckPolicies returns an object that can list and get DNSHealthCheckPolicies.
+func (s *dNSHealthCheckPolicyLister) DNSHealthCheckPolicies(namespace string) DNSHealthCheckPolicyNamespaceLister {
+	return dNSHealthCheckPolicyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DNSHealthCheckPolicyNamespaceLister helps list and get DNSHealthCheckPolicies.
+type DNSHealthCheckPolicyNamespaceLister interface {
+	// List lists all DNSHealthCheckPolicies in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.DNSHealthCheckPolicy, err error)
+	// Get retrieves the DNSHealthCheckPolicy from the indexer for a given namespace and name.
+	Get(name string) (*v1.DNSHealthCheckPolicy, error)
+	DNSHealthCheckPolicyNamespaceListerExpansion
+}
+
+// dNSHealthCheckPolicyNamespaceLister implements the DNSHealthCheckPolicyNamespaceLister
+// interface.
+type dNSHealthCheckPolicyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DNSHealthCheckPolicies in the indexer for a given namespace.
+func (s dNSHealthCheckPolicyNamespaceLister) List(selector labels.Selector) (ret []*v1.DNSHealthCheckPolicy, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.DNSHealthCheckPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the DNSHealthCheckPolicy from the indexer for a given namespace and name.
+func (s dNSHealthCheckPolicyNamespaceLister) Get(name string) (*v1.DNSHealthCheckPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("dnshealthcheckpolicy"), name)
+	}
+	return obj.(*v1.DNSHealthCheckPolicy), nil
+}
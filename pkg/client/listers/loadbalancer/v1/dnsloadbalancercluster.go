@@ -0,0 +1,54 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DNSLoadBalancerClusterLister helps list DNSLoadBalancerClusters.
+type DNSLoadBalancerClusterLister interface {
+	// List lists all DNSLoadBalancerClusters in the indexer.
+	List(selector labels.Selector) (ret []*v1.DNSLoadBalancerCluster, err error)
+	// Get retrieves the DNSLoadBalancerCluster from the index for a given name.
+	Get(name string) (*v1.DNSLoadBalancerCluster, error)
+	DNSLoadBalancerClusterListerExpansion
+}
+
+// dNSLoadBalancerClusterLister implements the DNSLoadBalancerClusterLister interface.
+type dNSLoadBalancerClusterLister struct {
+	indexer cache.Indexer
+}
+
+// NewDNSLoadBalancerClusterLister returns a new DNSLoadBalancerClusterLister.
+func NewDNSLoadBalancerClusterLister(indexer cache.Indexer) DNSLoadBalancerClusterLister {
+	return &dNSLoadBalancerClusterLister{indexer: indexer}
+}
+
+// List lists all DNSLoadBalancerClusters in the indexer.
+func (s *dNSLoadBalancerClusterLister) List(selector labels.Selector) (ret []*v1.DNSLoadBalancerCluster, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.DNSLoadBalancerCluster))
+	})
+	return ret, err
+}
+
+// Get retrieves the DNSLoadBalancerCluster from the index for a given name.
+func (s *dNSLoadBalancerClusterLister) Get(name string) (*v1.DNSLoadBalancerCluster, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("dnsloadbalancercluster"), name)
+	}
+	return obj.(*v1.DNSLoadBalancerCluster), nil
+}
@@ -0,0 +1,36 @@
+/*
+SPDX-FileCopyrightText: 2019 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// DNSHealthCheckPolicyListerExpansion allows custom methods to be added to
+// DNSHealthCheckPolicyLister.
+type DNSHealthCheckPolicyListerExpansion interface{}
+
+// DNSHealthCheckPolicyNamespaceListerExpansion allows custom methods to be added to
+// DNSHealthCheckPolicyNamespaceLister.
+type DNSHealthCheckPolicyNamespaceListerExpansion interface{}
+
+// DNSLoadBalancerListerExpansion allows custom methods to be added to
+// DNSLoadBalancerLister.
+type DNSLoadBalancerListerExpansion interface{}
+
+// DNSLoadBalancerNamespaceListerExpansion allows custom methods to be added to
+// DNSLoadBalancerNamespaceLister.
+type DNSLoadBalancerNamespaceListerExpansion interface{}
+
+// DNSLoadBalancerClusterListerExpansion allows custom methods to be added to
+// DNSLoadBalancerClusterLister.
+type DNSLoadBalancerClusterListerExpansion interface{}
+
+// DNSLoadBalancerEndpointListerExpansion allows custom methods to be added to
+// DNSLoadBalancerEndpointLister.
+type DNSLoadBalancerEndpointListerExpansion interface{}
+
+// DNSLoadBalancerEndpointNamespaceListerExpansion allows custom methods to be added to
+// DNSLoadBalancerEndpointNamespaceLister.
+type DNSLoadBalancerEndpointNamespaceListerExpansion interface{}
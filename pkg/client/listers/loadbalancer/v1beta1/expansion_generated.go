@@ -15,6 +15,10 @@ type DNSLoadBalancerListerExpansion interface{}
 // DNSLoadBalancerNamespaceLister.
 type DNSLoadBalancerNamespaceListerExpansion interface{}
 
+// DNSLoadBalancerClusterListerExpansion allows custom methods to be added to
+// DNSLoadBalancerClusterLister.
+type DNSLoadBalancerClusterListerExpansion interface{}
+
 // DNSLoadBalancerEndpointListerExpansion allows custom methods to be added to
 // DNSLoadBalancerEndpointLister.
 type DNSLoadBalancerEndpointListerExpansion interface{}
@@ -5,49 +5,253 @@
 package crds
 
 import (
-	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	lbv1beta1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
 )
 
-var DNSLBCRD = apiextensions.CreateCRDObject(api.GroupName, api.Version, api.LoadBalancerResourceKind, api.LoadBalancerResourcePlural, "dnlb", true,
-	v1beta1.CustomResourceColumnDefinition{
-		Name:        "DNSNAME",
-		Description: "DNS Name of loadbalancer",
-		Type:        "string",
-		JSONPath:    ".spec.dnsname",
+var DNSLBCRD = &CRD{
+	Group:      api.GroupName,
+	Kind:       api.LoadBalancerResourceKind,
+	Plural:     api.LoadBalancerResourcePlural,
+	ShortName:  "dnslb",
+	Namespaced: true,
+	Versions: []*CRDVersion{
+		{
+			Name:    api.Version,
+			Example: &api.DNSLoadBalancer{},
+			Refine: func(s *JSONSchemaProps) {
+				var types []string
+				for _, t := range api.LBTYPES {
+					types = append(types, string(t))
+				}
+				spec := s.MustProperty("spec").WithRequired("dnsname")
+				// the type is not defaulted, for objects stored with v1beta1 it is
+				// derived from the deprecated singleton flag by the conversion
+				spec.MustProperty("type").WithEnum(types...)
+				// the nested defaults are applied only to an existing health check
+				spec.MustProperty("healthCheck").WithDefault(map[string]interface{}{})
+				spec.MustProperty("healthCheck", "path").WithDefault(api.DEFAULT_HEALTH_PATH)
+				spec.MustProperty("healthCheck", "statusCode").WithRange(100, 599).WithDefault(api.DEFAULT_STATUS_CODE)
+				spec.MustProperty("healthCheckPolicyRef").WithRequired("name")
+				refineLoadBalancer(s)
+			},
+		},
+		{
+			Name:    lbv1beta1.Version,
+			Example: &lbv1beta1.DNSLoadBalancer{},
+			Refine: func(s *JSONSchemaProps) {
+				spec := s.MustProperty("spec").WithRequired("dnsname")
+				spec.MustProperty("type").WithEnum(lbv1beta1.LBTYPE_BALANCED, lbv1beta1.LBTYPE_EXCLUSIVE, lbv1beta1.LBTYPE_GEO)
+				// the type is not defaulted, it depends on the deprecated singleton flag
+				spec.MustProperty("healthPath").WithDefault(lbv1beta1.DEFAULT_HEALTH_PATH)
+				spec.MustProperty("statusCode").WithRange(100, 599).WithDefault(lbv1beta1.DEFAULT_STATUS_CODE)
+				refineLoadBalancer(s)
+			},
+		},
 	},
-	v1beta1.CustomResourceColumnDefinition{
-		Name:        "TYPE",
-		Description: "Type of loadbalancer",
-		Type:        "string",
-		JSONPath:    ".spec.type",
-	},
-	v1beta1.CustomResourceColumnDefinition{
-		Name:        "STATUS",
-		Description: "loadbalancer state",
-		Type:        "string",
-		JSONPath:    ".status.state",
-	})
-
-var DNSLBEPCRD = apiextensions.CreateCRDObject(api.GroupName, api.Version, api.LoadBalancerEndpointResourceKind, api.LoadBalancerEndpointResourcePlural, "dnslbep", true,
-	v1beta1.CustomResourceColumnDefinition{
-		Name:        "DNSLB",
-		Description: "Loadbalancer",
-		Type:        "string",
-		JSONPath:    ".spec.loadbalancer",
+	Convert: lbv1beta1.Convert,
+	Columns: []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "DNSNAME",
+			Description: "DNS Name of loadbalancer",
+			Type:        "string",
+			JSONPath:    ".spec.dnsname",
+		},
+		{
+			Name:        "TYPE",
+			Description: "Type of loadbalancer",
+			Type:        "string",
+			JSONPath:    ".spec.type",
+		},
+		{
+			Name:        "STATUS",
+			Description: "loadbalancer state",
+			Type:        "string",
+			JSONPath:    ".status.state",
+		},
+		{
+			Name:        "READY",
+			Description: "Ready condition of loadbalancer",
+			Type:        "string",
+			JSONPath:    `.status.conditions[?(@.type=="Ready")].status`,
+		}},
+}
+
+var DNSLBEPCRD = &CRD{
+	Group:      api.GroupName,
+	Kind:       api.LoadBalancerEndpointResourceKind,
+	Plural:     api.LoadBalancerEndpointResourcePlural,
+	ShortName:  "dnslbep",
+	Namespaced: true,
+	Versions: []*CRDVersion{
+		{
+			Name:    api.Version,
+			Example: &api.DNSLoadBalancerEndpoint{},
+			Refine: func(s *JSONSchemaProps) {
+				s.MustProperty("spec", "addresses", "*").WithIPFormat()
+				refineEndpoint(s)
+			},
+		},
+		{
+			Name:    lbv1beta1.Version,
+			Example: &lbv1beta1.DNSLoadBalancerEndpoint{},
+			Refine: func(s *JSONSchemaProps) {
+				s.MustProperty("spec", "ipaddress").WithIPFormat()
+				refineEndpoint(s)
+			},
+		},
 	},
-	v1beta1.CustomResourceColumnDefinition{
-		Name:        "HEALTHY",
-		Description: "Health status of endpoint",
-		Type:        "boolean",
-		JSONPath:    ".status.healthy",
+	Convert: lbv1beta1.Convert,
+	Columns: []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "DNSLB",
+			Description: "Loadbalancer",
+			Type:        "string",
+			JSONPath:    ".spec.loadbalancer",
+		},
+		{
+			Name:        "HEALTHY",
+			Description: "Health status of endpoint",
+			Type:        "boolean",
+			JSONPath:    ".status.healthy",
+		},
+		{
+			Name:        "STATUS",
+			Description: "Assigned to Loadbalancer",
+			Type:        "string",
+			JSONPath:    ".status.state",
+		}},
+}
+
+var DNSLBCLUSTERCRD = &CRD{
+	Group:      api.GroupName,
+	Kind:       api.LoadBalancerClusterResourceKind,
+	Plural:     api.LoadBalancerClusterResourcePlural,
+	ShortName:  "dnslbcluster",
+	Namespaced: false,
+	Versions: []*CRDVersion{
+		{
+			Name:    api.Version,
+			Example: &api.DNSLoadBalancerCluster{},
+			Refine:  refineCluster,
+		},
+		{
+			Name:    lbv1beta1.Version,
+			Example: &lbv1beta1.DNSLoadBalancerCluster{},
+			Refine:  refineCluster,
+		},
 	},
-	v1beta1.CustomResourceColumnDefinition{
-		Name:        "STATUS",
-		Description: "Assigned to Loadbalancer",
-		Type:        "string",
-		JSONPath:    ".status.state",
+	Convert: lbv1beta1.Convert,
+	Columns: []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "CLUSTERID",
+			Description: "Id of source cluster",
+			Type:        "string",
+			JSONPath:    ".spec.clusterId",
+		},
+		{
+			Name:        "REGION",
+			Description: "Region of source cluster",
+			Type:        "string",
+			JSONPath:    ".spec.region",
+		},
+		{
+			Name:        "ZONE",
+			Description: "Zone of source cluster",
+			Type:        "string",
+			JSONPath:    ".spec.zone",
+		},
+		{
+			Name:        "PROVIDER",
+			Description: "Infrastructure provider of source cluster",
+			Type:        "string",
+			JSONPath:    ".spec.provider",
+		}},
+}
+
+var DNSHCPCRD = &CRD{
+	Group:      api.GroupName,
+	Kind:       api.HealthCheckPolicyResourceKind,
+	Plural:     api.HealthCheckPolicyResourcePlural,
+	ShortName:  "dnshcp",
+	Namespaced: true,
+	Versions: []*CRDVersion{
+		{
+			Name:    api.Version,
+			Example: &api.DNSHealthCheckPolicy{},
+			Refine: func(s *JSONSchemaProps) {
+				var types []string
+				for _, t := range api.HEALTHCHECK_TYPES {
+					types = append(types, string(t))
+				}
+				spec := s.MustProperty("spec")
+				// port, path and status code depend on the type and are
+				// defaulted by the controller
+				spec.MustProperty("type").WithEnum(types...).WithDefault(string(api.HEALTHCHECK_HTTPS))
+				spec.MustProperty("port").WithRange(0, 65535)
+				spec.MustProperty("statusCode").WithRange(100, 599)
+				spec.MustProperty("healthyThreshold").WithRange(1, api.MAX_HEALTHCHECK_THRESHOLD).WithDefault(api.DEFAULT_HEALTHCHECK_THRESHOLD)
+				spec.MustProperty("unhealthyThreshold").WithRange(1, api.MAX_HEALTHCHECK_THRESHOLD).WithDefault(api.DEFAULT_HEALTHCHECK_THRESHOLD)
+			},
+		},
 	},
-)
+	Columns: []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "TYPE",
+			Description: "Type of health check",
+			Type:        "string",
+			JSONPath:    ".spec.type",
+		},
+		{
+			Name:        "PATH",
+			Description: "Path of health check url",
+			Type:        "string",
+			JSONPath:    ".spec.path",
+		},
+		{
+			Name:        "INTERVAL",
+			Description: "Period between probes",
+			Type:        "string",
+			JSONPath:    ".spec.interval",
+		}},
+}
+
+// CRDs are the custom resource definitions of the API group.
+var CRDs = []*CRD{DNSLBCRD, DNSLBEPCRD, DNSLBCLUSTERCRD, DNSHCPCRD}
+
+// refineLoadBalancer adds the rules common to all load balancer versions.
+func refineLoadBalancer(s *JSONSchemaProps) {
+	spec := s.MustProperty("spec")
+	spec.MustProperty("backend").WithEnum(api.BACKEND_DNSENTRY, api.BACKEND_RFC2136, api.BACKEND_EMBEDDED)
+	spec.MustProperty("access", "scope").WithEnum(api.SCOPE_CLUSTER, api.SCOPE_NAMESPACE, api.SCOPE_SELECTED)
+	spec.MustProperty("ttl").WithMinimum(1)
+	spec.MustProperty("srv").WithRequired("service", "protocol")
+	spec.MustProperty("srv", "port").WithRange(0, 65535)
+	spec.MustProperty("adaptiveTTL").WithRequired("transition")
+	spec.MustProperty("adaptiveTTL", "transition").WithMinimum(1)
+	spec.MustProperty("changeBudget").WithRequired("maxChanges")
+	spec.MustProperty("changeBudget", "maxChanges").WithMinimum(1)
+	status := s.MustProperty("status")
+	status.MustProperty("throttled", "budget").WithEnum(api.BUDGET_LOADBALANCER, api.BUDGET_GLOBAL)
+	refineConditions(status)
+}
+
+// refineEndpoint adds the rules common to all endpoint versions.
+func refineEndpoint(s *JSONSchemaProps) {
+	spec := s.MustProperty("spec").WithRequired("loadbalancer")
+	spec.MustProperty("port").WithRange(0, 65535)
+	spec.MustProperty("priority").WithRange(0, 65535)
+	spec.MustProperty("weight").WithRange(0, 65535)
+	refineConditions(s.MustProperty("status"))
+}
+
+func refineCluster(s *JSONSchemaProps) {
+	s.MustProperty("spec").WithRequired("clusterId")
+}
+
+func refineConditions(status *JSONSchemaProps) {
+	cond := status.MustProperty("conditions", "*").WithRequired("type", "status")
+	cond.MustProperty("status").WithEnum(string(api.CONDITION_TRUE), string(api.CONDITION_FALSE), string(api.CONDITION_UNKNOWN))
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package crds_test
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/crds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// structural reports the paths of properties violating the structural
+// schema rules: every property must have a type, unless it is an int or
+// string or preserves unknown fields.
+func structural(path string, s *JSONSchemaProps) []string {
+	var result []string
+	if s.Type == "" && !s.XIntOrString && s.XPreserveUnknownFields == nil {
+		result = append(result, path)
+	}
+	var names []string
+	for n := range s.Properties {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		result = append(result, structural(path+"."+n, s.Properties[n])...)
+	}
+	if s.Items != nil {
+		result = append(result, structural(path+"[]", s.Items)...)
+	}
+	if s.AdditionalProperties != nil {
+		result = append(result, structural(path+"{}", s.AdditionalProperties)...)
+	}
+	return result
+}
+
+var _ = Describe("crds", func() {
+	It("generates structural schemas", func() {
+		for _, crd := range CRDs {
+			for _, v := range crd.Versions {
+				Expect(structural(crd.Name()+"/"+v.Name, v.Schema())).To(BeEmpty())
+			}
+		}
+	})
+
+	It("generates schemas from the api types", func() {
+		max := float64(599)
+		threshold := float64(5)
+		s := DNSLBCRD.Schema()
+		Expect(s.MustProperty("spec").Required).To(Equal([]string{"dnsname"}))
+		Expect(s.MustProperty("spec", "type").Enum).To(Equal([]string{"Balanced", "Exclusive", "Geo"}))
+		Expect(s.MustProperty("spec", "healthCheck", "statusCode").Maximum).To(Equal(&max))
+		Expect(s.MustProperty("spec", "type").Default).To(BeNil())
+		Expect(s.MustProperty("spec", "healthCheck").Default).To(Equal(map[string]interface{}{}))
+		Expect(s.MustProperty("spec", "healthCheck", "statusCode").Default).To(Equal(200))
+		Expect(s.MustProperty("spec", "endpointValidityInterval").Format).To(Equal("duration"))
+		Expect(s.MustProperty("spec", "maxUnavailable").XIntOrString).To(BeTrue())
+		Expect(s.MustProperty("spec", "geo", "fallbacks", "*", "*").Type).To(Equal("string"))
+		Expect(s.MustProperty("status", "lastChange").Format).To(Equal("date-time"))
+		Expect(s.MustProperty("status", "conditions", "*", "status").Enum).To(Equal([]string{"True", "False", "Unknown"}))
+		Expect(s.MustProperty("metadata").Properties).To(BeEmpty())
+		Expect(s.Property("spec", "unknown")).To(BeNil())
+
+		Expect(s.MustProperty("spec", "healthCheckPolicyRef").Required).To(Equal([]string{"name"}))
+		hcp := DNSHCPCRD.Schema().MustProperty("spec")
+		Expect(hcp.MustProperty("type").Enum).To(Equal([]string{"HTTPS", "HTTP", "TCP"}))
+		Expect(hcp.MustProperty("headers", "*").Type).To(Equal("string"))
+		Expect(hcp.MustProperty("unhealthyThreshold").Maximum).To(Equal(&threshold))
+		Expect(hcp.MustProperty("unhealthyThreshold").Default).To(Equal(1))
+		Expect(hcp.MustProperty("port").Default).To(BeNil())
+
+		ip := DNSLBEPCRD.Schema().MustProperty("spec", "addresses", "*")
+		Expect(ip.AnyOf).To(HaveLen(2))
+		Expect(ip.AnyOf[0].Format).To(Equal("ipv4"))
+		Expect(ip.AnyOf[1].Format).To(Equal("ipv6"))
+	})
+
+	It("provides v1 custom resource definitions", func() {
+		crd := DNSLBEPCRD.V1(nil)
+		Expect(crd.APIVersion).To(Equal("apiextensions.k8s.io/v1"))
+		Expect(crd.Name).To(Equal("dnsloadbalancerendpoints.loadbalancer.gardener.cloud"))
+		Expect(crd.Spec.Scope).To(Equal("Namespaced"))
+		Expect(crd.Spec.PreserveUnknownFields).To(BeFalse())
+		Expect(crd.Spec.Versions).To(HaveLen(2))
+		Expect(crd.Spec.Conversion).To(BeNil())
+		version := crd.Spec.Versions[0]
+		Expect(version.Name).To(Equal("v1"))
+		Expect(version.Served).To(BeTrue())
+		Expect(version.Storage).To(BeTrue())
+		Expect(version.Subresources.Status).NotTo(BeNil())
+		Expect(crd.Spec.Versions[1].Name).To(Equal("v1beta1"))
+		Expect(crd.Spec.Versions[1].Served).To(BeFalse())
+		Expect(crd.Spec.Versions[1].Storage).To(BeFalse())
+		Expect(version.AdditionalPrinterColumns[len(version.AdditionalPrinterColumns)-1].Name).To(Equal("AGE"))
+
+		data, err := json.Marshal(crd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"jsonPath":".spec.loadbalancer"`))
+		Expect(string(data)).To(ContainSubstring(`"subresources":{"status":{}}`))
+		Expect(string(data)).To(ContainSubstring(`"preserveUnknownFields":false`))
+
+		Expect(DNSLBCLUSTERCRD.V1(nil).Spec.Scope).To(Equal("Cluster"))
+	})
+
+	It("serves all versions with a conversion webhook", func() {
+		crd := DNSLBCRD.V1(&Conversion{Service: resources.NewObjectName("kube-system", "dnslb"), Path: "/convert", CABundle: []byte("ca")})
+		Expect(crd.Spec.Versions[0].Served).To(BeTrue())
+		Expect(crd.Spec.Versions[1].Served).To(BeTrue())
+		Expect(crd.Spec.Versions[1].Schema.OpenAPIV3Schema.Property("spec", "healthPath")).NotTo(BeNil())
+		Expect(crd.Spec.Conversion.Strategy).To(Equal("Webhook"))
+		config := crd.Spec.Conversion.Webhook.ClientConfig
+		Expect(config.Service.Namespace).To(Equal("kube-system"))
+		Expect(config.Service.Name).To(Equal("dnslb"))
+		Expect(*config.Service.Path).To(Equal("/convert"))
+		Expect(config.CABundle).To(Equal([]byte("ca")))
+	})
+
+	It("provides v1beta1 custom resource definitions for bootstrapping", func() {
+		crd := DNSLBCRD.V1beta1()
+		Expect(crd.Spec.Version).To(Equal("v1"))
+		Expect(crd.Spec.Subresources.Status).NotTo(BeNil())
+		Expect(crd.Spec.Names.ShortNames).To(Equal([]string{"dnslb"}))
+	})
+
+	It("panics for unknown properties", func() {
+		Expect(func() { DNSLBCRD.Schema().MustProperty("spec", "unknown") }).To(Panic())
+	})
+})
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package crds
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+)
+
+// Register creates or updates the apiextensions.k8s.io/v1 custom resource
+// definitions in a cluster. Existing definitions, for example created with
+// apiextensions.k8s.io/v1beta1, are upgraded to the current schema. If the
+// cluster does not serve apiextensions.k8s.io/v1 the definitions are only
+// created with apiextensions.k8s.io/v1beta1.
+// With a conversion webhook all versions are served. Otherwise only the
+// storage version is served and objects stored with an older version are
+// migrated once to the storage version.
+// It waits until the definitions are established and refreshes the api
+// discovery of the cluster, so the resources used afterwards know an added
+// status sub resource or version.
+func Register(logger logger.LogContext, cluster resources.Cluster, conversion *Conversion, crds ...*CRD) error {
+	res, err := cluster.Resources().GetUnstructuredByGVK(CRDGroupVersionKind)
+	if err != nil {
+		logger.Infof("%s not served by cluster %s: using v1beta1 custom resource definitions", CRDGroupVersionKind.GroupVersion(), cluster.GetName())
+		for _, crd := range crds {
+			if err := apiextensions.CreateCRDFromObject(cluster, crd.V1beta1()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, crd := range crds {
+		if err := register(logger, cluster, res, crd, conversion); err != nil {
+			return fmt.Errorf("cannot register custom resource definition %s: %s", crd.Name(), err)
+		}
+	}
+	if len(crds) > 0 {
+		refreshDiscovery(res, crds[0])
+	}
+	return nil
+}
+
+func register(logger logger.LogContext, cluster resources.Cluster, res resources.Interface, crd *CRD, conversion *Conversion) error {
+	obj, err := toUnstructured(crd.V1(conversion))
+	if err != nil {
+		return err
+	}
+	old := &unstructured.Unstructured{}
+	_, err = res.GetInto(resources.NewObjectName(crd.Name()), old)
+	switch {
+	case errors.IsNotFound(err):
+		logger.Infof("creating custom resource definition %s", crd.Name())
+		if _, err := res.Create(obj); err != nil {
+			return err
+		}
+		return waitEstablished(res, crd.Name())
+	case err != nil:
+		return err
+	}
+
+	var migrate []*unstructured.Unstructured
+	stored := storageVersion(old)
+	if conversion == nil && crd.Convert != nil && stored != "" && stored != crd.Storage().Name {
+		// objects cannot be read with the old version after the update, so
+		// they are converted before
+		if migrate, err = convertObjects(cluster, crd, stored); err != nil {
+			return err
+		}
+	}
+
+	old.Object["spec"] = obj.Object["spec"]
+	logger.Infof("updating custom resource definition %s", crd.Name())
+	if _, err := res.Update(old); err != nil {
+		return err
+	}
+	if err := waitEstablished(res, crd.Name()); err != nil {
+		return err
+	}
+	if len(migrate) > 0 {
+		return migrateObjects(logger, cluster, crd, migrate)
+	}
+	return nil
+}
+
+// convertObjects reads the objects with an older version and converts them
+// to the storage version.
+func convertObjects(cluster resources.Cluster, crd *CRD, version string) ([]*unstructured.Unstructured, error) {
+	r, err := cluster.Resources().GetUnstructuredByGVK(crd.GroupVersionKind(version))
+	if err != nil {
+		return nil, err
+	}
+	objs, err := r.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	apiVersion := crd.GroupVersionKind(crd.Storage().Name).GroupVersion().String()
+	var result []*unstructured.Unstructured
+	for _, o := range objs {
+		data, err := o.Data().(*unstructured.Unstructured).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if data, err = crd.Convert(data, apiVersion); err != nil {
+			return nil, fmt.Errorf("cannot convert %s: %s", o.ObjectName(), err)
+		}
+		converted := &unstructured.Unstructured{}
+		if err := converted.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// migrateObjects writes converted objects with the storage version.
+func migrateObjects(logger logger.LogContext, cluster resources.Cluster, crd *CRD, objs []*unstructured.Unstructured) error {
+	gvk := crd.GroupVersionKind(crd.Storage().Name)
+	res, err := cluster.Resources().GetUnstructuredByGVK(gvk)
+	if err != nil {
+		return err
+	}
+	logger.Infof("migrating %d %s to version %s", len(objs), crd.Plural, gvk.Version)
+	for _, o := range objs {
+		if _, err := res.Update(o); err != nil {
+			logger.Warnf("cannot migrate %s %s/%s: %s", crd.Kind, o.GetNamespace(), o.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func toUnstructured(crd *CustomResourceDefinition) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(crd)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	return obj, obj.UnmarshalJSON(data)
+}
+
+// storageVersion provides the name of the storage version of a custom
+// resource definition.
+func storageVersion(obj *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(obj.Object, "spec", "versions")
+	for _, v := range versions {
+		if m, ok := v.(map[string]interface{}); ok && m["storage"] == true {
+			name, _ := m["name"].(string)
+			return name
+		}
+	}
+	version, _, _ := unstructured.NestedString(obj.Object, "spec", "version")
+	return version
+}
+
+// DISCOVERY_REFRESH_VERSION is a version never served by the cluster.
+const DISCOVERY_REFRESH_VERSION = "v0discovery"
+
+// refreshDiscovery updates the api discovery cached by the controller
+// manager library for a cluster. The cache is only refreshed if an unknown
+// kind is requested, so the kind of a custom resource definition is
+// requested with a version never served.
+func refreshDiscovery(res resources.Interface, crd *CRD) {
+	res.ResourceContext().Get(crd.GroupVersionKind(DISCOVERY_REFRESH_VERSION))
+}
+
+// waitEstablished waits until the api server serves a custom resource
+// definition.
+func waitEstablished(res resources.Interface, name string) error {
+	return wait.PollImmediate(2*time.Second, 60*time.Second, func() (bool, error) {
+		obj := &unstructured.Unstructured{}
+		if _, err := res.GetInto(resources.NewObjectName(name), obj); err != nil {
+			return false, err
+		}
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			if m, ok := c.(map[string]interface{}); ok {
+				switch {
+				case m["type"] == "Established" && m["status"] == "True":
+					return true, nil
+				case m["type"] == "NamesAccepted" && m["status"] == "False":
+					return false, fmt.Errorf("name conflict: %v", m["reason"])
+				}
+			}
+		}
+		return false, nil
+	})
+}
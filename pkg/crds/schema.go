@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package crds
+
+import (
+	"reflect"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// JSONSchemaProps is a structural OpenAPI v3 schema as used by
+// apiextensions.k8s.io/v1 custom resource definitions.
+type JSONSchemaProps struct {
+	Description            string                      `json:"description,omitempty"`
+	Type                   string                      `json:"type,omitempty"`
+	Format                 string                      `json:"format,omitempty"`
+	Required               []string                    `json:"required,omitempty"`
+	Enum                   []string                    `json:"enum,omitempty"`
+	Minimum                *float64                    `json:"minimum,omitempty"`
+	Maximum                *float64                    `json:"maximum,omitempty"`
+	Pattern                string                      `json:"pattern,omitempty"`
+	Default                interface{}                 `json:"default,omitempty"`
+	Items                  *JSONSchemaProps            `json:"items,omitempty"`
+	Properties             map[string]*JSONSchemaProps `json:"properties,omitempty"`
+	AdditionalProperties   *JSONSchemaProps            `json:"additionalProperties,omitempty"`
+	AnyOf                  []*JSONSchemaProps          `json:"anyOf,omitempty"`
+	XIntOrString           bool                        `json:"x-kubernetes-int-or-string,omitempty"`
+	XPreserveUnknownFields *bool                       `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+}
+
+var (
+	typeTime        = reflect.TypeOf(metav1.Time{})
+	typeDuration    = reflect.TypeOf(metav1.Duration{})
+	typeIntOrString = reflect.TypeOf(intstr.IntOrString{})
+	typeObjectMeta  = reflect.TypeOf(metav1.ObjectMeta{})
+)
+
+// SchemaFor generates the schema for the JSON representation of a Go type.
+// Fields are never required, this has to be added explicitly.
+func SchemaFor(t reflect.Type) *JSONSchemaProps {
+	switch t {
+	case typeTime:
+		return &JSONSchemaProps{Type: "string", Format: "date-time"}
+	case typeDuration:
+		return &JSONSchemaProps{Type: "string", Format: "duration"}
+	case typeIntOrString:
+		return &JSONSchemaProps{XIntOrString: true, AnyOf: []*JSONSchemaProps{{Type: "integer"}, {Type: "string"}}}
+	case typeObjectMeta:
+		// the object meta is validated by the api server
+		return &JSONSchemaProps{Type: "object"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return SchemaFor(t.Elem())
+	case reflect.String:
+		return &JSONSchemaProps{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchemaProps{Type: "boolean"}
+	case reflect.Int32, reflect.Uint16, reflect.Int16, reflect.Uint8, reflect.Int8:
+		return &JSONSchemaProps{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &JSONSchemaProps{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchemaProps{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchemaProps{Type: "string", Format: "byte"}
+		}
+		return &JSONSchemaProps{Type: "array", Items: SchemaFor(t.Elem())}
+	case reflect.Map:
+		return &JSONSchemaProps{Type: "object", AdditionalProperties: SchemaFor(t.Elem())}
+	case reflect.Struct:
+		schema := &JSONSchemaProps{Type: "object", Properties: map[string]*JSONSchemaProps{}}
+		addProperties(schema, t)
+		return schema
+	}
+	preserve := true
+	return &JSONSchemaProps{XPreserveUnknownFields: &preserve}
+}
+
+func addProperties(schema *JSONSchemaProps, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		if name == "" {
+			if f.Anonymous {
+				ft := f.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				addProperties(schema, ft)
+				continue
+			}
+			name = f.Name
+		}
+		schema.Properties[name] = SchemaFor(f.Type)
+	}
+}
+
+// Property provides the schema for a property path. A "*" selects the
+// items of an array or the values of a map. It is nil if the path is
+// not found.
+func (this *JSONSchemaProps) Property(path ...string) *JSONSchemaProps {
+	cur := this
+	for _, p := range path {
+		switch {
+		case cur == nil:
+			return nil
+		case p == "*" && cur.Items != nil:
+			cur = cur.Items
+		case p == "*":
+			cur = cur.AdditionalProperties
+		default:
+			cur = cur.Properties[p]
+		}
+	}
+	return cur
+}
+
+// MustProperty provides the schema for a property path, it panics
+// if the path is not found.
+func (this *JSONSchemaProps) MustProperty(path ...string) *JSONSchemaProps {
+	p := this.Property(path...)
+	if p == nil {
+		panic("schema property " + strings.Join(path, ".") + " not found")
+	}
+	return p
+}
+
+// WithEnum restricts the schema to a set of values.
+func (this *JSONSchemaProps) WithEnum(values ...string) *JSONSchemaProps {
+	this.Enum = values
+	return this
+}
+
+// WithDefault sets the value the api server fills in for a missing
+// property.
+func (this *JSONSchemaProps) WithDefault(value interface{}) *JSONSchemaProps {
+	this.Default = value
+	return this
+}
+
+// WithRange restricts the schema to a range of numbers.
+func (this *JSONSchemaProps) WithRange(min, max float64) *JSONSchemaProps {
+	this.Minimum = &min
+	this.Maximum = &max
+	return this
+}
+
+// WithMinimum restricts the schema to numbers not lower than a minimum.
+func (this *JSONSchemaProps) WithMinimum(min float64) *JSONSchemaProps {
+	this.Minimum = &min
+	return this
+}
+
+// WithRequired marks properties of an object schema as required.
+func (this *JSONSchemaProps) WithRequired(names ...string) *JSONSchemaProps {
+	this.Required = append(this.Required, names...)
+	return this
+}
+
+// WithIPFormat restricts a string schema to IPv4 or IPv6 addresses.
+func (this *JSONSchemaProps) WithIPFormat() *JSONSchemaProps {
+	this.AnyOf = []*JSONSchemaProps{{Format: "ipv4"}, {Format: "ipv6"}}
+	return this
+}
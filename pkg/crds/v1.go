@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package crds
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const CRD_GROUP = "apiextensions.k8s.io"
+const CRD_VERSION = "v1"
+const CRD_KIND = "CustomResourceDefinition"
+
+var CRDGroupVersionKind = schema.GroupVersionKind{Group: CRD_GROUP, Version: CRD_VERSION, Kind: CRD_KIND}
+
+// CustomResourceDefinition is the apiextensions.k8s.io/v1 representation
+// of a custom resource definition.
+type CustomResourceDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CustomResourceDefinitionSpec `json:"spec"`
+}
+
+type CustomResourceDefinitionSpec struct {
+	Group                 string                            `json:"group"`
+	Names                 CustomResourceDefinitionNames     `json:"names"`
+	Scope                 string                            `json:"scope"`
+	Versions              []CustomResourceDefinitionVersion `json:"versions"`
+	Conversion            *CustomResourceConversion         `json:"conversion,omitempty"`
+	PreserveUnknownFields bool                              `json:"preserveUnknownFields"`
+}
+
+type CustomResourceDefinitionNames struct {
+	Plural     string   `json:"plural"`
+	Singular   string   `json:"singular,omitempty"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	Kind       string   `json:"kind"`
+	ListKind   string   `json:"listKind,omitempty"`
+}
+
+type CustomResourceDefinitionVersion struct {
+	Name                     string                           `json:"name"`
+	Served                   bool                             `json:"served"`
+	Storage                  bool                             `json:"storage"`
+	Schema                   *CustomResourceValidation        `json:"schema,omitempty"`
+	Subresources             *CustomResourceSubresources      `json:"subresources,omitempty"`
+	AdditionalPrinterColumns []CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty"`
+}
+
+type CustomResourceValidation struct {
+	OpenAPIV3Schema *JSONSchemaProps `json:"openAPIV3Schema"`
+}
+
+type CustomResourceSubresources struct {
+	Status *CustomResourceSubresourceStatus `json:"status,omitempty"`
+}
+
+type CustomResourceSubresourceStatus struct{}
+
+type CustomResourceConversion struct {
+	Strategy string             `json:"strategy"`
+	Webhook  *WebhookConversion `json:"webhook,omitempty"`
+}
+
+type WebhookConversion struct {
+	ClientConfig             *WebhookClientConfig `json:"clientConfig,omitempty"`
+	ConversionReviewVersions []string             `json:"conversionReviewVersions"`
+}
+
+type WebhookClientConfig struct {
+	Service  *ServiceReference `json:"service,omitempty"`
+	CABundle []byte            `json:"caBundle,omitempty"`
+}
+
+type ServiceReference struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Path      *string `json:"path,omitempty"`
+}
+
+type CustomResourceColumnDefinition struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+	Priority    int32  `json:"priority,omitempty"`
+	JSONPath    string `json:"jsonPath"`
+}
+
+// Conversion describes the webhook converting objects between the
+// versions of a custom resource.
+type Conversion struct {
+	Service  resources.ObjectName
+	Path     string
+	CABundle []byte
+}
+
+// CRDVersion describes a version of a custom resource. The schema is
+// generated from the example object and refined by the Refine function.
+type CRDVersion struct {
+	Name    string
+	Example runtime.Object
+	Refine  func(schema *JSONSchemaProps)
+}
+
+// Schema provides the structural schema of the version.
+func (this *CRDVersion) Schema() *JSONSchemaProps {
+	s := SchemaFor(reflect.TypeOf(this.Example))
+	if this.Refine != nil {
+		this.Refine(s)
+	}
+	return s
+}
+
+// CRD describes a custom resource of the API group.
+type CRD struct {
+	Group      string
+	Kind       string
+	Plural     string
+	ShortName  string
+	Namespaced bool
+	// Versions lists the versions, the first one is the storage version
+	Versions []*CRDVersion
+	Columns  []v1beta1.CustomResourceColumnDefinition
+	// Convert converts the JSON representation of an object to another version
+	Convert func(data []byte, apiVersion string) ([]byte, error)
+}
+
+func (this *CRD) Name() string {
+	return this.Plural + "." + this.Group
+}
+
+// Storage provides the storage version.
+func (this *CRD) Storage() *CRDVersion {
+	return this.Versions[0]
+}
+
+// Schema provides the structural schema of the storage version.
+func (this *CRD) Schema() *JSONSchemaProps {
+	return this.Storage().Schema()
+}
+
+// GroupVersionKind provides the kind of the resource for a version.
+func (this *CRD) GroupVersionKind(version string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: this.Group, Version: version, Kind: this.Kind}
+}
+
+// V1 provides the apiextensions.k8s.io/v1 custom resource definition with
+// schemas and status sub resources. Without a conversion webhook only the
+// storage version is served, the other versions are kept unserved for
+// objects still stored with them.
+func (this *CRD) V1(conversion *Conversion) *CustomResourceDefinition {
+	scope := string(v1beta1.ClusterScoped)
+	if this.Namespaced {
+		scope = string(v1beta1.NamespaceScoped)
+	}
+	crd := &CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: CRDGroupVersionKind.GroupVersion().String(),
+			Kind:       CRD_KIND,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: this.Name()},
+		Spec: CustomResourceDefinitionSpec{
+			Group: this.Group,
+			Names: CustomResourceDefinitionNames{
+				Plural:   this.Plural,
+				Singular: strings.ToLower(this.Kind),
+				Kind:     this.Kind,
+				ListKind: this.Kind + "List",
+			},
+			Scope: scope,
+		},
+	}
+	if this.ShortName != "" {
+		crd.Spec.Names.ShortNames = []string{this.ShortName}
+	}
+	var columns []CustomResourceColumnDefinition
+	for _, c := range this.Columns {
+		columns = append(columns, CustomResourceColumnDefinition{
+			Name:        c.Name,
+			Type:        c.Type,
+			Format:      c.Format,
+			Description: c.Description,
+			Priority:    c.Priority,
+			JSONPath:    c.JSONPath,
+		})
+	}
+	columns = append(columns, CustomResourceColumnDefinition{Name: "AGE", Type: "date", JSONPath: ".metadata.creationTimestamp"})
+	for i, v := range this.Versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, CustomResourceDefinitionVersion{
+			Name:                     v.Name,
+			Served:                   i == 0 || conversion != nil,
+			Storage:                  i == 0,
+			Schema:                   &CustomResourceValidation{OpenAPIV3Schema: v.Schema()},
+			Subresources:             &CustomResourceSubresources{Status: &CustomResourceSubresourceStatus{}},
+			AdditionalPrinterColumns: columns,
+		})
+	}
+	if conversion != nil && len(this.Versions) > 1 {
+		path := conversion.Path
+		crd.Spec.Conversion = &CustomResourceConversion{
+			Strategy: "Webhook",
+			Webhook: &WebhookConversion{
+				ClientConfig: &WebhookClientConfig{
+					Service: &ServiceReference{
+						Namespace: conversion.Service.Namespace(),
+						Name:      conversion.Service.Name(),
+						Path:      &path,
+					},
+					CABundle: conversion.CABundle,
+				},
+				ConversionReviewVersions: []string{"v1", "v1beta1"},
+			},
+		}
+	}
+	return crd
+}
+
+// V1beta1 provides the apiextensions.k8s.io/v1beta1 custom resource
+// definition of the storage version with status sub resource, but without
+// schema, used for clusters not serving apiextensions.k8s.io/v1.
+func (this *CRD) V1beta1() *v1beta1.CustomResourceDefinition {
+	return apiextensions.CreateCRDObjectWithStatus(this.Group, this.Storage().Name, this.Kind, this.Plural, this.ShortName, this.Namespaced, this.Columns...)
+}
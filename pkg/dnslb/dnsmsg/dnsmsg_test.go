@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dnsmsg_test
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const secret = "c2VjcmV0LWtleS1mb3ItdGVzdGluZy1vbmx5"
+
+var _ = Describe("dnsmsg", func() {
+	Describe("records", func() {
+		It("should create records with fully qualified names", func() {
+			rrs := []dns.RR{
+				NewA("lb.example.org", 60, net.ParseIP("10.0.0.1")),
+				NewAAAA("lb.example.org", 60, net.ParseIP("fd00::1")),
+				NewCNAME("www.example.org", 60, "lb.example.org"),
+				NewTXT("_dnslb.lb.example.org", 60, "some text"),
+			}
+			values := []string{}
+			for _, rr := range rrs {
+				Expect(rr.Header().Name).To(HaveSuffix("."))
+				Expect(rr.Header().Ttl).To(Equal(uint32(60)))
+				values = append(values, Value(rr))
+			}
+			Expect(values).To(Equal([]string{"10.0.0.1", "fd00::1", "lb.example.org.", "some text"}))
+		})
+
+		It("should pack records", func() {
+			m := &dns.Msg{}
+			m.SetUpdate("example.org.")
+			m.Ns = []dns.RR{
+				NewA("lb.example.org", 60, net.ParseIP("10.0.0.1")),
+				NewTXT("_dnslb.lb.example.org", 60, strings.Repeat("x", 300)),
+				DeleteRRSet("lb.example.org", dns.TypeAAAA),
+			}
+			b, err := m.Pack()
+			Expect(err).NotTo(HaveOccurred())
+			r := &dns.Msg{}
+			Expect(r.Unpack(b)).To(Succeed())
+			Expect(r.Ns).To(HaveLen(3))
+			Expect(r.Ns[1].(*dns.TXT).Txt).To(HaveLen(2))
+			Expect(Value(r.Ns[1])).To(Equal(strings.Repeat("x", 300)))
+			Expect(r.Ns[2].Header().Class).To(Equal(uint16(dns.ClassANY)))
+		})
+
+		It("should create and parse SRV records", func() {
+			rr := NewSRV("_sip._tcp.lb.example.org", 60, 10, 5, 5060, "ep1.lb.example.org")
+			Expect(Value(rr)).To(Equal("10 5 5060 ep1.lb.example.org"))
+
+			priority, weight, port, target, ok := ParseSRV(Value(rr))
+			Expect(ok).To(BeTrue())
+			Expect([]uint16{priority, weight, port}).To(Equal([]uint16{10, 5, 5060}))
+			Expect(target).To(Equal("ep1.lb.example.org."))
+
+			_, _, _, _, ok = ParseSRV("lb.example.org")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should compare names case insensitively", func() {
+			Expect(EqualNames("LB.example.org", "lb.example.org.")).To(BeTrue())
+			Expect(EqualNames("lb.example.org", "lb.example.com")).To(BeFalse())
+		})
+	})
+
+	Describe("tsig", func() {
+		It("should normalize keys", func() {
+			key, err := NewTSIGKey("DNSLB-Key", "hmac-md5", secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(&TSIGKey{Name: "dnslb-key.", Algorithm: dns.HmacMD5, Secret: secret}))
+		})
+
+		It("should reject unsupported algorithms", func() {
+			_, err := NewTSIGKey("dnslb-key", "hmac-sha224", secret)
+			Expect(err).To(MatchError(ContainSubstring("unsupported TSIG algorithm")))
+		})
+
+		It("should reject invalid secrets", func() {
+			_, err := NewTSIGKey("dnslb-key", "hmac-sha256", "no base64!")
+			Expect(err).To(MatchError(ContainSubstring("invalid TSIG secret")))
+		})
+
+		It("should sign messages verifiable by the server", func() {
+			key, err := NewTSIGKey("dnslb-key", "hmac-sha256", secret)
+			Expect(err).NotTo(HaveOccurred())
+			m := &dns.Msg{}
+			m.SetQuestion("example.org.", dns.TypeA)
+			m.SetTsig(key.Name, key.Algorithm, TSIG_FUDGE, time.Now().Unix())
+			b, _, err := dns.TsigGenerate(m, key.Secret, "", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns.TsigVerify(b, key.Secret, "", false)).To(Succeed())
+		})
+	})
+})
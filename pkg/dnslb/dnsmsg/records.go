@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dnsmsg
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// Helpers for the records published and served for load balancers. The
+// wire format, transaction signatures and zone transfers are handled by
+// github.com/miekg/dns.
+
+// TypeString provides the mnemonic of a record type.
+func TypeString(rtype uint16) string {
+	return dns.Type(rtype).String()
+}
+
+// RcodeString provides the mnemonic of a response code.
+func RcodeString(rcode int) string {
+	if s, ok := dns.RcodeToString[rcode]; ok {
+		return s
+	}
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// EqualNames compares two DNS names case insensitively.
+func EqualNames(a, b string) bool {
+	return strings.EqualFold(dns.Fqdn(a), dns.Fqdn(b))
+}
+
+func header(name string, rtype uint16, ttl uint32) dns.RR_Header {
+	return dns.RR_Header{Name: dns.Fqdn(name), Rrtype: rtype, Class: dns.ClassINET, Ttl: ttl}
+}
+
+func NewA(name string, ttl uint32, ip net.IP) dns.RR {
+	return &dns.A{Hdr: header(name, dns.TypeA, ttl), A: ip.To4()}
+}
+
+func NewAAAA(name string, ttl uint32, ip net.IP) dns.RR {
+	return &dns.AAAA{Hdr: header(name, dns.TypeAAAA, ttl), AAAA: ip.To16()}
+}
+
+func NewCNAME(name string, ttl uint32, target string) dns.RR {
+	return &dns.CNAME{Hdr: header(name, dns.TypeCNAME, ttl), Target: dns.Fqdn(target)}
+}
+
+func NewSRV(name string, ttl uint32, priority, weight, port uint16, target string) dns.RR {
+	return &dns.SRV{Hdr: header(name, dns.TypeSRV, ttl), Priority: priority, Weight: weight, Port: port, Target: dns.Fqdn(target)}
+}
+
+// SRVValue provides the presentation format of SRV record data. The
+// target is given without trailing dot like all other targets.
+func SRVValue(priority, weight, port uint16, target string) string {
+	return fmt.Sprintf("%d %d %d %s", priority, weight, port, strings.TrimSuffix(target, "."))
+}
+
+// ParseSRV parses the presentation format of SRV record data.
+func ParseSRV(value string) (priority, weight, port uint16, target string, ok bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return 0, 0, 0, "", false
+	}
+	v := [3]uint16{}
+	for i := range v {
+		n, err := strconv.ParseUint(fields[i], 10, 16)
+		if err != nil {
+			return 0, 0, 0, "", false
+		}
+		v[i] = uint16(n)
+	}
+	return v[0], v[1], v[2], dns.Fqdn(fields[3]), true
+}
+
+func NewNS(name string, ttl uint32, host string) dns.RR {
+	return &dns.NS{Hdr: header(name, dns.TypeNS, ttl), Ns: dns.Fqdn(host)}
+}
+
+// NewTXT creates a TXT record. Texts exceeding the maximum length of a
+// character string are split.
+func NewTXT(name string, ttl uint32, texts ...string) dns.RR {
+	var strs []string
+	for _, t := range texts {
+		for len(t) > 255 {
+			strs = append(strs, t[:255])
+			t = t[255:]
+		}
+		strs = append(strs, t)
+	}
+	return &dns.TXT{Hdr: header(name, dns.TypeTXT, ttl), Txt: strs}
+}
+
+// NewSOA creates an SOA record with the given serial and default timers.
+func NewSOA(name string, ttl uint32, mname, rname string, serial uint32) dns.RR {
+	return &dns.SOA{
+		Hdr:     header(name, dns.TypeSOA, ttl),
+		Ns:      dns.Fqdn(mname),
+		Mbox:    dns.Fqdn(rname),
+		Serial:  serial,
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  ttl,
+	}
+}
+
+// DeleteRRSet creates an update record deleting all records of a type.
+func DeleteRRSet(name string, rtype uint16) dns.RR {
+	return &dns.ANY{Hdr: dns.RR_Header{Name: dns.Fqdn(name), Rrtype: rtype, Class: dns.ClassANY}}
+}
+
+// Value provides the record data in presentation format for address,
+// name and text records.
+func Value(rr dns.RR) string {
+	switch r := rr.(type) {
+	case *dns.A:
+		return r.A.String()
+	case *dns.AAAA:
+		return r.AAAA.String()
+	case *dns.CNAME:
+		return r.Target
+	case *dns.NS:
+		return r.Ns
+	case *dns.PTR:
+		return r.Ptr
+	case *dns.TXT:
+		return strings.Join(r.Txt, "")
+	case *dns.SRV:
+		return SRVValue(r.Priority, r.Weight, r.Port, r.Target)
+	}
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
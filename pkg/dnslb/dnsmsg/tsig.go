@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dnsmsg
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// Transaction signatures (RFC 8945)
+
+// TSIG_FUDGE is the permitted time difference between client and server.
+const TSIG_FUDGE = 300
+
+var algorithms = map[string]bool{
+	dns.HmacMD5:    true,
+	dns.HmacSHA1:   true,
+	dns.HmacSHA256: true,
+	dns.HmacSHA512: true,
+}
+
+// TSIGKey is a shared secret used to sign messages.
+type TSIGKey struct {
+	Name      string
+	Algorithm string
+	// Secret is the base64 encoded secret
+	Secret string
+}
+
+// NewTSIGKey creates a key for a base64 encoded secret. The algorithm
+// may be given with or without trailing dot, the short form "hmac-md5"
+// is accepted, too.
+func NewTSIGKey(name, algorithm, secret string) (*TSIGKey, error) {
+	algorithm = strings.ToLower(dns.Fqdn(algorithm))
+	if algorithm == "hmac-md5." {
+		algorithm = dns.HmacMD5
+	}
+	if !algorithms[algorithm] {
+		return nil, fmt.Errorf("unsupported TSIG algorithm %q", algorithm)
+	}
+	secret = strings.TrimSpace(secret)
+	if _, err := base64.StdEncoding.DecodeString(secret); err != nil {
+		return nil, fmt.Errorf("invalid TSIG secret: %s", err)
+	}
+	return &TSIGKey{Name: strings.ToLower(dns.Fqdn(name)), Algorithm: algorithm, Secret: secret}, nil
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile/reconcilers"
 	"github.com/gardener/controller-manager-library/pkg/resources"
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -18,11 +18,22 @@ import (
 
 const AnnotationLoadbalancer = api.GroupName + "/dnsloadbalancer"
 
+// annotations of sources for SRV records
+const AnnotationSRVPort = api.GroupName + "/srv-port"
+const AnnotationSRVPriority = api.GroupName + "/srv-priority"
+const AnnotationSRVWeight = api.GroupName + "/srv-weight"
+
 const TARGET_CLUSTER = "target"
 
 const LBUSAGES = "loadbalancer"
 
 const OPT_TARGETCHECKPERIOD = "target-check-period"
+const OPT_HEARTBEAT_NAMESPACE = "heartbeat-namespace"
+const OPT_HEARTBEAT_INTERVAL = "heartbeat-interval"
+const OPT_CLUSTER_REGION = "cluster-region"
+const OPT_CLUSTER_ZONE = "cluster-zone"
+const OPT_CLUSTER_PROVIDER = "cluster-provider"
+const OPT_CLUSTER_LABELS = "cluster-labels"
 
 var serviceGK = resources.NewGroupKind(corev1.GroupName, "Service")
 var ingressGK = resources.NewGroupKind(extensions.GroupName, "Ingress")
@@ -37,11 +48,20 @@ func init() {
 		FinalizerDomain(api.GroupName).
 		Cluster(cluster.DEFAULT). // used as main cluster
 		DefaultedDurationOption(OPT_TARGETCHECKPERIOD, 60*time.Second, "period for checking targets").
+		StringOption(OPT_HEARTBEAT_NAMESPACE, "namespace for heartbeat leases in target cluster (enables heartbeat mode)").
+		DefaultedDurationOption(OPT_HEARTBEAT_INTERVAL, 30*time.Second, "renewal period for heartbeat lease and cluster registration").
+		StringOption(OPT_CLUSTER_REGION, "region of source cluster used for cluster registration and endpoints").
+		StringOption(OPT_CLUSTER_ZONE, "zone of source cluster used for cluster registration").
+		StringOption(OPT_CLUSTER_PROVIDER, "infrastructure provider of source cluster used for cluster registration").
+		StringArrayOption(OPT_CLUSTER_LABELS, "labels (<key>=<value>) of source cluster used for cluster registration").
 		DefaultWorkerPool(3, 0).
 		MainResource(corev1.GroupName, "Service").
 		Watch(extensions.GroupName, "Ingress").
 		Reconciler(SourceReconciler).
 		Reconciler(reconcilers.UsageReconcilerTypeBySpec(nil, lbUsageAccessSpec), "usages").
+		WorkerPool("heartbeat", 1, 0).
+		Reconciler(HeartbeatReconciler, "heartbeat").
+		ReconcilerCommands("heartbeat", CMD_HEARTBEAT).
 		Cluster(TARGET_CLUSTER).
 		WorkerPool("endpoints", 3, 0).
 		Reconciler(reconcilers.SlaveReconcilerTypeBySpec(nil, lbSlaveAccessSpec), "endpoints").
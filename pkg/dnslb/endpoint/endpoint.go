@@ -6,13 +6,15 @@ package endpoint
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	dnsutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
 	"k8s.io/apimachinery/pkg/api/errors"
 
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/endpoint/sources"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -24,24 +26,31 @@ import (
 
 func (this *source_reconciler) newEndpoint(logger logger.LogContext, lb resources.Object, src sources.Source) *dnsutils.DNSLoadBalancerEndpointObject {
 	labels := map[string]string{
-		"controller": this.FinalizerHandler().FinalizerName(lb),
-		"source":     fmt.Sprintf("%s", src.Key()),
+		api.LABEL_CONTROLLER: dnsutils.LabelValue(this.FinalizerHandler().FinalizerName(lb)),
+		api.LABEL_SOURCE:     dnsutils.LabelValue(fmt.Sprintf("%s", src.Key())),
+		api.LABEL_NAMESPACE:  src.GetNamespace(),
 	}
-	if lb.GetClusterName() != src.GetClusterName() {
-		labels["cluster"] = fmt.Sprintf("%s", src.GetCluster().GetId())
+	if lb.GetCluster().GetId() != src.GetCluster().GetId() {
+		labels[api.LABEL_CLUSTER] = dnsutils.LabelValue(src.GetCluster().GetId())
 	}
 
-	ip, cname := src.GetTargets(lb)
+	ips, cname := src.GetTargets(lb)
+	port, priority, weight := this.getSRVInfo(logger, lb, src)
 	n := this.UpdateDeadline(logger, lb.Data().(*api.DNSLoadBalancer).Spec.EndpointValidityInterval, nil)
 	r, _ := this.ep_resource.Wrap(&api.DNSLoadBalancerEndpoint{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: src.GetName() + "-" + strings.ToLower(src.GroupKind().Kind) + "-",
 			Namespace:    lb.GetNamespace(),
+			Labels:       labels,
 		},
 		Spec: api.DNSLoadBalancerEndpointSpec{
-			IPAddress:    ip,
+			Addresses:    ips,
 			CName:        cname,
 			LoadBalancer: lb.GetName(),
+			Region:       this.region,
+			Port:         port,
+			Priority:     priority,
+			Weight:       weight,
 		},
 		Status: api.DNSLoadBalancerEndpointStatus{
 			ValidUntil: n,
@@ -55,25 +64,87 @@ func (this *source_reconciler) updateEndpoint(logger logger.LogContext, oldep, n
 	n := dnsutils.DNSLoadBalancerEndpoint(newep).DNSLoadBalancerEndpoint()
 	o := dnsutils.DNSLoadBalancerEndpoint(oldep).DNSLoadBalancerEndpoint()
 	mod := resources.NewModificationState(oldep)
-	mod.AssureLabel("controller", newep.GetLabel("controller"))
-	mod.AssureLabel("source", newep.GetLabel("source"))
-	mod.AssureLabel("cluster", newep.GetLabel("cluster"))
+	if o.Labels == nil {
+		o.Labels = map[string]string{}
+	}
+	mod.AssureLabel(api.LABEL_CONTROLLER, newep.GetLabel(api.LABEL_CONTROLLER))
+	mod.AssureLabel(api.LABEL_SOURCE, newep.GetLabel(api.LABEL_SOURCE))
+	mod.AssureLabel(api.LABEL_CLUSTER, newep.GetLabel(api.LABEL_CLUSTER))
+	mod.AssureLabel(api.LABEL_NAMESPACE, newep.GetLabel(api.LABEL_NAMESPACE))
 	mod.AddOwners(src)
 
-	mod.AssureStringValue(&o.Spec.IPAddress, n.Spec.IPAddress)
+	if !reflect.DeepEqual(o.Spec.Addresses, n.Spec.Addresses) {
+		o.Spec.Addresses = n.Spec.Addresses
+		mod.Modify(true)
+	}
 	mod.AssureStringValue(&o.Spec.CName, n.Spec.CName)
 	mod.AssureStringValue(&o.Spec.LoadBalancer, n.Spec.LoadBalancer)
+	mod.AssureStringValue(&o.Spec.Region, n.Spec.Region)
+	mod.AssureIntValue(&o.Spec.Port, n.Spec.Port)
+	mod.AssureIntValue(&o.Spec.Priority, n.Spec.Priority)
+	mod.AssureIntValue(&o.Spec.Weight, n.Spec.Weight)
+	return mod
+}
 
+// updateValidity extends the validity of an endpoint. It is part of the
+// status and therefore written separately from the spec.
+func (this *source_reconciler) updateValidity(logger logger.LogContext, ep resources.Object, lb resources.Object) (bool, error) {
 	lbspec := dnsutils.DNSLoadBalancer(lb).Spec()
-	t := this.UpdateDeadline(logger, lbspec.EndpointValidityInterval, o.Status.ValidUntil)
-	if t != o.Status.ValidUntil {
-		o.Status.ValidUntil = t
-		mod.Modify(true)
+	return dnsutils.ModifyStatus(ep, dnsutils.FIELD_MANAGER_ENDPOINT, func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSLoadBalancerEndpoint).Status
+		t := this.UpdateDeadline(logger, lbspec.EndpointValidityInterval, status.ValidUntil)
+		if t == status.ValidUntil {
+			return false, nil
+		}
+		status.ValidUntil = t
+		return true, nil
+	})
+}
+
+// getSRVInfo provides port, priority and weight of a source for load
+// balancers with SRV records. The port is taken from the source port
+// named by the source annotation or the load balancer, priority and
+// weight from source annotations.
+func (this *source_reconciler) getSRVInfo(logger logger.LogContext, lb resources.Object, src sources.Source) (port, priority, weight int) {
+	srv := lb.Data().(*api.DNSLoadBalancer).Spec.SRV
+	if srv == nil {
+		return
 	}
-	return mod
+	annos := src.GetAnnotations()
+	if ps, ok := src.(sources.PortSource); ok {
+		name := srv.PortName
+		if n, ok := annos[AnnotationSRVPort]; ok {
+			name = n
+		}
+		if p, ok := ps.GetPort(name); ok {
+			port = p
+		} else {
+			logger.Warnf("port %q not found for %s", name, src.ObjectName())
+		}
+	}
+	priority = this.getIntAnnotation(logger, src, AnnotationSRVPriority)
+	weight = this.getIntAnnotation(logger, src, AnnotationSRVWeight)
+	return
+}
+
+func (this *source_reconciler) getIntAnnotation(logger logger.LogContext, src sources.Source, name string) int {
+	v, ok := src.GetAnnotations()[name]
+	if !ok {
+		return 0
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 || i > 65535 {
+		logger.Warnf("invalid value %q for annotation %s of %s", v, name, src.ObjectName())
+		return 0
+	}
+	return i
 }
 
 func (this *source_reconciler) UpdateDeadline(logger logger.LogContext, duration *metav1.Duration, deadline *metav1.Time) *metav1.Time {
+	if this.heartbeat {
+		// liveness is reported by the heartbeat lease of the source cluster
+		return nil
+	}
 	if duration != nil && duration.Duration != 0 {
 		logger.Debugf("validity interval found: %s", duration.Duration)
 		now := time.Now()
@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/endpoint/sources"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
@@ -26,6 +26,8 @@ import (
 
 type source_reconciler struct {
 	targetCheckPeriod time.Duration
+	heartbeat         bool
+	region            string
 	*reconcilers.SlaveAccess
 	usages      *reconcilers.UsageAccess
 	lb_resource resources.Interface
@@ -49,8 +51,13 @@ func SourceReconciler(c controller.Interface) (reconcile.Interface, error) {
 		return nil, err
 	}
 
+	namespace, _ := c.GetStringOption(OPT_HEARTBEAT_NAMESPACE)
+	region, _ := c.GetStringOption(OPT_CLUSTER_REGION)
+
 	return &source_reconciler{
 		targetCheckPeriod: targetCheckPeriod,
+		heartbeat:         namespace != "" && c.GetMainCluster().GetId() != target.GetId(),
+		region:            region,
 		SlaveAccess:       reconcilers.NewSlaveAccessBySpec(c, lbSlaveAccessSpec),
 		usages:            reconcilers.NewUsageAccessBySpec(c, lbUsageAccessSpec),
 		lb_resource:       lb,
@@ -87,6 +94,9 @@ func (this *source_reconciler) Reconcile(logger logger.LogContext, obj resources
 			}
 			logger.Infof("dns load balancer endpoint %s created for %s", newep.ObjectName(), ref)
 			src.Eventf(corev1.EventTypeNormal, "sync", "dns load balancer endpoint %s created", newep.ObjectName())
+			if _, err := this.updateValidity(logger, newep, lb); err != nil {
+				return reconcile.Delay(logger, fmt.Errorf("error updating validity of load balancer endpoint '%s': %s", newep.ObjectName(), err))
+			}
 			return reconcile.Succeeded(logger).RescheduleAfter(this.targetCheckPeriod)
 		}
 		mod := this.updateEndpoint(logger, ep, newep, lb, src)
@@ -103,6 +113,9 @@ func (this *source_reconciler) Reconcile(logger logger.LogContext, obj resources
 		} else {
 			logger.Debugf("endpoint up to date")
 		}
+		if _, err := this.updateValidity(logger, ep, lb); err != nil {
+			return reconcile.Delay(logger, fmt.Errorf("error updating validity of load balancer endpoint '%s': %s", ep.ObjectName(), err))
+		}
 		return reconcile.Succeeded(logger).RescheduleAfter(this.targetCheckPeriod)
 	} else {
 		err := this.deleteEndpoint(logger, obj, ep)
@@ -150,10 +163,10 @@ func (this *source_reconciler) validate(logger logger.LogContext, ref resources.
 	lb, err := this.lb_resource.GetCached(ref)
 	if lb == nil || err != nil {
 		if errors.IsNotFound(err) {
-			src.Eventf(corev1.EventTypeNormal, AnnotationLoadbalancer, fmt.Sprintf("dns loadbalancer '%s' does not exist", ref))
+			src.Eventf(corev1.EventTypeNormal, AnnotationLoadbalancer, "dns loadbalancer '%s' does not exist", ref)
 			return nil, reconcile.Failed(logger, fmt.Errorf("dns loadbalancer '%s' does not exist", ref))
 		} else {
-			src.Eventf(corev1.EventTypeNormal, AnnotationLoadbalancer, fmt.Sprintf("cannot get dns loadbalancer '%s': %s", ref, err))
+			src.Eventf(corev1.EventTypeNormal, AnnotationLoadbalancer, "cannot get dns loadbalancer '%s': %s", ref, err)
 			return nil, reconcile.Delay(logger, fmt.Errorf("cannot get dns loadbalancer '%s': %s", ref, err))
 		}
 	}
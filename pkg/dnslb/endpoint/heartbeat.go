@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package endpoint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	dnsutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	coordination "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const CMD_HEARTBEAT = "heartbeat"
+
+// HeartbeatReconciler periodically renews the heartbeat lease and checks
+// the cluster registration of the source cluster in the target cluster.
+// Both are only maintained if the source cluster differs from the target
+// cluster.
+func HeartbeatReconciler(c controller.Interface) (reconcile.Interface, error) {
+	namespace, _ := c.GetStringOption(OPT_HEARTBEAT_NAMESPACE)
+	interval, err := c.GetDurationOption(OPT_HEARTBEAT_INTERVAL)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("heartbeat interval must be positive")
+	}
+	info, err := clusterInfo(c)
+	if err != nil {
+		return nil, err
+	}
+
+	target := c.GetCluster(TARGET_CLUSTER)
+	multi := c.GetMainCluster().GetId() != target.GetId()
+
+	var leases, registry resources.Interface
+	if multi && namespace != "" {
+		leases, err = target.Resources().GetByExample(&coordination.Lease{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if multi {
+		registry, err = target.Resources().GetByExample(&api.DNSLoadBalancerCluster{})
+		if err != nil {
+			c.Warnf("cluster registration not possible: %s", err)
+			registry = nil
+		}
+	}
+	return &heartbeat_reconciler{
+		controller: c,
+		namespace:  namespace,
+		interval:   interval,
+		info:       info,
+		leases:     leases,
+		registry:   registry,
+	}, nil
+}
+
+func clusterInfo(c controller.Interface) (*api.DNSLoadBalancerClusterSpec, error) {
+	info := &api.DNSLoadBalancerClusterSpec{ClusterId: c.GetMainCluster().GetId()}
+	info.Region, _ = c.GetStringOption(OPT_CLUSTER_REGION)
+	info.Zone, _ = c.GetStringOption(OPT_CLUSTER_ZONE)
+	info.Provider, _ = c.GetStringOption(OPT_CLUSTER_PROVIDER)
+	labels, _ := c.GetStringArrayOption(OPT_CLUSTER_LABELS)
+	for _, l := range labels {
+		parts := strings.SplitN(l, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid cluster label %q (expected <key>=<value>)", l)
+		}
+		if info.Labels == nil {
+			info.Labels = map[string]string{}
+		}
+		info.Labels[parts[0]] = parts[1]
+	}
+	return info, nil
+}
+
+type heartbeat_reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	namespace  string
+	interval   time.Duration
+	info       *api.DNSLoadBalancerClusterSpec
+	leases     resources.Interface
+	registry   resources.Interface
+}
+
+func (this *heartbeat_reconciler) Start() {
+	if this.leases != nil {
+		this.controller.Infof("heartbeat mode: renewing lease %s/%s every %s",
+			this.namespace, dnsutils.HeartbeatLeaseName(this.info.ClusterId), this.interval)
+	}
+	if this.registry != nil {
+		this.controller.Infof("registering cluster %q (region %q, zone %q)",
+			this.info.ClusterId, this.info.Region, this.info.Zone)
+	}
+	if this.leases != nil || this.registry != nil {
+		this.controller.EnqueueCommand(CMD_HEARTBEAT)
+	}
+}
+
+func (this *heartbeat_reconciler) Command(logger logger.LogContext, cmd string) reconcile.Status {
+	if this.leases == nil && this.registry == nil {
+		return reconcile.Succeeded(logger).Stop()
+	}
+	if this.leases != nil {
+		if err := this.renewLease(logger); err != nil {
+			return reconcile.Delay(logger, err)
+		}
+	}
+	if this.registry != nil {
+		if err := this.register(logger); err != nil {
+			return reconcile.Delay(logger, err)
+		}
+	}
+	return reconcile.Succeeded(logger).RescheduleAfter(this.interval)
+}
+
+func (this *heartbeat_reconciler) renewLease(logger logger.LogContext) error {
+	lease, err := this.leases.Wrap(&coordination.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dnsutils.HeartbeatLeaseName(this.info.ClusterId),
+			Namespace: this.namespace,
+			Labels: map[string]string{
+				api.LABEL_CLUSTER: dnsutils.LabelValue(this.info.ClusterId),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dnsutils.Lease(lease).Renew(this.info.ClusterId, 3*this.interval)
+	if err != nil {
+		return fmt.Errorf("cannot renew heartbeat lease %s: %s", lease.ObjectName(), err)
+	}
+	logger.Debugf("heartbeat lease %s renewed", lease.ObjectName())
+	return nil
+}
+
+// register creates or updates the cluster registration. It is only written
+// if the description of the cluster changed, the liveness of the cluster is
+// reported by the heartbeat lease only.
+func (this *heartbeat_reconciler) register(logger logger.LogContext) error {
+	name := resources.NewObjectName(dnsutils.ClusterObjectName(this.info.ClusterId))
+	if cur, err := this.registry.GetCached(name); err == nil {
+		if reflect.DeepEqual(&dnsutils.DNSLoadBalancerCluster(cur).DNSLoadBalancerCluster().Spec, this.info) {
+			return nil
+		}
+	}
+	o, err := this.registry.Wrap(&api.DNSLoadBalancerCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name.Name(),
+			Labels: map[string]string{
+				api.LABEL_CLUSTER: dnsutils.LabelValue(this.info.ClusterId),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = o.CreateOrModify(func(data resources.ObjectData) (bool, error) {
+		cluster := data.(*api.DNSLoadBalancerCluster)
+		if reflect.DeepEqual(&cluster.Spec, this.info) {
+			return false, nil
+		}
+		logger.Infof("updating registration for cluster %q", this.info.ClusterId)
+		cluster.Spec = *this.info.DeepCopy()
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot update cluster registration %s: %s", o.ObjectName(), err)
+	}
+	logger.Debugf("cluster registration %s updated", o.ObjectName())
+	return nil
+}
@@ -38,18 +38,18 @@ func (this *SourceType) Get(obj resources.Object) (sources.Source, error) {
 	return &Source{resources.Ingress(obj)}, nil
 }
 
-func (this *Source) GetTargets(lb resources.Object) (ip, cname string) {
+func (this *Source) GetTargets(lb resources.Object) (ips []string, cname string) {
 	data := this.Ingress()
 	target := utils.DNSLoadBalancer(lb).DNSLoadBalancer()
 	for _, l := range data.Status.LoadBalancer.Ingress {
 		if l.IP != "" {
-			ip = l.IP
+			ips = append(ips, l.IP)
 		}
 		if l.Hostname != "" {
 			cname = l.Hostname
 		}
 	}
-	if cname == "" && ip == "" {
+	if cname == "" && len(ips) == 0 {
 		for _, i := range data.Spec.Rules {
 			if i.Host != "" && i.Host != target.Spec.DNSName {
 				cname = i.Host
@@ -74,8 +74,8 @@ func (this *Source) Validate(lb resources.Object) (bool, error) {
 	if !dns {
 		return false, fmt.Errorf("load balancer host '%s' not configured as host rule for '%s'", target.Spec.DNSName, this.ObjectName())
 	}
-	cname, ip := this.GetTargets(lb)
-	if cname == "" && ip == "" {
+	ips, cname := this.GetTargets(lb)
+	if cname == "" && len(ips) == 0 {
 		return false, fmt.Errorf("no host rule or loadbalancer status defined for '%s'", this.ObjectName())
 	}
 	return true, nil
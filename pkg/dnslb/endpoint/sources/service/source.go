@@ -21,6 +21,7 @@ type SourceType struct {
 }
 
 var _ sources.Source = &Source{}
+var _ sources.PortSource = &Source{}
 
 func init() {
 	sources.Register(&SourceType{resources.NewGroupKind(api.GroupName, "Service")})
@@ -37,11 +38,11 @@ func (this *SourceType) Get(obj resources.Object) (sources.Source, error) {
 	return &Source{resources.Service(obj)}, nil
 }
 
-func (this *Source) GetTargets(lb resources.Object) (ip, cname string) {
+func (this *Source) GetTargets(lb resources.Object) (ips []string, cname string) {
 	status := this.Status()
 	for _, i := range status.LoadBalancer.Ingress {
 		if i.IP != "" {
-			ip = i.IP
+			ips = append(ips, i.IP)
 		}
 		if i.Hostname != "" {
 			cname = i.Hostname
@@ -50,6 +51,22 @@ func (this *Source) GetTargets(lb resources.Object) (ip, cname string) {
 	return
 }
 
+func (this *Source) GetPort(name string) (int, bool) {
+	ports := this.Service().Spec.Ports
+	if name == "" {
+		if len(ports) == 1 {
+			return int(ports[0].Port), true
+		}
+		return 0, false
+	}
+	for _, p := range ports {
+		if p.Name == name {
+			return int(p.Port), true
+		}
+	}
+	return 0, false
+}
+
 func (this *Source) Validate(lb resources.Object) (bool, error) {
 	ok, err := HasLoadBalancer(this.Service())
 	if err != nil {
@@ -58,8 +75,8 @@ func (this *Source) Validate(lb resources.Object) (bool, error) {
 	if !ok {
 		return true, fmt.Errorf("load balancer not yet assigned for '%s'", this.ObjectName())
 	}
-	cname, ip := this.GetTargets(lb)
-	if cname == "" && ip == "" {
+	ips, cname := this.GetTargets(lb)
+	if cname == "" && len(ips) == 0 {
 		return false, fmt.Errorf("no host rule or loadbalancer status defined for '%s'", this.ObjectName())
 	}
 	return true, nil
@@ -11,10 +11,19 @@ import (
 
 type Source interface {
 	resources.Object
-	GetTargets(lb resources.Object) (ip, cname string)
+	// GetTargets provides the ip addresses or the host name of a source
+	GetTargets(lb resources.Object) (ips []string, cname string)
 	Validate(lb resources.Object) (bool, error)
 }
 
+// PortSource is implemented by sources providing named ports
+// used for SRV records.
+type PortSource interface {
+	// GetPort provides the port with the given name. For an empty name
+	// the only port is provided.
+	GetPort(name string) (int, bool)
+}
+
 type SourceType interface {
 	GetGroupKind() schema.GroupKind
 	Get(resources.Object) (Source, error)
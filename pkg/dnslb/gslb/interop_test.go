@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gslb_test
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/miekg/dns"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/gslb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// freeAddr provides a local address with a port currently unused for UDP
+// and TCP.
+func freeAddr() string {
+	for {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr := l.Addr().String()
+		l.Close()
+		if c, err := net.ListenPacket("udp", addr); err == nil {
+			c.Close()
+			return addr
+		}
+	}
+}
+
+var _ = Describe("interoperability", func() {
+	var registry *Registry
+	var server *Server
+	var addr string
+	lb := resources.NewObjectName("default", "lb")
+
+	exchange := func(network string, m *dns.Msg) *dns.Msg {
+		c := &dns.Client{Net: network}
+		r, _, err := c.Exchange(m, addr)
+		Expect(err).NotTo(HaveOccurred())
+		return r
+	}
+	query := func(network, name string, rtype uint16) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetQuestion(name, rtype)
+		return exchange(network, m)
+	}
+
+	BeforeEach(func() {
+		var err error
+		registry = NewRegistry()
+		server, err = NewServer(logger.New(), registry, Config{Zones: []string{"gslb.example.org"}, NameServers: []string{"ns1.example.org"}, TTL: 30})
+		Expect(err).NotTo(HaveOccurred())
+		addr = freeAddr()
+		Expect(server.Start(addr)).To(Succeed())
+
+		targets := utils.NewStringSet()
+		for i := 1; i <= 40; i++ {
+			targets.Add(fmt.Sprintf("10.0.0.%d", i))
+		}
+		Expect(registry.Update(lb, map[string]*Answer{
+			"www.gslb.example.org":       {Targets: utils.NewStringSet("10.0.0.1", "2001:db8::1"), TTL: 20},
+			"many.gslb.example.org":      {Targets: targets, TTL: 20},
+			"alias.gslb.example.org":     {Targets: utils.NewStringSet("lb.other.org"), TTL: 20},
+			"_sip._tcp.gslb.example.org": {Targets: utils.NewStringSet(SRVValue(0, 1, 5060, "ep1.gslb.example.org")), TTL: 20},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("miekg/dns client", func() {
+		It("should answer queries via UDP and TCP", func() {
+			for _, network := range []string{"udp", "tcp"} {
+				r := query(network, "www.gslb.example.org.", dns.TypeA)
+				Expect(r.Rcode).To(Equal(dns.RcodeSuccess))
+				Expect(r.Authoritative).To(BeTrue())
+				Expect(r.Answer).To(HaveLen(1))
+				Expect(r.Answer[0].String()).To(Equal("www.gslb.example.org.\t20\tIN\tA\t10.0.0.1"))
+
+				r = query(network, "www.gslb.example.org.", dns.TypeAAAA)
+				Expect(r.Answer[0].(*dns.AAAA).AAAA.String()).To(Equal("2001:db8::1"))
+
+				r = query(network, "alias.gslb.example.org.", dns.TypeA)
+				Expect(r.Answer[0].(*dns.CNAME).Target).To(Equal("lb.other.org."))
+
+				r = query(network, "_sip._tcp.gslb.example.org.", dns.TypeSRV)
+				Expect(r.Answer[0].String()).To(Equal("_sip._tcp.gslb.example.org.\t20\tIN\tSRV\t0 1 5060 ep1.gslb.example.org."))
+			}
+		})
+
+		It("should answer zone and negative queries", func() {
+			r := query("udp", "gslb.example.org.", dns.TypeSOA)
+			soa := r.Answer[0].(*dns.SOA)
+			Expect(soa.Ns).To(Equal("ns1.example.org."))
+			Expect(soa.Minttl).To(Equal(uint32(30)))
+			Expect(soa.Serial).To(Equal(registry.Serial()))
+
+			r = query("udp", "gslb.example.org.", dns.TypeNS)
+			Expect(r.Answer[0].(*dns.NS).Ns).To(Equal("ns1.example.org."))
+
+			r = query("udp", "other.gslb.example.org.", dns.TypeA)
+			Expect(r.Rcode).To(Equal(dns.RcodeNameError))
+			Expect(r.Ns[0].Header().Rrtype).To(Equal(dns.TypeSOA))
+
+			r = query("udp", "www.example.com.", dns.TypeA)
+			Expect(r.Rcode).To(Equal(dns.RcodeRefused))
+		})
+
+		It("should accept queries with EDNS", func() {
+			m := new(dns.Msg)
+			m.SetQuestion("www.gslb.example.org.", dns.TypeA)
+			m.SetEdns0(4096, false)
+			r := exchange("udp", m)
+			Expect(r.Rcode).To(Equal(dns.RcodeSuccess))
+			Expect(r.Answer).To(HaveLen(1))
+		})
+
+		It("should truncate large UDP responses", func() {
+			r := query("udp", "many.gslb.example.org.", dns.TypeA)
+			Expect(r.Truncated).To(BeTrue())
+			Expect(r.Answer).To(BeEmpty())
+
+			r = query("tcp", "many.gslb.example.org.", dns.TypeA)
+			Expect(r.Truncated).To(BeFalse())
+			Expect(r.Answer).To(HaveLen(40))
+		})
+	})
+
+	// dig is used as independent client if it is installed.
+	Describe("dig", func() {
+		dig := func(args ...string) string {
+			host, port, _ := net.SplitHostPort(addr)
+			out, err := exec.Command("dig", append([]string{"@" + host, "-p", port, "+noall", "+answer"}, args...)...).CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(out))
+			return strings.Join(strings.Fields(string(out)), " ")
+		}
+
+		BeforeEach(func() {
+			if _, err := exec.LookPath("dig"); err != nil {
+				Skip("dig not installed")
+			}
+		})
+
+		It("should answer queries", func() {
+			Expect(dig("www.gslb.example.org", "A")).To(Equal("www.gslb.example.org. 20 IN A 10.0.0.1"))
+			Expect(dig("+tcp", "_sip._tcp.gslb.example.org", "SRV")).To(Equal("_sip._tcp.gslb.example.org. 20 IN SRV 0 1 5060 ep1.gslb.example.org."))
+			Expect(strings.Fields(dig("many.gslb.example.org", "A"))).To(HaveLen(40 * 5))
+		})
+	})
+})
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gslb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/miekg/dns"
+)
+
+// Answer describes the targets served for a DNS name.
+type Answer struct {
+	Targets utils.StringSet
+	// Exclusive answers contain a single target only, otherwise
+	// all targets are served in round-robin order.
+	Exclusive bool
+	TTL       uint32
+}
+
+type entry struct {
+	owner   resources.ObjectName
+	answer  Answer
+	targets []string
+	next    int
+}
+
+// Registry keeps the live answers for the DNS names of all load balancers.
+type Registry struct {
+	lock   sync.Mutex
+	names  map[string]*entry
+	owners map[resources.ObjectName]utils.StringSet
+	serial uint32
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		names:  map[string]*entry{},
+		owners: map[resources.ObjectName]utils.StringSet{},
+		serial: uint32(time.Now().Unix()),
+	}
+}
+
+func key(name string) string {
+	return strings.ToLower(dns.Fqdn(name))
+}
+
+// Serial provides the zone serial, which is incremented for every change.
+func (this *Registry) Serial() uint32 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.serial
+}
+
+// Update sets the answers for the DNS names of a load balancer. Names of
+// the load balancer not mentioned anymore are removed. Names served for
+// other load balancers are rejected.
+func (this *Registry) Update(owner resources.ObjectName, answers map[string]*Answer) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	names := utils.StringSet{}
+	var conflicts []string
+	changed := false
+	for name, a := range answers {
+		k := key(name)
+		e := this.names[k]
+		if e != nil && e.owner != owner {
+			conflicts = append(conflicts, fmt.Sprintf("%s (used by %s)", name, e.owner))
+			continue
+		}
+		names.Add(k)
+		if e != nil && e.answer.Exclusive == a.Exclusive && e.answer.TTL == a.TTL && e.answer.Targets.Equals(a.Targets) {
+			continue
+		}
+		targets := a.Targets.AsArray()
+		sort.Strings(targets)
+		this.names[k] = &entry{owner: owner, answer: Answer{Targets: a.Targets.Copy(), Exclusive: a.Exclusive, TTL: a.TTL}, targets: targets}
+		changed = true
+	}
+	for k := range this.owners[owner] {
+		if !names.Contains(k) {
+			delete(this.names, k)
+			changed = true
+		}
+	}
+	if len(names) > 0 {
+		this.owners[owner] = names
+	} else {
+		delete(this.owners, owner)
+	}
+	if changed {
+		this.serial++
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("dns names already served: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// Remove removes all DNS names of a load balancer.
+func (this *Registry) Remove(owner resources.ObjectName) {
+	this.Update(owner, nil)
+}
+
+// Get provides the targets currently served for a DNS name.
+func (this *Registry) Get(name string) (*Answer, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	e := this.names[key(name)]
+	if e == nil {
+		return nil, false
+	}
+	return &Answer{Targets: e.answer.Targets.Copy(), Exclusive: e.answer.Exclusive, TTL: e.answer.TTL}, true
+}
+
+// Next provides the targets to answer a single query for a DNS name.
+// Balanced names are rotated for every query, exclusive names always
+// provide the same single target.
+func (this *Registry) Next(name string) ([]string, uint32, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	e := this.names[key(name)]
+	if e == nil {
+		return nil, 0, false
+	}
+	n := len(e.targets)
+	if n == 0 {
+		return nil, e.answer.TTL, true
+	}
+	if e.answer.Exclusive {
+		return e.targets[:1], e.answer.TTL, true
+	}
+	result := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		result = append(result, e.targets[(e.next+i)%n])
+	}
+	e.next = (e.next + 1) % n
+	return result, e.answer.TTL, true
+}
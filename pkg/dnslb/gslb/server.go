@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gslb
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/miekg/dns"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/metrics"
+)
+
+// MAX_UDP_SIZE is the maximum size of responses sent via UDP. Larger
+// responses are truncated to force clients to retry via TCP.
+const MAX_UDP_SIZE = 512
+
+const TCP_TIMEOUT = 10 * time.Second
+
+// UNKNOWN_NAME is used as metrics label for queries of unknown names.
+const UNKNOWN_NAME = "-"
+
+// Config describes the zones delegated to the server.
+type Config struct {
+	Zones []string
+	// NameServers are the host names of the name servers of the zones.
+	NameServers []string
+	// Hostmaster is the mail address published in the SOA records.
+	Hostmaster string
+	// TTL is the maximum TTL of all answers and the negative caching TTL.
+	TTL uint32
+}
+
+// Server is an authoritative DNS server for zones delegated to it. It
+// answers queries for the DNS names of load balancers from the live health
+// state kept in a registry.
+type Server struct {
+	logger   logger.LogContext
+	config   Config
+	registry *Registry
+
+	lock    sync.Mutex
+	servers []*dns.Server
+}
+
+func NewServer(logger logger.LogContext, registry *Registry, config Config) (*Server, error) {
+	if len(config.Zones) == 0 {
+		return nil, fmt.Errorf("no zones configured")
+	}
+	zones := make([]string, len(config.Zones))
+	for i, z := range config.Zones {
+		zones[i] = strings.ToLower(dns.Fqdn(z))
+	}
+	// prefer the most specific zone for nested zones
+	sort.Slice(zones, func(i, j int) bool { return len(zones[i]) > len(zones[j]) })
+	config.Zones = zones
+	if len(config.NameServers) == 0 {
+		config.NameServers = []string{"ns." + zones[len(zones)-1]}
+	}
+	if config.Hostmaster == "" {
+		config.Hostmaster = "hostmaster." + zones[len(zones)-1]
+	}
+	config.Hostmaster = strings.Replace(config.Hostmaster, "@", ".", 1)
+	return &Server{logger: logger, config: config, registry: registry}, nil
+}
+
+func (this *Server) Registry() *Registry {
+	return this.registry
+}
+
+// Zone provides the served zone containing a DNS name or an empty string.
+func (this *Server) Zone(name string) string {
+	for _, z := range this.config.Zones {
+		if dns.IsSubDomain(z, dns.Fqdn(name)) {
+			return z
+		}
+	}
+	return ""
+}
+
+// TTL provides the TTL used to serve a DNS name for a requested TTL.
+func (this *Server) TTL(ttl *int64) uint32 {
+	if ttl != nil && *ttl > 0 && uint32(*ttl) < this.config.TTL {
+		return uint32(*ttl)
+	}
+	return this.config.TTL
+}
+
+// Start starts serving queries via UDP and TCP on the given address.
+func (this *Server) Start(addr string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	udp, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	tcp, err := net.Listen("tcp", addr)
+	if err != nil {
+		udp.Close()
+		return err
+	}
+	timeout := func() time.Duration { return TCP_TIMEOUT }
+	this.servers = []*dns.Server{
+		{PacketConn: udp, Handler: this},
+		{Listener: tcp, Handler: this, ReadTimeout: TCP_TIMEOUT, WriteTimeout: TCP_TIMEOUT, IdleTimeout: timeout},
+	}
+	this.logger.Infof("serving zones %s on %s", strings.Join(this.config.Zones, ", "), addr)
+	for _, s := range this.servers {
+		started := make(chan struct{})
+		s.NotifyStartedFunc = func() { close(started) }
+		go func(s *dns.Server) {
+			if err := s.ActivateAndServe(); err != nil {
+				this.logger.Errorf("serving DNS failed: %s", err)
+			}
+		}(s)
+		<-started
+	}
+	return nil
+}
+
+// Close stops serving queries.
+func (this *Server) Close() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for _, s := range this.servers {
+		s.Shutdown()
+	}
+	this.servers = nil
+}
+
+// ServeDNS answers a request received via UDP or TCP. If a response sent
+// via UDP exceeds MAX_UDP_SIZE, it is truncated.
+func (this *Server) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	resp := this.Handle(req)
+	if _, ok := w.RemoteAddr().(*net.UDPAddr); ok && resp.Len() > MAX_UDP_SIZE {
+		resp.Truncated = true
+		resp.Answer, resp.Ns, resp.Extra = nil, nil, nil
+	}
+	if err := w.WriteMsg(resp); err != nil {
+		this.logger.Warnf("cannot send response: %s", err)
+	}
+}
+
+// Handle answers a query.
+func (this *Server) Handle(req *dns.Msg) *dns.Msg {
+	resp := &dns.Msg{
+		MsgHdr: dns.MsgHdr{
+			Id:               req.Id,
+			Response:         true,
+			Opcode:           req.Opcode,
+			RecursionDesired: req.RecursionDesired,
+		},
+		Question: req.Question,
+	}
+	if req.Opcode != dns.OpcodeQuery {
+		resp.Rcode = dns.RcodeNotImplemented
+		return resp
+	}
+	if len(req.Question) != 1 {
+		resp.Rcode = dns.RcodeFormatError
+		return resp
+	}
+	q := req.Question[0]
+	label := UNKNOWN_NAME
+	defer func() {
+		metrics.ReportDNSQuery(label, dnsmsg.TypeString(q.Qtype), dnsmsg.RcodeString(resp.Rcode))
+	}()
+
+	zone := this.Zone(q.Name)
+	if zone == "" || (q.Qclass != dns.ClassINET && q.Qclass != dns.ClassANY) {
+		resp.Rcode = dns.RcodeRefused
+		return resp
+	}
+	resp.Authoritative = true
+
+	if dnsmsg.EqualNames(q.Name, zone) {
+		label = zone
+		switch q.Qtype {
+		case dns.TypeSOA:
+			resp.Answer = []dns.RR{this.soa(zone)}
+		case dns.TypeNS:
+			resp.Answer = this.ns(zone)
+		case dns.TypeANY:
+			resp.Answer = append([]dns.RR{this.soa(zone)}, this.ns(zone)...)
+		default:
+			resp.Ns = []dns.RR{this.soa(zone)}
+		}
+		return resp
+	}
+
+	targets, ttl, ok := this.registry.Next(q.Name)
+	if !ok || len(targets) == 0 {
+		if ok {
+			label = strings.ToLower(q.Name)
+		}
+		// names without healthy targets are not resolvable
+		resp.Rcode = dns.RcodeNameError
+		resp.Ns = []dns.RR{this.soa(zone)}
+		return resp
+	}
+	label = strings.ToLower(q.Name)
+	resp.Answer = this.records(q.Name, q.Qtype, targets, ttl)
+	if len(resp.Answer) == 0 {
+		resp.Ns = []dns.RR{this.soa(zone)}
+	}
+	return resp
+}
+
+// records provides the matching records for the targets of a DNS name.
+// Addresses are served as A or AAAA records, SRV data as SRV records.
+// Host names are served as a single CNAME record for all query types.
+func (this *Server) records(name string, qtype uint16, targets []string, ttl uint32) []dns.RR {
+	var result []dns.RR
+	for _, t := range targets {
+		ip := net.ParseIP(t)
+		if ip == nil {
+			if priority, weight, port, target, ok := dnsmsg.ParseSRV(t); ok {
+				if qtype == dns.TypeSRV || qtype == dns.TypeANY {
+					result = append(result, dnsmsg.NewSRV(name, ttl, priority, weight, port, target))
+				}
+				continue
+			}
+			if len(result) == 0 {
+				return []dns.RR{dnsmsg.NewCNAME(name, ttl, t)}
+			}
+			continue
+		}
+		if ip.To4() != nil {
+			if qtype == dns.TypeA || qtype == dns.TypeANY {
+				result = append(result, dnsmsg.NewA(name, ttl, ip))
+			}
+		} else {
+			if qtype == dns.TypeAAAA || qtype == dns.TypeANY {
+				result = append(result, dnsmsg.NewAAAA(name, ttl, ip))
+			}
+		}
+	}
+	return result
+}
+
+func (this *Server) soa(zone string) dns.RR {
+	return dnsmsg.NewSOA(zone, this.config.TTL, this.config.NameServers[0], this.config.Hostmaster, this.registry.Serial())
+}
+
+func (this *Server) ns(zone string) []dns.RR {
+	var result []dns.RR
+	for _, n := range this.config.NameServers {
+		result = append(result, dnsmsg.NewNS(zone, this.config.TTL, n))
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gslb_test
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/miekg/dns"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/gslb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("gslb", func() {
+	var registry *Registry
+	var server *Server
+	lb := resources.NewObjectName("default", "lb")
+
+	query := func(name string, rtype uint16) *dns.Msg {
+		m := &dns.Msg{}
+		m.SetQuestion(dns.Fqdn(name), rtype)
+		return server.Handle(m)
+	}
+	values := func(rrs []dns.RR) []string {
+		var result []string
+		for _, rr := range rrs {
+			result = append(result, Value(rr))
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		var err error
+		registry = NewRegistry()
+		server, err = NewServer(logger.New(), registry, Config{Zones: []string{"gslb.example.org"}, NameServers: []string{"ns1.example.org", "ns2.example.org"}, TTL: 30})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should answer SOA and NS queries for the zone", func() {
+		r := query("gslb.example.org", dns.TypeSOA)
+		Expect(r.Rcode).To(Equal(dns.RcodeSuccess))
+		Expect(r.Authoritative).To(BeTrue())
+		Expect(r.Answer).To(HaveLen(1))
+		Expect(r.Answer[0].Header().Rrtype).To(Equal(dns.TypeSOA))
+
+		r = query("gslb.example.org", dns.TypeNS)
+		Expect(values(r.Answer)).To(Equal([]string{"ns1.example.org.", "ns2.example.org."}))
+	})
+
+	It("should rotate answers for balanced names", func() {
+		Expect(registry.Update(lb, map[string]*Answer{"www.gslb.example.org": {Targets: utils.NewStringSet("10.0.0.1", "10.0.0.2"), TTL: 20}})).To(Succeed())
+		r := query("www.gslb.example.org", dns.TypeA)
+		Expect(values(r.Answer)).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+		Expect(r.Answer[0].Header().Ttl).To(Equal(uint32(20)))
+		r = query("WWW.gslb.example.org", dns.TypeA)
+		Expect(values(r.Answer)).To(Equal([]string{"10.0.0.2", "10.0.0.1"}))
+	})
+
+	It("should provide a single answer for exclusive names", func() {
+		registry.Update(lb, map[string]*Answer{"www.gslb.example.org": {Targets: utils.NewStringSet("10.0.0.1", "10.0.0.2"), Exclusive: true, TTL: 20}})
+		Expect(values(query("www.gslb.example.org", dns.TypeA).Answer)).To(Equal([]string{"10.0.0.1"}))
+		Expect(values(query("www.gslb.example.org", dns.TypeA).Answer)).To(Equal([]string{"10.0.0.1"}))
+	})
+
+	It("should answer host names with CNAME records", func() {
+		registry.Update(lb, map[string]*Answer{"www.gslb.example.org": {Targets: utils.NewStringSet("lb.other.org"), TTL: 20}})
+		r := query("www.gslb.example.org", dns.TypeAAAA)
+		Expect(r.Answer).To(HaveLen(1))
+		Expect(r.Answer[0].Header().Rrtype).To(Equal(dns.TypeCNAME))
+		Expect(Value(r.Answer[0])).To(Equal("lb.other.org."))
+	})
+
+	It("should answer SRV records", func() {
+		registry.Update(lb, map[string]*Answer{"_sip._tcp.gslb.example.org": {Targets: utils.NewStringSet(SRVValue(0, 1, 5060, "ep1.gslb.example.org")), TTL: 20}})
+		r := query("_sip._tcp.gslb.example.org", dns.TypeSRV)
+		Expect(values(r.Answer)).To(Equal([]string{"0 1 5060 ep1.gslb.example.org"}))
+		r = query("_sip._tcp.gslb.example.org", dns.TypeA)
+		Expect(r.Answer).To(BeEmpty())
+	})
+
+	It("should answer unknown names and types with negative responses", func() {
+		registry.Update(lb, map[string]*Answer{"www.gslb.example.org": {Targets: utils.NewStringSet("10.0.0.1"), TTL: 20}})
+		r := query("www.gslb.example.org", dns.TypeAAAA)
+		Expect(r.Rcode).To(Equal(dns.RcodeSuccess))
+		Expect(r.Answer).To(BeEmpty())
+		Expect(r.Ns[0].Header().Rrtype).To(Equal(dns.TypeSOA))
+
+		r = query("other.gslb.example.org", dns.TypeA)
+		Expect(r.Rcode).To(Equal(dns.RcodeNameError))
+		Expect(r.Ns[0].Header().Rrtype).To(Equal(dns.TypeSOA))
+
+		r = query("www.example.com", dns.TypeA)
+		Expect(r.Rcode).To(Equal(dns.RcodeRefused))
+		Expect(r.Authoritative).To(BeFalse())
+	})
+
+	It("should remove names and reject foreign names", func() {
+		serial := registry.Serial()
+		registry.Update(lb, map[string]*Answer{"www.gslb.example.org": {Targets: utils.NewStringSet("10.0.0.1"), TTL: 20}})
+		Expect(registry.Serial()).To(Equal(serial + 1))
+
+		other := resources.NewObjectName("default", "other")
+		Expect(registry.Update(other, map[string]*Answer{"www.gslb.example.org": {Targets: utils.NewStringSet("10.0.0.2"), TTL: 20}})).NotTo(Succeed())
+
+		registry.Remove(lb)
+		Expect(query("www.gslb.example.org", dns.TypeA).Rcode).To(Equal(dns.RcodeNameError))
+		Expect(registry.Serial()).To(Equal(serial + 2))
+	})
+
+	It("should not answer other opcodes", func() {
+		m := &dns.Msg{}
+		m.SetUpdate("gslb.example.org.")
+		r := server.Handle(m)
+		Expect(r.Rcode).To(Equal(dns.RcodeNotImplemented))
+	})
+})
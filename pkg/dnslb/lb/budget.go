@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+)
+
+const KEY_BUDGET = "change-budget"
+
+// sharedLimiter provides the change limiter shared by all sources of
+// the controller, it tracks the changes for the global change budget.
+func sharedLimiter(c controller.Interface, class string) (*watch.ChangeLimiter, error) {
+	lbs, err := c.GetMainCluster().Resources().GetByExample(&api.DNSLoadBalancer{})
+	if err != nil {
+		return nil, err
+	}
+	return c.GetOrCreateSharedValue(KEY_BUDGET, func() interface{} {
+		limit, _ := c.GetIntOption(OPT_CHANGE_BUDGET)
+		window, _ := c.GetDurationOption(OPT_CHANGE_BUDGET_WINDOW)
+		if limit > 0 {
+			c.Infof("global change budget: %d changes per %s", limit, window)
+		}
+		return watch.NewChangeLimiter(limit, window, changeHistory(lbs, class))
+	}).(*watch.ChangeLimiter), nil
+}
+
+// changeHistory provides the changes recorded in the status of all load
+// balancers of a class. The cache contains all load balancers, so the
+// global change budget is shared by all shards. The limiter reads it once
+// per watch.CHANGE_HISTORY_REFRESH only.
+func changeHistory(lbs resources.Interface, class string) watch.ChangeHistory {
+	return func() map[string][]time.Time {
+		result := map[string][]time.Time{}
+		list, err := lbs.ListCached(labels.Everything())
+		if err != nil {
+			return result
+		}
+		for _, o := range list {
+			if GetClass(o) != class {
+				continue
+			}
+			result[o.ObjectName().String()] = watch.ChangeTimes(lbutils.DNSLoadBalancer(o).Status().Changes)
+		}
+		return result
+	}
+}
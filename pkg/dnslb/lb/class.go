@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"reflect"
+	"sync"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// AnnotationClass selects the controller instance responsible for a
+// load balancer. Load balancers without class belong to DEFAULT_CLASS.
+const AnnotationClass = api.GroupName + "/class"
+
+const DEFAULT_CLASS = "default"
+
+var KEY_RESPONSIBILITY = reflect.TypeOf((*Responsibility)(nil))
+
+// GetClass provides the class of a load balancer.
+func GetClass(obj resources.Object) string {
+	class := obj.GetAnnotations()[AnnotationClass]
+	if class == "" {
+		return DEFAULT_CLASS
+	}
+	return class
+}
+
+// Responsibility decides which load balancers are reconciled by a DNS
+// controller. It handles only the load balancers of the class given by
+// the option OPT_LB_CLASS. In sharded mode CONTROLLER_SHARDS handles the
+// load balancers assigned to this replica and CONTROLLER none of them.
+type Responsibility struct {
+	class    string
+	sharding *Sharding
+	shards   bool
+}
+
+// responsibility provides the responsibility shared by the reconcilers of
+// a controller.
+func responsibility(c controller.Interface) *Responsibility {
+	return c.GetOrCreateSharedValue(KEY_RESPONSIBILITY, func() interface{} {
+		class, _ := c.GetStringOption(OPT_LB_CLASS)
+		if class == "" {
+			class = DEFAULT_CLASS
+		}
+		return &Responsibility{
+			class:    class,
+			sharding: sharedSharding(c, class),
+			shards:   c.GetName() == CONTROLLER_SHARDS,
+		}
+	}).(*Responsibility)
+}
+
+// Class provides the load balancer class handled by the controller.
+func (this *Responsibility) Class() string {
+	return this.class
+}
+
+// HasClass checks whether a load balancer belongs to the class of the
+// controller.
+func (this *Responsibility) HasClass(obj resources.Object) bool {
+	return GetClass(obj) == this.class
+}
+
+// IsResponsibleFor checks whether a load balancer is reconciled by the
+// controller.
+func (this *Responsibility) IsResponsibleFor(obj resources.Object) bool {
+	if !this.HasClass(obj) {
+		return false
+	}
+	shards := this.sharding.Shards()
+	if this.shards {
+		return shards.IsResponsibleFor(obj.ObjectName())
+	}
+	return shards == nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ResponsibleReconciler restricts a reconciler of the load balancers to
+// the load balancers the controller is responsible for. A load balancer
+// handled before is passed on once more after the responsibility moved
+// to another controller or replica, so its local state can be dropped.
+func ResponsibleReconciler(rtype controller.ReconcilerType) controller.ReconcilerType {
+	return func(c controller.Interface) (reconcile.Interface, error) {
+		nested, err := rtype(c)
+		if err != nil {
+			return nil, err
+		}
+		return &responsible_reconciler{
+			Interface:      nested,
+			responsibility: responsibility(c),
+			handled:        resources.ClusterObjectKeySet{},
+		}, nil
+	}
+}
+
+type responsible_reconciler struct {
+	reconcile.Interface
+	responsibility *Responsibility
+
+	lock    sync.Mutex
+	handled resources.ClusterObjectKeySet
+}
+
+// accept checks whether a load balancer is passed on and tracks the
+// load balancers handled by the controller.
+func (this *responsible_reconciler) accept(obj resources.Object) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	key := obj.ClusterKey()
+	if this.responsibility.IsResponsibleFor(obj) {
+		this.handled.Add(key)
+		return true
+	}
+	if this.handled.Contains(key) {
+		this.handled.Remove(key)
+		return true
+	}
+	return false
+}
+
+func (this *responsible_reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	if !this.accept(obj) {
+		return reconcile.Succeeded(logger)
+	}
+	return this.Interface.Reconcile(logger, obj)
+}
+
+func (this *responsible_reconciler) Delete(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	if !this.accept(obj) {
+		return reconcile.Succeeded(logger)
+	}
+	return this.Interface.Delete(logger, obj)
+}
+
+func (this *responsible_reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	this.lock.Lock()
+	handled := this.handled.Contains(key)
+	this.handled.Remove(key)
+	this.lock.Unlock()
+	if !handled {
+		return reconcile.Succeeded(logger)
+	}
+	return this.Interface.Deleted(logger, key)
+}
@@ -5,21 +5,126 @@
 package lb
 
 import (
+	"time"
+
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
-	"github.com/gardener/dnslb-controller-manager/pkg/crds"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 )
 
 var OPT_BOGUS_NXDOMAIN = "bogus-nxdomain"
+var OPT_HEARTBEAT_NAMESPACE = "heartbeat-namespace"
+var OPT_STARTUP_GRACE = "startup-grace-period"
+var OPT_CLEANUP_POLICY = "cleanup-policy"
+var OPT_BACKEND = "backend"
+var OPT_RFC2136_SERVER = "rfc2136-server"
+var OPT_RFC2136_ZONE = "rfc2136-zone"
+var OPT_RFC2136_OWNER = "rfc2136-owner-id"
+var OPT_RFC2136_TSIG_KEY = "rfc2136-tsig-key"
+var OPT_RFC2136_TSIG_ALGORITHM = "rfc2136-tsig-algorithm"
+var OPT_RFC2136_TSIG_SECRET_FILE = "rfc2136-tsig-secret-file"
+var OPT_GSLB_PORT = "gslb-port"
+var OPT_GSLB_ZONES = "gslb-zones"
+var OPT_GSLB_NAMESERVERS = "gslb-nameservers"
+var OPT_GSLB_HOSTMASTER = "gslb-hostmaster"
+var OPT_GSLB_TTL = "gslb-ttl"
+var OPT_LB_CLASS = "lb-class"
+var OPT_FREEZE = "freeze"
+var OPT_FREEZE_CONFIGMAP = "freeze-configmap"
+var OPT_SHARD_NAMESPACE = "shard-namespace"
+var OPT_SHARD_ID = "shard-id"
+var OPT_SHARD_LEASE_DURATION = "shard-lease-duration"
+var OPT_CHANGE_BUDGET = "change-budget"
+var OPT_CHANGE_BUDGET_WINDOW = "change-budget-window"
+var OPT_WEBHOOK_PORT = "webhook-port"
+var OPT_WEBHOOK_CERT_DIR = "webhook-cert-dir"
+var OPT_WEBHOOK_SERVICE = "webhook-service"
+var OPT_ENDPOINT_WRITERS = "endpoint-writers"
+
+const (
+	CLEANUP_DELETE = "Delete" // outdated endpoints are deleted
+	CLEANUP_IGNORE = "Ignore" // outdated endpoints are kept, but not used as targets
+)
+
+// CONTROLLER is the DNS controller. It runs once per lease and reconciles
+// all load balancers of its class, unless CONTROLLER_SHARDS is running in
+// sharded mode.
+const CONTROLLER = "dnslb-loadbalancer"
+
+// CONTROLLER_SHARDS reconciles the load balancers in sharded mode. It runs
+// on all replicas without lease. The state reconciler and the cleanup of
+// the RFC2136 records are kept in CONTROLLER.
+const CONTROLLER_SHARDS = "dnslb-loadbalancer-shards"
+
+// CONTROLLER_WEBHOOK serves the admission and conversion webhooks. It runs
+// on all replicas without lease.
+const CONTROLLER_WEBHOOK = "dnslb-webhook"
 
 func init() {
-	source.DNSSourceController(source.NewDNSSouceTypeForCreator("dnslb-loadbalancer", api.LoadBalancerGroupKind, NewDNSLBSource), nil).
+	configure(CONTROLLER).
 		RequireLease().
-		FinalizerDomain(api.GroupName).
-		StringOption(OPT_BOGUS_NXDOMAIN, "ip address returned by DNS for unknown domain").
 		Reconciler(StateReconciler, "state").ReconcilerWatch("state", api.GroupName, api.LoadBalancerEndpointResourceKind).
+		WorkerPool("rfc2136", 1, 0).
+		Reconciler(RFC2136Reconciler, "rfc2136").
+		ReconcilerCommands("rfc2136", CMD_RFC2136_GC).
+		Reconciler(HealthCheckPolicyReconciler, "healthcheckpolicies").ReconcilerWatch("healthcheckpolicies", api.GroupName, api.HealthCheckPolicyResourceKind).
 		Cluster(cluster.DEFAULT).
-		CustomResourceDefinitions(crds.DNSLBCRD, crds.DNSLBEPCRD).
 		MustRegister("loadbalancer")
+
+	configure(CONTROLLER_SHARDS).
+		// shared with CONTROLLER to switch between the modes
+		FinalizerName(api.GroupName+"/"+CONTROLLER).
+		StringOption(OPT_SHARD_NAMESPACE, "namespace for member leases of controller replicas (enables sharded mode)").
+		StringOption(OPT_SHARD_ID, "member id of controller replica in sharded mode (default is host name)").
+		DefaultedDurationOption(OPT_SHARD_LEASE_DURATION, SHARD_DEFAULT_LEASE_DURATION, "duration of member leases in sharded mode").
+		Reconciler(StateTrackingReconciler, "state").ReconcilerWatch("state", api.GroupName, api.LoadBalancerEndpointResourceKind).
+		Reconciler(HealthCheckPolicyReconciler, "healthcheckpolicies").ReconcilerWatch("healthcheckpolicies", api.GroupName, api.HealthCheckPolicyResourceKind).
+		WorkerPool("shards", 1, 0).
+		Reconciler(ShardReconciler, "shards").
+		ReconcilerCommands("shards", CMD_SHARDS).
+		Cluster(cluster.DEFAULT).
+		MustRegister("loadbalancer")
+
+	controller.Configure(CONTROLLER_WEBHOOK).
+		MainResource(api.GroupName, api.LoadBalancerResourceKind).
+		DefaultWorkerPool(1, 0).
+		Reconciler(WebhookReconciler).
+		IntOption(OPT_WEBHOOK_PORT, "port of admission webhook server (enables the webhook)").
+		StringOption(OPT_WEBHOOK_CERT_DIR, "directory with tls.crt, tls.key and optional ca.crt of the webhook server (default is a generated certificate)").
+		StringOption(OPT_WEBHOOK_SERVICE, "service (<namespace>/<name>) of the webhook server used to register the webhook configurations").
+		StringArrayOption(OPT_ENDPOINT_WRITERS, "users or groups permitted to set the origin labels of endpoints (checked by the webhook, default all)").
+		MustRegister("loadbalancer")
+}
+
+// configure provides the configuration shared by the DNS controllers.
+func configure(name string) controller.Configuration {
+	sourceType := source.NewDNSSouceTypeForCreator(name, api.LoadBalancerGroupKind, NewDNSLBSource)
+	return source.DNSSourceController(sourceType, nil).
+		Reconciler(ResponsibleReconciler(source.SourceReconciler(sourceType, nil))).
+		FinalizerDomain(api.GroupName).
+		StringOption(OPT_BOGUS_NXDOMAIN, "ip address returned by DNS for unknown domain").
+		StringOption(OPT_HEARTBEAT_NAMESPACE, "namespace for heartbeat leases of source clusters (enables heartbeat mode)").
+		DefaultedDurationOption(OPT_STARTUP_GRACE, 3*time.Minute, "grace period after startup before outdated endpoints are handled").
+		DefaultedStringOption(OPT_LB_CLASS, DEFAULT_CLASS, "class of load balancers handled by this controller instance").
+		StringOption(OPT_CLEANUP_POLICY, "handling of outdated endpoints (Delete or Ignore, default is Ignore in heartbeat mode, Delete otherwise)").
+		DefaultedStringOption(OPT_BACKEND, api.BACKEND_DNSENTRY, "default backend for publishing DNS records (DNSEntry or RFC2136)").
+		StringOption(OPT_RFC2136_SERVER, "address of authoritative DNS server for RFC2136 backend").
+		StringOption(OPT_RFC2136_ZONE, "zone for dynamic updates of RFC2136 backend").
+		DefaultedStringOption(OPT_RFC2136_OWNER, "dnslb", "owner id for records published by RFC2136 backend").
+		StringOption(OPT_RFC2136_TSIG_KEY, "TSIG key name for RFC2136 backend").
+		DefaultedStringOption(OPT_RFC2136_TSIG_ALGORITHM, "hmac-sha256", "TSIG algorithm for RFC2136 backend").
+		StringOption(OPT_RFC2136_TSIG_SECRET_FILE, "file containing base64 encoded TSIG secret for RFC2136 backend").
+		IntOption(OPT_GSLB_PORT, "port of embedded authoritative dns server (enables the server)").
+		StringArrayOption(OPT_GSLB_ZONES, "zones delegated to the embedded dns server").
+		StringArrayOption(OPT_GSLB_NAMESERVERS, "name server host names published for the zones of the embedded dns server").
+		StringOption(OPT_GSLB_HOSTMASTER, "hostmaster mail address published for the zones of the embedded dns server").
+		DefaultedDurationOption(OPT_GSLB_TTL, GSLB_DEFAULT_TTL, "maximum ttl of answers of the embedded dns server").
+		BoolOption(OPT_FREEZE, "suspend all dns changes").
+		StringOption(OPT_FREEZE_CONFIGMAP, "config map (<namespace>/<name>) to suspend all dns changes").
+		IntOption(OPT_CHANGE_BUDGET, "maximum number of record changes of all load balancers per window (0 for unlimited)").
+		DefaultedDurationOption(OPT_CHANGE_BUDGET_WINDOW, watch.DEFAULT_BUDGET_WINDOW, "time window for the global change budget").
+		StringOption(OPT_WEBHOOK_CERT_DIR, "directory with tls.crt, tls.key and optional ca.crt of the webhook server (default is a generated certificate)").
+		StringOption(OPT_WEBHOOK_SERVICE, "service (<namespace>/<name>) of the webhook server used for the conversion webhook")
 }
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/crds"
+)
+
+const KEY_CRDS = "crds"
+
+type sharedCRDsValue struct {
+	err error
+}
+
+// registerCRDs creates or upgrades the custom resource definitions in the
+// main cluster once for all reconcilers of the controller. It is called
+// before any reconciler resolves the resources of the load balancers, so
+// they can be used after the definitions are established without restart.
+// Older versions are only served if a webhook service is configured for
+// the conversion, served by CONTROLLER_WEBHOOK.
+func registerCRDs(c controller.Interface) error {
+	return c.GetOrCreateSharedValue(KEY_CRDS, func() interface{} {
+		conversion, err := webhookConversion(c)
+		if err != nil {
+			return &sharedCRDsValue{err}
+		}
+		return &sharedCRDsValue{crds.Register(c, c.GetMainCluster(), conversion, crds.CRDs...)}
+	}).(*sharedCRDsValue).err
+}
@@ -10,22 +10,27 @@ import (
 	"reflect"
 	"time"
 
+	coordination "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/scope"
 	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
 	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
 
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 )
 
 var KEY_STATE = reflect.TypeOf((*State)(nil))
+var KEY_PROBES = reflect.TypeOf((*watch.ProbeHistory)(nil))
+var KEY_GUARDED = reflect.TypeOf((*watch.HeldBackEndpoints)(nil))
 
 type DNSLBSource struct {
 	source.DefaultDNSSource
@@ -33,11 +38,32 @@ type DNSLBSource struct {
 	state      *State
 	started    time.Time
 	nxdomain   net.IP
+
+	grace          time.Duration
+	cleanup        string
+	namespace      string
+	leases         resources.Interface
+	clusters       resources.Interface
+	policies       resources.Interface
+	entries        *Entries
+	resolver       *watch.Resolver
+	backend        string
+	rfc2136        *RFC2136Publisher
+	gslb           *EmbeddedServer
+	shards         *Shards
+	freeze         *Freeze
+	limiter        *watch.ChangeLimiter
+	responsibility *Responsibility
+	probes         *watch.ProbeHistory
+	guarded        *watch.HeldBackEndpoints
 }
 
 var _ source.DNSSource = &DNSLBSource{}
 
 func NewDNSLBSource(c controller.Interface) (source.DNSSource, error) {
+	if err := registerCRDs(c); err != nil {
+		return nil, err
+	}
 
 	var ip net.IP
 	val, _ := c.GetStringOption(OPT_BOGUS_NXDOMAIN)
@@ -49,11 +75,132 @@ func NewDNSLBSource(c controller.Interface) (source.DNSSource, error) {
 			c.Infof("using bogus nxdomain address %s", ip)
 		}
 	}
+	grace, err := c.GetDurationOption(OPT_STARTUP_GRACE)
+	if err != nil {
+		return nil, err
+	}
+
+	var leases resources.Interface
+	namespace, _ := c.GetStringOption(OPT_HEARTBEAT_NAMESPACE)
+	if namespace != "" {
+		leases, err = c.GetMainCluster().Resources().GetByExample(&coordination.Lease{})
+		if err != nil {
+			return nil, err
+		}
+		c.Infof("heartbeat mode: using leases in namespace %s", namespace)
+	}
+
+	// In heartbeat mode a single expired lease makes all endpoints of a
+	// source cluster outdated, for example if the endpoint controller is
+	// down or cannot reach the shared cluster. Deleting them would remove
+	// all targets of the cluster, so they are only ignored by default.
+	cleanup, _ := c.GetStringOption(OPT_CLEANUP_POLICY)
+	switch cleanup {
+	case "":
+		cleanup = CLEANUP_DELETE
+		if namespace != "" {
+			cleanup = CLEANUP_IGNORE
+		}
+	case CLEANUP_DELETE, CLEANUP_IGNORE:
+	default:
+		return nil, fmt.Errorf("invalid cleanup policy %q", cleanup)
+	}
+	c.Infof("cleanup policy for outdated endpoints: %s", cleanup)
+
+	clusters, err := c.GetMainCluster().Resources().GetByExample(&api.DNSLoadBalancerCluster{})
+	if err != nil {
+		c.Warnf("cluster registry not available: %s", err)
+		clusters = nil
+	}
+
+	policies, err := c.GetMainCluster().Resources().GetByExample(&api.DNSHealthCheckPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
+	responsibility := responsibility(c)
+	class := responsibility.Class()
+	c.Infof("responsible for load balancer class %q", class)
+	if targetclass, _ := c.GetStringOption(source.OPT_TARGETCLASS); targetclass == "" && class != DEFAULT_CLASS {
+		c.Warnf("no dns target class configured (option %s): dns entries of load balancer class %q get the default dns class", source.OPT_TARGETCLASS, class)
+	}
+
+	entries, err := NewEntries(c)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, _ := c.GetStringOption(OPT_BACKEND)
+	switch backend {
+	case api.BACKEND_DNSENTRY, api.BACKEND_RFC2136:
+	default:
+		return nil, fmt.Errorf("invalid backend %q", backend)
+	}
+	publisher, err := sharedRFC2136Publisher(c)
+	if err != nil {
+		return nil, err
+	}
+	if backend == api.BACKEND_RFC2136 && publisher == nil {
+		return nil, fmt.Errorf("backend %s requires option %s", backend, OPT_RFC2136_SERVER)
+	}
+
+	server, err := sharedEmbeddedServer(c)
+	if err != nil {
+		return nil, err
+	}
+
+	shards, err := sharedShards(c)
+	if err != nil {
+		return nil, err
+	}
+	if shards != nil && server != nil {
+		return nil, fmt.Errorf("embedded dns server not supported in sharded mode")
+	}
+
+	freeze, err := sharedFreeze(c)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter, err := sharedLimiter(c, class)
+	if err != nil {
+		return nil, err
+	}
+
 	state := c.GetOrCreateSharedValue(KEY_STATE,
 		func() interface{} {
 			return NewState(c)
 		}).(*State)
-	return &DNSLBSource{controller: c, state: state, nxdomain: ip}, nil
+	probes := c.GetOrCreateSharedValue(KEY_PROBES,
+		func() interface{} {
+			return watch.NewProbeHistory()
+		}).(*watch.ProbeHistory)
+	guarded := c.GetOrCreateSharedValue(KEY_GUARDED,
+		func() interface{} {
+			return watch.NewHeldBackEndpoints()
+		}).(*watch.HeldBackEndpoints)
+	return &DNSLBSource{
+		controller:     c,
+		state:          state,
+		nxdomain:       ip,
+		grace:          grace,
+		cleanup:        cleanup,
+		namespace:      namespace,
+		leases:         leases,
+		clusters:       clusters,
+		policies:       policies,
+		entries:        entries,
+		resolver:       watch.NewResolver(),
+		backend:        backend,
+		rfc2136:        publisher,
+		gslb:           server,
+		shards:         shards,
+		freeze:         freeze,
+		limiter:        limiter,
+		responsibility: responsibility,
+		probes:         probes,
+		guarded:        guarded,
+	}, nil
 }
 
 func (this *DNSLBSource) Setup() {
@@ -61,25 +208,71 @@ func (this *DNSLBSource) Setup() {
 }
 func (this *DNSLBSource) Start() {
 	this.started = time.Now()
+	if this.gslb != nil {
+		this.gslb.Start(this.controller)
+	}
+	this.freeze.Start(this.controller)
 }
 
 func (this *DNSLBSource) GetDNSInfo(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (*source.DNSInfo, error) {
 	lb := lbutils.DNSLoadBalancer(obj)
+	if !this.responsibility.IsResponsibleFor(obj) {
+		return this.handOver(logger, lb, current), nil
+	}
 	if lb.Spec().DNSName == "" {
-		lb.Copy().UpdateState(api.STATE_ERROR, "no dns name specified")
+		lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, "no dns name specified")
 		return nil, fmt.Errorf("no dns name specified")
 	}
-	targets, done, err := this.GetTargets(logger, obj, current)
+	backend, err := this.getBackend(lb)
 	if err != nil {
+		lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, err.Error())
 		return nil, err
 	}
+	switch backend {
+	case api.BACKEND_RFC2136:
+		return this.getRFC2136Info(logger, lb)
+	case api.BACKEND_EMBEDDED:
+		return this.getEmbeddedInfo(logger, lb)
+	}
+	if obj.HasFinalizer(RFC2136_FINALIZER) && this.freeze.Reason(logger, lb) == "" {
+		// backend switched: remove records published by dynamic updates
+		if err := this.cleanupRFC2136(logger, lb); err != nil {
+			return nil, err
+		}
+	}
+
+	w, targets, done, err := this.GetTargets(logger, obj, current)
+	if err != nil {
+		return nil, err
+	}
+	if !w.HoldsChanges() {
+		this.updateEntries(logger, lb, w)
+		this.serveAnswers(logger, lb, w, targets)
+	}
 	info := &source.DNSInfo{Targets: targets, Feedback: done}
 	spec := &obj.Data().(*api.DNSLoadBalancer).Spec
 	info.Names = utils.NewStringSet(spec.DNSName)
-	info.TTL = spec.TTL
+	info.TTL = w.TTL()
 	return info, nil
 }
 
+func (this *DNSLBSource) Delete(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	this.removeAnswers(obj.ObjectName())
+	watch.RemoveState(obj.ObjectName())
+	this.limiter.Remove(obj.ObjectName().String())
+	this.probes.Remove(obj.ObjectName().String())
+	this.guarded.Remove(obj.ObjectName().String())
+	if err := this.entries.Cleanup(logger, obj.ClusterKey()); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	if obj.HasFinalizer(RFC2136_FINALIZER) {
+		if err := this.cleanupRFC2136(logger, lbutils.DNSLoadBalancer(obj)); err != nil {
+			return reconcile.Delay(logger, err)
+		}
+	}
+	return this.DefaultDNSSource.Delete(logger, obj)
+}
+
 func (this *DNSLBSource) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) {
 	logger.Infof("loadbalancer is deleting -> reschedule all endpoint objects")
 	for _, o := range this.state.GetEndpointsFor(key) {
@@ -87,23 +280,70 @@ func (this *DNSLBSource) Deleted(logger logger.LogContext, key resources.Cluster
 		this.controller.Enqueue(o)
 	}
 	this.state.RemoveLoadBalancer(key)
+	this.removeAnswers(key.ObjectName())
+	watch.RemoveState(key.ObjectName())
+	this.limiter.Remove(key.ObjectName().String())
+	this.probes.Remove(key.ObjectName().String())
+	this.guarded.Remove(key.ObjectName().String())
+	if err := this.entries.Cleanup(logger, key); err != nil {
+		logger.Warnf("cannot cleanup dns entries: %s", err)
+	}
 	this.DefaultDNSSource.Deleted(logger, key)
 }
 
-func (this *DNSLBSource) GetTargets(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (utils.StringSet, source.DNSFeedback, error) {
+func (this *DNSLBSource) GetTargets(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (*watch.Watch, utils.StringSet, source.DNSFeedback, error) {
 	now := metav1.Now()
 	lb := lbutils.DNSLoadBalancer(obj)
 
-	w, err := watch.NewWatch(logger, lb, current, this.nxdomain)
+	w, err := watch.NewWatch(logger, lb, current, this.nxdomain, this.resolver)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	hc, err := this.getHealthCheck(lb)
 	if err != nil {
-		return nil, nil, err
+		lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, err.Error())
+		return nil, nil, nil, err
+	}
+	if hc != nil {
+		w.HealthCheck = hc
+	}
+	w.Freeze = this.freeze.Reason(logger, lb)
+	w.Limiter = this.limiter
+	w.Probes = this.probes
+	w.Guarded = this.guarded
+	access, err := scope.Eval(lb, lb.Spec().Access, obj.GetCluster().GetId())
+	if err != nil {
+		lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, err.Error())
+		return nil, nil, nil, err
 	}
 	for _, o := range this.state.GetEndpointsFor(obj.ClusterKey()) {
 		e := lbutils.DNSLoadBalancerEndpoint(o)
 		ep := e.DNSLoadBalancerEndpoint()
-		t := &watch.Target{IPAddress: ep.Spec.IPAddress, Name: ep.Spec.CName, DNSEP: e}
+		invalid := reconcile.StringEqual(ep.Status.State, api.STATE_INVALID)
+		if !access.ValidFor(ep) {
+			logger.Infof("endpoint %q not permitted by access control", o.ObjectName())
+			if !invalid {
+				this.controller.Enqueue(o)
+			}
+			continue
+		}
+		if invalid {
+			// access control might have been relaxed
+			this.controller.Enqueue(o)
+		}
+		t := &watch.Target{Name: ep.Spec.CName, DNSEP: e, Cluster: this.getClusterInfo(e), Region: ep.Spec.Region,
+			Port: ep.Spec.Port, Priority: ep.Spec.Priority, Weight: ep.Spec.Weight}
+		if t.Name == "" && len(ep.Spec.Addresses) > 0 {
+			t.IPAddress = ep.Spec.Addresses[0]
+			t.Addresses = ep.Spec.Addresses
+		}
+		if t.Region == "" && t.Cluster != nil {
+			t.Region = t.Cluster.Region
+		}
 		if t.IsValid() {
-			if now.Time.Before(this.started.Add(3*time.Minute)) || !this.handleCleanup(logger, e, w, &now) {
+			if this.isStale(logger, e, &now) {
+				this.handleCleanup(logger, e, w)
+			} else {
 				w.Targets = append(w.Targets, t)
 				logger.Debugf("found %s target '%s' for '%s'", t.GetRecordType(), t.GetHostName(), obj.ObjectName())
 			}
@@ -113,27 +353,97 @@ func (this *DNSLBSource) GetTargets(logger logger.LogContext, obj resources.Obje
 	}
 
 	set, done := w.Handle()
-	return set, done, nil
+	if w.LookupInterval > 0 {
+		// refresh the flattened addresses of host name endpoints
+		this.controller.EnqueueAfter(obj, w.LookupInterval)
+	}
+	if d := w.HealthCheck.Interval; d > 0 {
+		// probe the endpoints with the interval of the health check policy
+		this.controller.EnqueueAfter(obj, d)
+	}
+	if d := w.Settling(); d > 0 {
+		// switch back to the steady ttl after the settle window
+		this.controller.EnqueueAfter(obj, d)
+	}
+	if d := w.Deferred(); d > 0 {
+		// publish the deferred change when the budget permits it
+		this.controller.EnqueueAfter(obj, d)
+	}
+	if w.HeldBack() > 0 {
+		// continue removing unhealthy endpoints
+		this.controller.EnqueueAfter(obj, watch.GUARD_RECHECK_INTERVAL)
+	}
+	return w, set, done, nil
+}
+
+// updateEntries maintains the additional DNS entries of a load balancer.
+// Entries not required anymore are removed. SRV records cannot be
+// published by DNS entries.
+func (this *DNSLBSource) updateEntries(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, w *watch.Watch) {
+	if err := this.entries.Update(logger, lb, w.AdditionalNames(), w.TTL()); err != nil {
+		logger.Warnf("%s", err)
+		lb.Eventf(corev1.EventTypeWarning, "sync", "%s", err)
+	}
+	if srv := w.SRVRecords(); srv != nil {
+		msg := fmt.Sprintf("SRV record %s requires backend %s or %s", srv.DNSName, api.BACKEND_RFC2136, api.BACKEND_EMBEDDED)
+		logger.Warnf("%s", msg)
+		lb.Eventf(corev1.EventTypeWarning, "sync", "%s", msg)
+	}
+}
+
+// getClusterInfo provides the registered description of the source cluster
+// of an endpoint, if available.
+func (this *DNSLBSource) getClusterInfo(e *lbutils.DNSLoadBalancerEndpointObject) *api.DNSLoadBalancerClusterSpec {
+	cluster := e.GetLabel(api.LABEL_CLUSTER)
+	if this.clusters == nil || cluster == "" {
+		return nil
+	}
+	o, err := this.clusters.GetCached(lbutils.ClusterObjectName(cluster))
+	if err != nil {
+		return nil
+	}
+	return lbutils.DNSLoadBalancerCluster(o).Spec()
 }
 
-func (this *DNSLBSource) handleCleanup(logger logger.LogContext, e *lbutils.DNSLoadBalancerEndpointObject, w *watch.Watch, threshold *metav1.Time) bool {
-	del := false
+// isStale checks whether an endpoint is outdated, either by an expired
+// validity interval or by an expired heartbeat lease of its source cluster.
+// During the startup grace period no endpoint is considered to be stale.
+func (this *DNSLBSource) isStale(logger logger.LogContext, e *lbutils.DNSLoadBalancerEndpointObject, now *metav1.Time) bool {
+	if now.Time.Before(this.started.Add(this.grace)) {
+		return false
+	}
 	ep := e.DNSLoadBalancerEndpoint()
-	if ep.Status.ValidUntil != nil {
-		if ep.Status.ValidUntil.Before(threshold) {
-			del = true
-		}
-	} else {
-		if w == nil {
-			del = true
+	if ep.Status.ValidUntil != nil && ep.Status.ValidUntil.Before(now) {
+		return true
+	}
+	cluster := e.GetLabel(api.LABEL_CLUSTER)
+	if this.leases != nil && cluster != "" {
+		o, err := this.leases.Namespace(this.namespace).GetCached(lbutils.HeartbeatLeaseName(cluster))
+		if err == nil && lbutils.Lease(o).IsExpired(now.Time) {
+			logger.Debugf("heartbeat lease for cluster %q expired", cluster)
+			return true
 		}
 	}
-	if del {
-		e.Delete()
-		if w != nil {
-			w.DNSLB.Eventf(corev1.EventTypeNormal, "sync", "dns load balancer endpoint %s/%s deleted", ep.GetNamespace(), ep.GetName())
+	return false
+}
+
+func (this *DNSLBSource) handleCleanup(logger logger.LogContext, e *lbutils.DNSLoadBalancerEndpointObject, w *watch.Watch) {
+	if this.cleanup != CLEANUP_DELETE {
+		logger.Infof("ignoring outdated dns load balancer endpoint %s", e.ObjectName())
+		_, err := e.Copy().UpdateStateWith(lbutils.FIELD_MANAGER_DNS, api.STATE_INACTIVE, "endpoint expired", nil, func(status *api.DNSLoadBalancerEndpointStatus) {
+			status.InactiveReason = api.INACTIVE_EXPIRED
+		})
+		if err != nil {
+			logger.Warnf("cannot update status of outdated dns load balancer endpoint %s: %s", e.ObjectName(), err)
 		}
-		logger.Infof("outdated dns load balancer endpoint %s deleted", e.ObjectName())
+		return
+	}
+	ep := e.DNSLoadBalancerEndpoint()
+	err := e.Delete()
+	if err != nil {
+		logger.Warnf("cannot delete outdated dns load balancer endpoint %s: %s", e.ObjectName(), err)
+		return
 	}
-	return del
+	w.DNSLB.Eventf(corev1.EventTypeNormal, "sync", "dns load balancer endpoint %s/%s deleted", ep.GetNamespace(), ep.GetName())
+	logger.Infof("outdated dns load balancer endpoint %s deleted", e.ObjectName())
 }
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	dnsapi "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// ENTRY_OWNER is used as label (sanitized) and annotation (exact) key
+// to identify the load balancer owning an additional DNS entry.
+const ENTRY_OWNER = api.GroupName + "/loadbalancer"
+
+// Entries maintains additional DNS entries for a load balancer whose
+// targets differ from the targets of the main DNS name. Those entries
+// are not handled by the DNS source framework (it supports only one
+// target set for all names), therefore they are identified by an
+// owner label instead of owner references.
+type Entries struct {
+	resource    resources.Interface
+	namespace   string
+	nameprefix  string
+	targetclass string
+}
+
+func NewEntries(c controller.Interface) (*Entries, error) {
+	target := c.GetCluster(source.TARGET_CLUSTER)
+	res, err := target.Resources().GetByGK(source.ENTRY)
+	if err != nil {
+		return nil, err
+	}
+	entries := &Entries{resource: res}
+	entries.targetclass, _ = c.GetStringOption(source.OPT_TARGETCLASS)
+	if entries.targetclass == "" {
+		copt, _ := c.GetStringOption(source.OPT_CLASS)
+		classes := dnsutils.NewClasses(copt)
+		if !classes.Contains(dnsutils.DEFAULT_CLASS) && classes.Main() != dnsutils.DEFAULT_CLASS {
+			entries.targetclass = classes.Main()
+		}
+	}
+	if c.GetMainCluster() != target {
+		entries.namespace, _ = c.GetStringOption(source.OPT_NAMESPACE)
+		entries.nameprefix, _ = c.GetStringOption(source.OPT_NAMEPREFIX)
+	}
+	return entries, nil
+}
+
+func ownerRef(key resources.ClusterObjectKey) string {
+	return key.Namespace() + "/" + key.Name()
+}
+
+func (this *Entries) list(key resources.ClusterObjectKey) ([]resources.Object, error) {
+	ref := ownerRef(key)
+	selector := labels.SelectorFromSet(labels.Set{ENTRY_OWNER: lbutils.LabelValue(ref)})
+	list, err := this.resource.ListCached(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := []resources.Object{}
+	for _, o := range list {
+		if o.GetAnnotations()[ENTRY_OWNER] == ref {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
+// Update assures the given DNS names with their target sets. Existing
+// additional entries for names not contained in the desired set
+// are deleted. Names with an empty target set are not published.
+func (this *Entries) Update(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, desired map[string]utils.StringSet, ttl *int64) error {
+	existing, err := this.list(lb.ClusterKey())
+	if err != nil {
+		return err
+	}
+	found := map[string]resources.Object{}
+	for _, o := range existing {
+		name := o.Data().(*dnsapi.DNSEntry).Spec.DNSName
+		targets := desired[name]
+		if len(targets) == 0 || found[name] != nil {
+			this.delete(logger, lb, o)
+			continue
+		}
+		found[name] = o
+	}
+
+	var errs []string
+	for name, targets := range desired {
+		if len(targets) == 0 {
+			continue
+		}
+		if o := found[name]; o != nil {
+			err = this.update(logger, lb, o, targets, ttl)
+		} else {
+			err = this.create(logger, lb, name, targets, ttl)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot update dns entries: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Cleanup deletes all additional entries of a load balancer.
+func (this *Entries) Cleanup(logger logger.LogContext, key resources.ClusterObjectKey) error {
+	existing, err := this.list(key)
+	if err != nil {
+		return err
+	}
+	for _, o := range existing {
+		if err := this.delete(logger, nil, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (this *Entries) annotations(key resources.ClusterObjectKey) map[string]string {
+	annos := map[string]string{ENTRY_OWNER: ownerRef(key)}
+	if this.targetclass != "" {
+		annos[source.CLASS_ANNOTATION] = this.targetclass
+	}
+	return annos
+}
+
+func (this *Entries) create(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, name string, targets utils.StringSet, ttl *int64) error {
+	entry := &dnsapi.DNSEntry{}
+	entry.GenerateName = strings.ToLower(this.nameprefix + lb.GetName() + "-dnslb-")
+	if this.namespace == "" {
+		entry.Namespace = lb.GetNamespace()
+	} else {
+		entry.Namespace = this.namespace
+	}
+	entry.SetLabels(map[string]string{ENTRY_OWNER: lbutils.LabelValue(ownerRef(lb.ClusterKey()))})
+	entry.SetAnnotations(this.annotations(lb.ClusterKey()))
+	entry.Spec.DNSName = name
+	entry.Spec.Targets = targets.AsArray()
+	entry.Spec.TTL = ttl
+
+	o, err := this.resource.Create(entry)
+	if err != nil {
+		return err
+	}
+	lb.Eventf(corev1.EventTypeNormal, "reconcile", "created dns entry object %s for %s", o.ObjectName(), name)
+	logger.Infof("created dns entry object %s for %s", o.ObjectName(), name)
+	return nil
+}
+
+func (this *Entries) update(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, o resources.Object, targets utils.StringSet, ttl *int64) error {
+	_, err := o.Modify(func(data resources.ObjectData) (bool, error) {
+		spec := &data.(*dnsapi.DNSEntry).Spec
+		mod := &utils.ModificationState{}
+		annos := data.GetAnnotations()
+		if annos == nil {
+			annos = map[string]string{}
+		}
+		for k, v := range this.annotations(lb.ClusterKey()) {
+			if annos[k] != v {
+				annos[k] = v
+				mod.Modify(true)
+			}
+		}
+		if this.targetclass == "" && annos[source.CLASS_ANNOTATION] != "" {
+			delete(annos, source.CLASS_ANNOTATION)
+			mod.Modify(true)
+		}
+		data.SetAnnotations(annos)
+		mod.AssureInt64PtrPtr(&spec.TTL, ttl)
+		mod.AssureStringSet(&spec.Targets, targets)
+		if mod.IsModified() {
+			logger.Infof("update dns entry %s for %s: %s", o.ObjectName(), spec.DNSName, targets)
+		}
+		return mod.IsModified(), nil
+	})
+	return err
+}
+
+func (this *Entries) delete(logger logger.LogContext, lb resources.Object, o resources.Object) error {
+	err := o.Delete()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		logger.Errorf("cannot delete dns entry object %s: %s", o.ObjectName(), err)
+		return err
+	}
+	if lb != nil {
+		lb.Eventf(corev1.EventTypeNormal, "reconcile", "deleted dns entry object %s", o.ObjectName())
+	}
+	logger.Infof("deleted dns entry object %s", o.ObjectName())
+	return nil
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// AnnotationFreeze suspends DNS changes for a single load balancer.
+const AnnotationFreeze = api.GroupName + "/freeze"
+
+// FREEZE_KEY is the config map key to suspend DNS changes for all load
+// balancers. The optional key FREEZE_REASON_KEY describes the reason.
+const FREEZE_KEY = "frozen"
+const FREEZE_REASON_KEY = "reason"
+
+type freezeKey struct{}
+
+// Freeze determines whether DNS changes are suspended. It is configured
+// globally by the controller options or a config map, or per load balancer
+// by annotation. Only the configured config map is watched.
+//
+// The freeze is shared by all controllers of the controller manager: the
+// option of any controller suspends the DNS changes of all of them, and
+// all controllers must use the same config map.
+type Freeze struct {
+	lock        sync.RWMutex
+	flag        bool
+	configmap   resources.ObjectName
+	store       cache.Store
+	informer    cache.Controller
+	once        sync.Once
+	controllers map[string]controller.Interface
+}
+
+func sharedFreeze(c controller.Interface) (*Freeze, error) {
+	f := controllermanager.GetOrCreateSharedValue(c.GetContext(), freezeKey{}, func(*controllermanager.ControllerManager) interface{} {
+		return &Freeze{controllers: map[string]controller.Interface{}}
+	}).(*Freeze)
+	if err := f.configure(c); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// configure adds the options of a controller to the freeze. The
+// controller is rescheduled if the freeze config map changes.
+func (this *Freeze) configure(c controller.Interface) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, ok := this.controllers[c.GetName()]; ok {
+		return nil
+	}
+	if flag, _ := c.GetBoolOption(OPT_FREEZE); flag {
+		c.Infof("dns changes are frozen by option %s", OPT_FREEZE)
+		this.flag = true
+	}
+	name, _ := c.GetStringOption(OPT_FREEZE_CONFIGMAP)
+	if name != "" {
+		parts := strings.Split(name, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid freeze config map %q (expected <namespace>/<name>)", name)
+		}
+		configmap := resources.NewObjectName(parts[0], parts[1])
+		switch {
+		case this.informer == nil:
+			cfg := c.GetMainCluster().Config()
+			client, err := corev1client.NewForConfig(&cfg)
+			if err != nil {
+				return err
+			}
+			this.configmap = configmap
+			lw := cache.NewListWatchFromClient(client.RESTClient(), "configmaps", parts[0], fields.OneTermEqualSelector("metadata.name", parts[1]))
+			this.store, this.informer = cache.NewInformer(lw, &corev1.ConfigMap{}, 0, cache.ResourceEventHandlerFuncs{
+				AddFunc:    func(obj interface{}) { this.changed() },
+				UpdateFunc: func(old, obj interface{}) { this.changed() },
+				DeleteFunc: func(obj interface{}) { this.changed() },
+			})
+		case this.configmap != configmap:
+			return fmt.Errorf("freeze config map %s differs from config map %s of other controller", configmap, this.configmap)
+		}
+		c.Infof("using freeze config map %s", this.configmap)
+	}
+	this.controllers[c.GetName()] = c
+	return nil
+}
+
+// Start starts watching the freeze config map, if configured.
+func (this *Freeze) Start(c controller.Interface) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if this.informer == nil {
+		return
+	}
+	this.once.Do(func() {
+		go this.informer.Run(c.GetContext().Done())
+	})
+}
+
+// Reason provides the reason for suspending DNS changes for a load
+// balancer, or an empty string if changes are permitted.
+func (this *Freeze) Reason(logger logger.LogContext, lb resources.Object) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if this.informer == nil {
+		return FreezeReason(this.flag, this.configmap, nil, true, lb.GetAnnotations())
+	}
+	available := this.informer.HasSynced()
+	var configmap *corev1.ConfigMap
+	if available {
+		o, exists, err := this.store.GetByKey(this.configmap.String())
+		switch {
+		case err != nil:
+			available = false
+		case exists:
+			configmap = o.(*corev1.ConfigMap)
+		}
+	}
+	if !available {
+		logger.Warnf("freeze config map %s not available", this.configmap)
+	}
+	return FreezeReason(this.flag, this.configmap, configmap, available, lb.GetAnnotations())
+}
+
+// FreezeReason decides whether DNS changes are suspended. The config map
+// is nil if it does not exist. If it is not available, DNS changes are
+// suspended, too (fail safe).
+func FreezeReason(flag bool, name resources.ObjectName, configmap *corev1.ConfigMap, available bool, annotations map[string]string) string {
+	if flag {
+		return "frozen by controller option"
+	}
+	if !available {
+		return fmt.Sprintf("freeze config map %s not available", name)
+	}
+	if configmap != nil && configmap.Data[FREEZE_KEY] == "true" {
+		if reason := configmap.Data[FREEZE_REASON_KEY]; reason != "" {
+			return reason
+		}
+		return fmt.Sprintf("frozen by config map %s", name)
+	}
+	if annotations[AnnotationFreeze] == "true" {
+		return "frozen by annotation"
+	}
+	return ""
+}
+
+// changed reschedules all load balancers of all controllers if the freeze
+// config map changes.
+func (this *Freeze) changed() {
+	this.lock.RLock()
+	controllers := []controller.Interface{}
+	for _, c := range this.controllers {
+		controllers = append(controllers, c)
+	}
+	this.lock.RUnlock()
+	for _, c := range controllers {
+		reschedule(c)
+	}
+}
+
+// reschedule reschedules the load balancers a controller is responsible for.
+func reschedule(c controller.Interface) {
+	lbs, err := c.GetMainCluster().GetResource(api.LoadBalancerGroupKind)
+	if err != nil {
+		c.Warnf("cannot get load balancer resource: %s", err)
+		return
+	}
+	list, err := lbs.ListCached(labels.Everything())
+	if err != nil {
+		c.Warnf("cannot list load balancers: %s", err)
+		return
+	}
+	c.Infof("freeze config map changed -> reschedule %d load balancers", len(list))
+	responsibility := responsibility(c)
+	for _, o := range list {
+		if responsibility.IsResponsibleFor(o) {
+			c.Enqueue(o)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb_test
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("freeze", func() {
+	name := resources.NewObjectName("kube-system", "dnslb-freeze")
+	configmap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{Data: data}
+	}
+	annotated := map[string]string{AnnotationFreeze: "true"}
+
+	frozen := configmap(map[string]string{FREEZE_KEY: "true"})
+
+	It("permits changes by default", func() {
+		Expect(FreezeReason(false, name, nil, true, nil)).To(Equal(""))
+	})
+
+	It("is frozen by the controller option", func() {
+		Expect(FreezeReason(true, name, nil, true, nil)).To(Equal("frozen by controller option"))
+	})
+
+	It("prefers the controller option", func() {
+		Expect(FreezeReason(true, name, frozen, false, annotated)).To(Equal("frozen by controller option"))
+	})
+
+	Context("config map", func() {
+		It("freezes changes", func() {
+			Expect(FreezeReason(false, name, frozen, true, nil)).To(Equal("frozen by config map kube-system/dnslb-freeze"))
+		})
+
+		It("reports the reason of the config map", func() {
+			cm := configmap(map[string]string{FREEZE_KEY: "true", FREEZE_REASON_KEY: "provider outage"})
+			Expect(FreezeReason(false, name, cm, true, nil)).To(Equal("provider outage"))
+		})
+
+		It("permits changes for other values", func() {
+			cm := configmap(map[string]string{FREEZE_KEY: "false", FREEZE_REASON_KEY: "provider outage"})
+			Expect(FreezeReason(false, name, cm, true, nil)).To(Equal(""))
+		})
+
+		It("freezes changes if it is not available", func() {
+			Expect(FreezeReason(false, name, nil, false, nil)).To(Equal("freeze config map kube-system/dnslb-freeze not available"))
+		})
+	})
+
+	Context("annotation", func() {
+		It("freezes changes", func() {
+			Expect(FreezeReason(false, name, configmap(nil), true, annotated)).To(Equal("frozen by annotation"))
+		})
+
+		It("ignores other values", func() {
+			Expect(FreezeReason(false, name, nil, true, map[string]string{AnnotationFreeze: "yes"})).To(Equal(""))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	dnsapi "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/gslb"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// GSLB_SYNC_PERIOD is the health check period for load balancers served by
+// the embedded DNS server.
+const GSLB_SYNC_PERIOD = time.Minute
+const GSLB_DEFAULT_TTL = 30 * time.Second
+
+var KEY_GSLB = reflect.TypeOf((*EmbeddedServer)(nil))
+
+// EmbeddedServer is the authoritative DNS server embedded into the
+// controller, serving the zones delegated to it.
+type EmbeddedServer struct {
+	*gslb.Server
+	addr string
+	once sync.Once
+}
+
+type sharedServer struct {
+	server *EmbeddedServer
+	err    error
+}
+
+// sharedEmbeddedServer provides the embedded DNS server shared by the
+// reconcilers of a controller. It is nil if no port is configured.
+func sharedEmbeddedServer(c controller.Interface) (*EmbeddedServer, error) {
+	shared := c.GetOrCreateSharedValue(KEY_GSLB, func() interface{} {
+		s, err := NewEmbeddedServer(c)
+		return &sharedServer{s, err}
+	}).(*sharedServer)
+	return shared.server, shared.err
+}
+
+func NewEmbeddedServer(c controller.Interface) (*EmbeddedServer, error) {
+	port, _ := c.GetIntOption(OPT_GSLB_PORT)
+	if port <= 0 {
+		return nil, nil
+	}
+	zones, _ := c.GetStringArrayOption(OPT_GSLB_ZONES)
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("option %s required for embedded dns server", OPT_GSLB_ZONES)
+	}
+	nameservers, _ := c.GetStringArrayOption(OPT_GSLB_NAMESERVERS)
+	hostmaster, _ := c.GetStringOption(OPT_GSLB_HOSTMASTER)
+	ttl, err := c.GetDurationOption(OPT_GSLB_TTL)
+	if err != nil {
+		return nil, err
+	}
+	if ttl < time.Second {
+		return nil, fmt.Errorf("invalid ttl %s for embedded dns server", ttl)
+	}
+	server, err := gslb.NewServer(c, gslb.NewRegistry(), gslb.Config{
+		Zones:       zones,
+		NameServers: nameservers,
+		Hostmaster:  hostmaster,
+		TTL:         uint32(ttl / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmbeddedServer{Server: server, addr: ":" + strconv.Itoa(port)}, nil
+}
+
+// Start starts serving queries. It is called once only.
+func (this *EmbeddedServer) Start(logger logger.LogContext) {
+	this.once.Do(func() {
+		if err := this.Server.Start(this.addr); err != nil {
+			logger.Errorf("cannot start embedded dns server: %s", err)
+		}
+	})
+}
+
+// Current provides the served state of a DNS name.
+func (this *EmbeddedServer) Current(dnsname string) *source.DNSCurrentState {
+	current := &source.DNSCurrentState{Names: map[string]*source.DNSState{}, Targets: utils.StringSet{}}
+	if a, ok := this.Registry().Get(dnsname); ok {
+		current.Targets = a.Targets
+		current.Names[dnsname] = &source.DNSState{State: dnsapi.STATE_READY}
+	}
+	return current
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// serveAnswers registers the healthy targets of all DNS names of a load
+// balancer contained in a zone of the embedded DNS server.
+func (this *DNSLBSource) serveAnswers(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, w *watch.Watch, targets utils.StringSet) error {
+	if this.gslb == nil {
+		return nil
+	}
+	ttl := this.gslb.TTL(w.TTL())
+	desired := w.AdditionalNames()
+	desired[lb.Spec().DNSName] = targets
+	answers := map[string]*gslb.Answer{}
+	for name, set := range desired {
+		if this.gslb.Zone(name) != "" {
+			answers[name] = &gslb.Answer{Targets: set, Exclusive: w.Singleton, TTL: ttl}
+		}
+	}
+	if srv := w.SRVRecords(); srv != nil && this.gslb.Zone(srv.DNSName) != "" {
+		answers[srv.DNSName] = &gslb.Answer{Targets: srv.Targets, TTL: ttl}
+	}
+	err := this.gslb.Registry().Update(lb.ObjectName(), answers)
+	if err != nil {
+		logger.Warnf("%s", err)
+		lb.Eventf(corev1.EventTypeWarning, "sync", "%s", err)
+	}
+	return err
+}
+
+// getEmbeddedInfo serves the DNS names of a load balancer by the embedded
+// DNS server. No DNS names are reported to the DNS source framework,
+// therefore it removes all DNSEntry objects of the load balancer.
+func (this *DNSLBSource) getEmbeddedInfo(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject) (*source.DNSInfo, error) {
+	dnsname := lb.Spec().DNSName
+	if lb.HasFinalizer(RFC2136_FINALIZER) && this.freeze.Reason(logger, lb) == "" {
+		if err := this.cleanupRFC2136(logger, lb); err != nil {
+			return nil, err
+		}
+	}
+	w, targets, done, err := this.GetTargets(logger, lb, this.gslb.Current(dnsname))
+	if err != nil {
+		return nil, err
+	}
+	if !w.HoldsChanges() {
+		this.entries.Update(logger, lb, nil, nil)
+		err = this.serveAnswers(logger, lb, w, targets)
+	}
+	if done != nil {
+		if err != nil {
+			done.Failed(dnsname, err)
+		} else {
+			done.Succeeded()
+		}
+	}
+	// the DNS source framework does not reschedule load balancers without names
+	this.controller.EnqueueAfter(lb, GSLB_SYNC_PERIOD)
+	return &source.DNSInfo{}, err
+}
+
+func (this *DNSLBSource) removeAnswers(key resources.ObjectName) {
+	if this.gslb != nil {
+		this.gslb.Registry().Remove(key)
+	}
+}
+
+func (this *DNSLBSource) checkEmbedded(lb *lbutils.DNSLoadBalancerObject) error {
+	if this.gslb == nil {
+		return fmt.Errorf("backend %s not configured", api.BACKEND_EMBEDDED)
+	}
+	if this.gslb.Zone(lb.Spec().DNSName) == "" {
+		return fmt.Errorf("dns name %s not in a zone served by the embedded dns server", lb.Spec().DNSName)
+	}
+	return nil
+}
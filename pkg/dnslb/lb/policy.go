@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/validation"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// policyName provides the name of the health check policy referenced by a
+// load balancer, or nil if it does not refer to a policy.
+func policyName(lb resources.Object) resources.ObjectName {
+	ref := lb.Data().(*api.DNSLoadBalancer).Spec.HealthCheckPolicyRef
+	if ref == nil {
+		return nil
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = lb.GetNamespace()
+	}
+	return resources.NewObjectName(namespace, ref.Name)
+}
+
+// getHealthCheck provides the health check of the policy referenced by a
+// load balancer, or nil if it does not refer to a policy.
+func (this *DNSLBSource) getHealthCheck(lb *lbutils.DNSLoadBalancerObject) (*watch.HealthCheck, error) {
+	name := policyName(lb)
+	if name == nil {
+		return nil, nil
+	}
+	o, err := this.policies.GetCached(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("health check policy %s not found", name)
+		}
+		return nil, fmt.Errorf("cannot get health check policy %s: %s", name, err)
+	}
+	spec := &o.Data().(*api.DNSHealthCheckPolicy).Spec
+	if err := validation.ValidateHealthCheckPolicySpec(spec, field.NewPath("spec")).ToAggregate(); err != nil {
+		return nil, fmt.Errorf("invalid health check policy %s: %s", name, err)
+	}
+	return watch.NewPolicyHealthCheck(name.String(), spec), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// HealthCheckPolicyReconciler reschedules all load balancers referring to
+// a health check policy if the policy changes.
+func HealthCheckPolicyReconciler(c controller.Interface) (reconcile.Interface, error) {
+	if err := registerCRDs(c); err != nil {
+		return nil, err
+	}
+	lbs, err := c.GetMainCluster().GetResource(api.LoadBalancerGroupKind)
+	if err != nil {
+		return nil, err
+	}
+	return &policy_reconciler{
+		controller:     c,
+		lbs:            lbs,
+		responsibility: responsibility(c),
+	}, nil
+}
+
+type policy_reconciler struct {
+	reconcile.DefaultReconciler
+	controller     controller.Interface
+	lbs            resources.Interface
+	responsibility *Responsibility
+}
+
+func (this *policy_reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	this.enqueueReferring(logger, obj.ObjectName())
+	return reconcile.Succeeded(logger)
+}
+
+func (this *policy_reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	this.enqueueReferring(logger, key.ObjectName())
+	return reconcile.Succeeded(logger)
+}
+
+func (this *policy_reconciler) enqueueReferring(logger logger.LogContext, name resources.ObjectName) {
+	list, err := this.lbs.ListCached(labels.Everything())
+	if err != nil {
+		logger.Warnf("cannot list load balancers: %s", err)
+		return
+	}
+	count := 0
+	for _, o := range list {
+		if ref := policyName(o); ref != nil && ref.String() == name.String() && this.responsibility.IsResponsibleFor(o) {
+			this.controller.Enqueue(o)
+			count++
+		}
+	}
+	if count > 0 {
+		logger.Infof("health check policy %s changed -> reschedule %d load balancers", name, count)
+	}
+}
@@ -0,0 +1,357 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	dnsapi "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/rfc2136"
+	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// RFC2136_FINALIZER protects load balancers with records published by
+// dynamic updates.
+const RFC2136_FINALIZER = api.GroupName + "/rfc2136"
+
+const RFC2136_SYNC_PERIOD = 2 * time.Minute
+const RFC2136_GC_PERIOD = 10 * time.Minute
+const RFC2136_DEFAULT_TTL = 120
+
+const CMD_RFC2136_GC = "rfc2136-gc"
+
+var KEY_RFC2136 = reflect.TypeOf((*RFC2136Publisher)(nil))
+
+// RFC2136Publisher publishes the DNS names of load balancers directly to an
+// authoritative DNS server using TSIG signed dynamic updates (RFC 2136).
+type RFC2136Publisher struct {
+	zone *rfc2136.Zone
+
+	lock      sync.Mutex
+	published map[resources.ObjectName]utils.StringSet
+}
+
+type sharedPublisher struct {
+	publisher *RFC2136Publisher
+	err       error
+}
+
+// sharedRFC2136Publisher provides the publisher shared by the reconcilers
+// of a controller. It is nil if no server is configured.
+func sharedRFC2136Publisher(c controller.Interface) (*RFC2136Publisher, error) {
+	shared := c.GetOrCreateSharedValue(KEY_RFC2136, func() interface{} {
+		p, err := NewRFC2136Publisher(c)
+		return &sharedPublisher{p, err}
+	}).(*sharedPublisher)
+	return shared.publisher, shared.err
+}
+
+func NewRFC2136Publisher(c controller.Interface) (*RFC2136Publisher, error) {
+	server, _ := c.GetStringOption(OPT_RFC2136_SERVER)
+	if server == "" {
+		return nil, nil
+	}
+	zone, _ := c.GetStringOption(OPT_RFC2136_ZONE)
+	if zone == "" {
+		return nil, fmt.Errorf("option %s required for RFC2136 server", OPT_RFC2136_ZONE)
+	}
+	owner, _ := c.GetStringOption(OPT_RFC2136_OWNER)
+
+	var key *dnsmsg.TSIGKey
+	name, _ := c.GetStringOption(OPT_RFC2136_TSIG_KEY)
+	if name != "" {
+		file, _ := c.GetStringOption(OPT_RFC2136_TSIG_SECRET_FILE)
+		if file == "" {
+			return nil, fmt.Errorf("option %s required for TSIG key", OPT_RFC2136_TSIG_SECRET_FILE)
+		}
+		secret, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read TSIG secret: %s", err)
+		}
+		algorithm, _ := c.GetStringOption(OPT_RFC2136_TSIG_ALGORITHM)
+		key, err = dnsmsg.NewTSIGKey(name, algorithm, string(secret))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.Warnf("no TSIG key configured for RFC2136 server %s", server)
+	}
+	c.Infof("using RFC2136 server %s for zone %s (owner %s)", server, zone, owner)
+	return &RFC2136Publisher{
+		zone:      rfc2136.NewZone(rfc2136.NewClient(server, key), zone, owner),
+		published: map[resources.ObjectName]utils.StringSet{},
+	}, nil
+}
+
+// Current provides the published state of a DNS name.
+func (this *RFC2136Publisher) Current(dnsname string) (*source.DNSCurrentState, error) {
+	set, err := this.zone.Get(dnsname)
+	if err != nil {
+		return nil, err
+	}
+	current := &source.DNSCurrentState{Names: map[string]*source.DNSState{}, Targets: set.Targets}
+	if set.Owned {
+		current.Names[dnsname] = &source.DNSState{State: dnsapi.STATE_READY}
+	}
+	return current, nil
+}
+
+// Publish reconciles the records for the desired DNS names of a load balancer.
+// Names published before, but not desired anymore, are deleted. The lock
+// only protects the published names, load balancers are reconciled
+// sequentially by the controller.
+func (this *RFC2136Publisher) Publish(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, desired map[string]utils.StringSet, ttl *int64) error {
+	key := lb.ObjectName()
+	t := int64(RFC2136_DEFAULT_TTL)
+	if ttl != nil {
+		t = *ttl
+	}
+
+	names := utils.StringSet{}
+	var errs []string
+	for name, targets := range desired {
+		names.Add(name)
+		mod, err := this.zone.Reconcile(name, targets, t, key.String())
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else if mod {
+			logger.Infof("updated records for %s: %s", name, targets)
+		}
+	}
+	for name := range this.getPublished(key) {
+		if !names.Contains(name) {
+			if err := this.zone.Delete(name); err != nil {
+				errs = append(errs, err.Error())
+				names.Add(name)
+			} else {
+				logger.Infof("deleted records for %s", name)
+			}
+		}
+	}
+	this.setPublished(key, names)
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Cleanup deletes the records of all DNS names published for a load balancer.
+func (this *RFC2136Publisher) Cleanup(logger logger.LogContext, key resources.ObjectName, names ...string) error {
+	set := utils.NewStringSet(names...).AddSet(this.getPublished(key))
+	for name := range set {
+		if err := this.zone.Delete(name); err != nil {
+			return err
+		}
+		logger.Infof("deleted records for %s", name)
+	}
+	this.setPublished(key, nil)
+	return nil
+}
+
+// getPublished provides the names published for a load balancer. The
+// sets are replaced but never modified.
+func (this *RFC2136Publisher) getPublished(key resources.ObjectName) utils.StringSet {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.published[key]
+}
+
+func (this *RFC2136Publisher) setPublished(key resources.ObjectName, names utils.StringSet) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if names == nil {
+		delete(this.published, key)
+	} else {
+		this.published[key] = names
+	}
+}
+
+// CollectGarbage deletes owned records of vanished load balancers and
+// of names not published anymore by an existing load balancer. A name
+// published concurrently by a load balancer may be deleted, it is
+// published again by the next sync of the load balancer.
+func (this *RFC2136Publisher) CollectGarbage(logger logger.LogContext, lbs resources.Interface) error {
+	owned, err := this.zone.Owned()
+	if err != nil {
+		return err
+	}
+
+	for name, ref := range owned {
+		parts := strings.SplitN(ref, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		_, err := lbs.Namespace(parts[0]).GetCached(parts[1])
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				continue
+			}
+		} else {
+			published := this.getPublished(resources.NewObjectName(parts[0], parts[1]))
+			if published == nil || published.Contains(name) {
+				continue
+			}
+		}
+		logger.Infof("deleting obsolete records for %s (%s)", name, ref)
+		if err := this.zone.Delete(name); err != nil {
+			logger.Warnf("%s", err)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *DNSLBSource) getBackend(lb *lbutils.DNSLoadBalancerObject) (string, error) {
+	backend := lb.Spec().Backend
+	switch backend {
+	case "":
+		if this.gslb != nil && this.gslb.Zone(lb.Spec().DNSName) != "" {
+			return api.BACKEND_EMBEDDED, nil
+		}
+		return this.backend, nil
+	case api.BACKEND_EMBEDDED:
+		if err := this.checkEmbedded(lb); err != nil {
+			return "", err
+		}
+	case api.BACKEND_DNSENTRY:
+	case api.BACKEND_RFC2136:
+		if this.rfc2136 == nil {
+			return "", fmt.Errorf("backend %s not configured", backend)
+		}
+	default:
+		return "", fmt.Errorf("invalid backend %q", backend)
+	}
+	return backend, nil
+}
+
+// getRFC2136Info publishes the DNS names of a load balancer by dynamic
+// updates. No DNS names are reported to the DNS source framework, therefore
+// it removes all DNSEntry objects of the load balancer.
+func (this *DNSLBSource) getRFC2136Info(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject) (*source.DNSInfo, error) {
+	dnsname := lb.Spec().DNSName
+	if err := lb.SetFinalizer(RFC2136_FINALIZER); err != nil {
+		return nil, err
+	}
+	current, err := this.rfc2136.Current(dnsname)
+	if err != nil {
+		lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, err.Error())
+		return nil, err
+	}
+	w, targets, done, err := this.GetTargets(logger, lb, current)
+	if err != nil {
+		return nil, err
+	}
+	if !w.HoldsChanges() {
+		// additional names are published by dynamic updates, too
+		cleanup := this.entries.Update(logger, lb, nil, nil)
+
+		desired := w.AdditionalNames()
+		desired[dnsname] = targets
+		if srv := w.SRVRecords(); srv != nil {
+			desired[srv.DNSName] = srv.Targets
+		}
+		err = this.rfc2136.Publish(logger, lb, desired, w.TTL())
+		this.serveAnswers(logger, lb, w, targets)
+		if err == nil && cleanup != nil {
+			err = fmt.Errorf("cannot remove dns entries: %s", cleanup)
+		}
+	}
+	if done != nil {
+		if err != nil {
+			done.Failed(dnsname, err)
+		} else {
+			done.Succeeded()
+		}
+	}
+	// the DNS source framework does not reschedule load balancers without names
+	this.controller.EnqueueAfter(lb, RFC2136_SYNC_PERIOD)
+	return &source.DNSInfo{}, err
+}
+
+func (this *DNSLBSource) cleanupRFC2136(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject) error {
+	if this.rfc2136 == nil {
+		return fmt.Errorf("backend %s not configured", api.BACKEND_RFC2136)
+	}
+	if err := this.rfc2136.Cleanup(logger, lb.ObjectName(), lb.Spec().DNSName); err != nil {
+		return err
+	}
+	return lb.RemoveFinalizer(RFC2136_FINALIZER)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RFC2136Reconciler periodically removes obsolete records published by
+// dynamic updates.
+func RFC2136Reconciler(c controller.Interface) (reconcile.Interface, error) {
+	if err := registerCRDs(c); err != nil {
+		return nil, err
+	}
+	publisher, err := sharedRFC2136Publisher(c)
+	if err != nil {
+		return nil, err
+	}
+	lbs, err := c.GetMainCluster().GetResource(api.LoadBalancerGroupKind)
+	if err != nil {
+		return nil, err
+	}
+	grace, err := c.GetDurationOption(OPT_STARTUP_GRACE)
+	if err != nil {
+		return nil, err
+	}
+	return &rfc2136_reconciler{
+		controller: c,
+		publisher:  publisher,
+		lbs:        lbs,
+		grace:      grace,
+	}, nil
+}
+
+type rfc2136_reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	publisher  *RFC2136Publisher
+	lbs        resources.Interface
+	grace      time.Duration
+	started    time.Time
+}
+
+func (this *rfc2136_reconciler) Start() {
+	this.started = time.Now()
+	if this.publisher != nil {
+		this.controller.EnqueueCommand(CMD_RFC2136_GC)
+	}
+}
+
+func (this *rfc2136_reconciler) Command(logger logger.LogContext, cmd string) reconcile.Status {
+	if this.publisher == nil {
+		return reconcile.Succeeded(logger).Stop()
+	}
+	// all load balancers should have been reconciled before
+	if wait := this.started.Add(this.grace).Sub(time.Now()); wait > 0 {
+		return reconcile.Succeeded(logger).RescheduleAfter(wait)
+	}
+	if err := this.publisher.CollectGarbage(logger, this.lbs); err != nil {
+		logger.Warnf("garbage collection failed: %s", err)
+	}
+	return reconcile.Succeeded(logger).RescheduleAfter(RFC2136_GC_PERIOD)
+}
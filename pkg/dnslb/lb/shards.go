@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	coordination "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/shard"
+	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+const CMD_SHARDS = "shards"
+const SHARD_LEASE_PREFIX = "dnslb-shard-"
+const SHARD_DEFAULT_LEASE_DURATION = 30 * time.Second
+
+// LABEL_SHARD_GROUP marks the member leases of the replicas sharing the
+// load balancers of a class.
+const LABEL_SHARD_GROUP = api.GroupName + "/shard-group"
+
+var KEY_SHARDS = reflect.TypeOf((*Shards)(nil))
+
+// Shards splits the load balancers of a class among the controller
+// replicas running CONTROLLER_SHARDS. Every replica maintains a member
+// lease, load balancers are assigned to the live members by consistent
+// hashing of their key. If a replica cannot renew its own lease before it
+// expires, it gives up all load balancers until the lease is renewed.
+//
+// A load balancer taken over from a live member is only handled after the
+// lease of the previous owner valid at the time of the change has expired.
+// The previous owner either has seen the new member by then, or it has
+// given up all load balancers, so no load balancer is handled by two
+// replicas at the same time.
+type Shards struct {
+	controller controller.Interface
+	leases     resources.Interface
+	lbs        resources.Interface
+	namespace  string
+	id         string
+	group      string
+	duration   time.Duration
+
+	lock      sync.RWMutex
+	ring      *shard.Ring
+	expires   time.Time
+	handovers shard.Handovers
+}
+
+// Sharding provides the shards of CONTROLLER_SHARDS for a class to
+// CONTROLLER. It is shared by the controllers of the controller manager,
+// it has no shards if sharding is not configured.
+type Sharding struct {
+	lock   sync.RWMutex
+	shards *Shards
+}
+
+type shardingKey struct {
+	class string
+}
+
+// sharedSharding provides the sharding of a load balancer class.
+func sharedSharding(c controller.Interface, class string) *Sharding {
+	return controllermanager.GetOrCreateSharedValue(c.GetContext(), shardingKey{class}, func(*controllermanager.ControllerManager) interface{} {
+		return &Sharding{}
+	}).(*Sharding)
+}
+
+// Shards provides the shards used in sharded mode, or nil.
+func (this *Sharding) Shards() *Shards {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.shards
+}
+
+func (this *Sharding) set(shards *Shards) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.shards = shards
+}
+
+type sharedShardsValue struct {
+	shards *Shards
+	err    error
+}
+
+// sharedShards provides the shards shared by the reconcilers of a
+// controller. It is nil if sharding is not configured or for CONTROLLER.
+func sharedShards(c controller.Interface) (*Shards, error) {
+	if c.GetName() != CONTROLLER_SHARDS {
+		return nil, nil
+	}
+	shared := c.GetOrCreateSharedValue(KEY_SHARDS, func() interface{} {
+		s, err := NewShards(c)
+		if s != nil {
+			responsibility(c).sharding.set(s)
+		}
+		return &sharedShardsValue{s, err}
+	}).(*sharedShardsValue)
+	return shared.shards, shared.err
+}
+
+func NewShards(c controller.Interface) (*Shards, error) {
+	namespace, _ := c.GetStringOption(OPT_SHARD_NAMESPACE)
+	if namespace == "" {
+		c.Infof("sharded mode not configured (option %s): load balancers are reconciled by %s", OPT_SHARD_NAMESPACE, CONTROLLER)
+		return nil, nil
+	}
+	id, _ := c.GetStringOption(OPT_SHARD_ID)
+	if id == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine shard id: %s", err)
+		}
+		id = host
+	}
+	duration, err := c.GetDurationOption(OPT_SHARD_LEASE_DURATION)
+	if err != nil {
+		return nil, err
+	}
+	if duration < 3*time.Second {
+		return nil, fmt.Errorf("shard lease duration %s too short", duration)
+	}
+	leases, err := c.GetMainCluster().Resources().GetByExample(&coordination.Lease{})
+	if err != nil {
+		return nil, err
+	}
+	lbs, err := c.GetMainCluster().GetResource(api.LoadBalancerGroupKind)
+	if err != nil {
+		return nil, err
+	}
+	class := responsibility(c).Class()
+	c.Infof("sharded mode: member %q of shard group %q in namespace %s", id, class, namespace)
+	return &Shards{
+		controller: c,
+		leases:     leases,
+		lbs:        lbs,
+		namespace:  namespace,
+		id:         id,
+		group:      class,
+		duration:   duration,
+	}, nil
+}
+
+// Owner provides the replica responsible for a load balancer. Before the
+// first synchronization of the members no replica is responsible.
+func (this *Shards) Owner(name resources.ObjectName) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.ring.Owner(name.String())
+}
+
+// IsResponsibleFor checks whether a load balancer is handled by this
+// replica. Without sharding no load balancer is handled. After the own
+// lease has expired, no load balancer is handled until it is renewed.
+func (this *Shards) IsResponsibleFor(name resources.ObjectName) bool {
+	if this == nil {
+		return false
+	}
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	now := time.Now()
+	return now.Before(this.expires) && this.ring.Owner(name.String()) == this.id &&
+		this.handovers.Until(this.ring, this.id, name.String(), now).IsZero()
+}
+
+// Sync renews the member lease of this replica and updates the members
+// from the leases not yet expired. If the members change, the load
+// balancers newly assigned to this replica are enqueued, after the
+// handover if they are taken over from a live member. If the lease
+// cannot be renewed before it expires, all load balancers are given up,
+// so they are taken over by other replicas afterwards.
+func (this *Shards) Sync(logger logger.LogContext) error {
+	start := time.Now()
+	if err := this.renew(); err != nil {
+		this.lock.Lock()
+		defer this.lock.Unlock()
+		if this.ring != nil && !start.Before(this.expires) {
+			logger.Warnf("shard lease expired: giving up all load balancers")
+			this.ring = nil
+		}
+		return err
+	}
+	list, err := this.leases.Namespace(this.namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{LABEL_SHARD_GROUP: lbutils.LabelValue(this.group)}).String(),
+	})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	members := []string{}
+	others := []string{}
+	for _, o := range list {
+		l := lbutils.Lease(o)
+		if !strings.HasPrefix(l.GetName(), SHARD_LEASE_PREFIX) || l.IsExpired(now) || l.Spec().HolderIdentity == nil {
+			continue
+		}
+		members = append(members, *l.Spec().HolderIdentity)
+		if *l.Spec().HolderIdentity != this.id {
+			others = append(others, *l.Spec().HolderIdentity)
+		}
+	}
+	ring := shard.NewRing(members...)
+
+	this.lock.Lock()
+	// the lease is valid for the duration starting before the renewal,
+	// the members are valid only if they have been read afterwards
+	this.expires = start.Add(this.duration)
+	this.handovers = this.handovers.Prune(now)
+	old := this.ring
+	if ring.Equals(old) {
+		this.lock.Unlock()
+		return nil
+	}
+	previous := old
+	if previous == nil {
+		// after a restart the load balancers may still be handled by
+		// the other members
+		previous = shard.NewRing(others...)
+	}
+	this.handovers = append(this.handovers, shard.Handover{Previous: previous, Until: now.Add(this.duration)})
+	this.ring = ring
+	handovers := this.handovers
+	this.lock.Unlock()
+
+	logger.Infof("shard members changed: %s", strings.Join(ring.Members(), ", "))
+	lbs, err := this.lbs.ListCached(labels.Everything())
+	if err != nil {
+		return err
+	}
+	count := 0
+	for _, o := range lbs {
+		key := o.ObjectName().String()
+		if GetClass(o) == this.group && ring.Owner(key) == this.id && old.Owner(key) != this.id {
+			if until := handovers.Until(ring, this.id, key, now); until.IsZero() {
+				this.controller.Enqueue(o)
+			} else {
+				this.controller.EnqueueAfter(o, until.Sub(now))
+			}
+			count++
+		}
+	}
+	logger.Infof("%d of %d load balancers taken over", count, len(lbs))
+	return nil
+}
+
+func (this *Shards) renew() error {
+	lease, err := this.leases.Wrap(&coordination.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SHARD_LEASE_PREFIX + lbutils.ClusterObjectName(this.group+"-"+this.id),
+			Namespace: this.namespace,
+			Labels: map[string]string{
+				LABEL_SHARD_GROUP: lbutils.LabelValue(this.group),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = lbutils.Lease(lease).Renew(this.id, this.duration)
+	if err != nil {
+		return fmt.Errorf("cannot renew shard lease %s: %s", lease.ObjectName(), err)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// handOver handles a load balancer reconciled by another replica or by
+// the other DNS controller. It has been queued before the shards were
+// rebalanced or the mode was switched. The published DNS entries are kept
+// unchanged. In sharded mode the local state of the load balancer is
+// dropped. Otherwise the state is kept, because it may belong to the
+// other DNS controller running in the same process.
+func (this *DNSLBSource) handOver(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, current *source.DNSCurrentState) *source.DNSInfo {
+	if this.shards != nil {
+		logger.Infof("load balancer handled by shard %q", this.shards.Owner(lb.ObjectName()))
+		this.removeAnswers(lb.ObjectName())
+		watch.RemoveState(lb.ObjectName())
+		this.probes.Remove(lb.ObjectName().String())
+	} else {
+		logger.Infof("load balancer handled by other dns controller")
+	}
+	info := &source.DNSInfo{Names: utils.StringSet{}, Targets: current.Targets}
+	for n := range current.Names {
+		info.Names.Add(n)
+	}
+	info.TTL = lb.Status().TTL
+	if info.TTL == nil {
+		info.TTL = lb.Spec().TTL
+	}
+	return info
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ShardReconciler periodically synchronizes the shard members.
+func ShardReconciler(c controller.Interface) (reconcile.Interface, error) {
+	shards, err := sharedShards(c)
+	if err != nil {
+		return nil, err
+	}
+	return &shard_reconciler{
+		controller: c,
+		shards:     shards,
+	}, nil
+}
+
+type shard_reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	shards     *Shards
+}
+
+func (this *shard_reconciler) Start() {
+	if this.shards != nil {
+		this.controller.EnqueueCommand(CMD_SHARDS)
+	}
+}
+
+func (this *shard_reconciler) Command(logger logger.LogContext, cmd string) reconcile.Status {
+	if this.shards == nil {
+		return reconcile.Succeeded(logger).Stop()
+	}
+	if err := this.shards.Sync(logger); err != nil {
+		logger.Warnf("shard synchronization failed: %s", err)
+	}
+	return reconcile.Succeeded(logger).RescheduleAfter(this.shards.duration / 3)
+}
@@ -5,7 +5,7 @@
 package lb
 
 import (
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
@@ -14,36 +14,84 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 )
 
+// StateReconciler maintains the endpoints of the load balancers and
+// validates them.
 func StateReconciler(c controller.Interface) (reconcile.Interface, error) {
+	return newStateReconciler(c, true)
+}
+
+// StateTrackingReconciler maintains the endpoints of the load balancers
+// for CONTROLLER_SHARDS. The endpoints are validated by the state
+// reconciler of CONTROLLER.
+func StateTrackingReconciler(c controller.Interface) (reconcile.Interface, error) {
+	return newStateReconciler(c, false)
+}
+
+func newStateReconciler(c controller.Interface, validate bool) (reconcile.Interface, error) {
+	if err := registerCRDs(c); err != nil {
+		return nil, err
+	}
+	lbs, err := c.GetMainCluster().GetResource(api.LoadBalancerGroupKind)
+	if err != nil {
+		return nil, err
+	}
 	state := c.GetOrCreateSharedValue(KEY_STATE,
 		func() interface{} {
 			return NewState(c)
 		}).(*State)
 
 	return &stateReconciler{
-		controller: c,
-		state:      state,
+		controller:     c,
+		state:          state,
+		lbs:            lbs,
+		responsibility: responsibility(c),
+		validate:       validate,
 	}, nil
 }
 
 type stateReconciler struct {
 	reconcile.DefaultReconciler
-	controller controller.Interface
-	state      *State
+	controller     controller.Interface
+	state          *State
+	lbs            resources.Interface
+	responsibility *Responsibility
+	validate       bool
+}
+
+// handles checks whether the load balancer of an endpoint belongs to the
+// class of the controller. Endpoints of unknown load balancers are handled
+// by all controllers.
+func (this *stateReconciler) handles(ep *lbutils.DNSLoadBalancerEndpointObject) bool {
+	ref := ep.GetLoadBalancerRef()
+	if ref == nil {
+		return true
+	}
+	lb, err := this.lbs.GetCached(ref.ObjectName())
+	if err != nil {
+		return true
+	}
+	return this.responsibility.HasClass(lb)
 }
 
 func (this *stateReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	if !this.handles(lbutils.DNSLoadBalancerEndpoint(obj)) {
+		this.state.RemoveEndpoint(logger, obj.ClusterKey())
+		return reconcile.Succeeded(logger)
+	}
 	logger.Infof("reconcile endpoint %q", obj.ClusterKey())
 
 	this.state.UpdateEndpoint(logger, obj)
+	if !this.validate {
+		return reconcile.Succeeded(logger)
+	}
 	ep := lbutils.DNSLoadBalancerEndpoint(obj)
 	err := ep.Validate()
 	mod := false
 	if err != nil {
-		mod, err = ep.UpdateState(api.STATE_INVALID, err.Error(), nil)
+		mod, err = ep.UpdateState(lbutils.FIELD_MANAGER_STATE, api.STATE_INVALID, err.Error(), nil)
 	} else {
 		if reconcile.StringEqual(ep.Status().State, api.STATE_INVALID) {
-			mod, err = ep.UpdateState(api.STATE_PENDING, "", nil)
+			mod, err = ep.UpdateState(lbutils.FIELD_MANAGER_STATE, api.STATE_PENDING, "", nil)
 		}
 	}
 	if mod {
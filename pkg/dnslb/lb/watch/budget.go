@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/metrics"
+)
+
+const DEFAULT_BUDGET_WINDOW = time.Hour
+
+// CHANGE_HISTORY_REFRESH is the period the changes of all load balancers
+// are cached for the global change budget. Changes of the controller
+// itself are added immediately, only changes of other replicas are
+// counted with a delay.
+const CHANGE_HISTORY_REFRESH = 30 * time.Second
+
+// ChangeHistory provides the changes recorded in the status of all
+// load balancers handled by the controller.
+type ChangeHistory func() map[string][]time.Time
+
+// ChangeLimiter enforces the change budgets of the load balancers and the
+// global change budget. The changes are recorded in the status of the
+// load balancers, so the budgets are kept across restarts and shared by
+// all replicas. Changes not yet visible in the status are kept in memory.
+type ChangeLimiter struct {
+	lock    sync.Mutex
+	limit   int
+	window  time.Duration
+	history ChangeHistory
+	pending map[string][]time.Time
+
+	// global are the ordered changes of all load balancers within the
+	// global window, taken from the history at refreshed
+	global    []time.Time
+	refreshed time.Time
+}
+
+// NewChangeLimiter creates a limiter with the given global budget.
+// A limit less or equal to zero disables the global budget.
+func NewChangeLimiter(limit int, window time.Duration, history ChangeHistory) *ChangeLimiter {
+	if window <= 0 {
+		window = DEFAULT_BUDGET_WINDOW
+	}
+	return &ChangeLimiter{limit: limit, window: window, history: history, pending: map[string][]time.Time{}}
+}
+
+func prune(changes []time.Time, window time.Duration, now time.Time) []time.Time {
+	i := 0
+	for i < len(changes) && !changes[i].Add(window).After(now) {
+		i++
+	}
+	return changes[i:]
+}
+
+// merge provides the ordered union of two lists of changes. The status
+// keeps the times with a resolution of seconds only.
+func merge(a, b []time.Time) []time.Time {
+	found := map[int64]bool{}
+	result := []time.Time{}
+	for _, list := range [][]time.Time{a, b} {
+		for _, t := range list {
+			if !found[t.Unix()] {
+				found[t.Unix()] = true
+				result = append(result, t)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// wait provides the time until a change is permitted by a budget.
+func wait(changes []time.Time, limit int, window time.Duration, now time.Time) time.Duration {
+	if limit <= 0 || len(changes) < limit {
+		return 0
+	}
+	return changes[len(changes)-limit].Add(window).Sub(now)
+}
+
+// Reserve records a change of a load balancer, if it is permitted by the
+// budget of the load balancer and the global budget. The recorded changes
+// are the changes taken from the status of the load balancer. It returns
+// the changes to record in the status. Otherwise it provides the exhausted
+// budget and the time until a change is permitted.
+func (this *ChangeLimiter) Reserve(key string, budget *api.DNSLoadBalancerChangeBudget, recorded []time.Time, now time.Time) (string, time.Duration, []time.Time) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	retention := time.Duration(0)
+	if this.limit > 0 {
+		retention = this.window
+	}
+	changes := prune(merge(recorded, this.pending[key]), this.maxWindow(budget), now)
+	if budget != nil {
+		window := budgetWindow(budget)
+		if window > retention {
+			retention = window
+		}
+		if d := wait(prune(changes, window, now), budget.MaxChanges, window, now); d > 0 {
+			return api.BUDGET_LOADBALANCER, d, nil
+		}
+	}
+	if this.limit > 0 {
+		if d := wait(this.globalChanges(now), this.limit, this.window, now); d > 0 {
+			return api.BUDGET_GLOBAL, d, nil
+		}
+		this.global = append(this.global, now)
+	}
+	if retention == 0 {
+		delete(this.pending, key)
+		return "", 0, nil
+	}
+	this.pending[key] = append(prune(this.pending[key], retention, now), now)
+	return "", 0, prune(append(changes, now), retention, now)
+}
+
+func (this *ChangeLimiter) maxWindow(budget *api.DNSLoadBalancerChangeBudget) time.Duration {
+	window := this.window
+	if budget != nil && budgetWindow(budget) > window {
+		window = budgetWindow(budget)
+	}
+	return window
+}
+
+// globalChanges provides the ordered changes of all load balancers within
+// the global window. The history is only read once per refresh period
+// instead of for every change, the changes of the controller are added
+// to the cached changes by Reserve.
+func (this *ChangeLimiter) globalChanges(now time.Time) []time.Time {
+	if this.refreshed.IsZero() || now.Sub(this.refreshed) >= CHANGE_HISTORY_REFRESH || now.Before(this.refreshed) {
+		all := map[string][]time.Time{}
+		if this.history != nil {
+			all = this.history()
+		}
+		for k, p := range this.pending {
+			all[k] = merge(all[k], p)
+		}
+		this.global = []time.Time{}
+		for _, c := range all {
+			this.global = append(this.global, c...)
+		}
+		sort.Slice(this.global, func(i, j int) bool { return this.global[i].Before(this.global[j]) })
+		this.refreshed = now
+	}
+	this.global = prune(this.global, this.window, now)
+	return this.global
+}
+
+func budgetWindow(budget *api.DNSLoadBalancerChangeBudget) time.Duration {
+	if budget.Window != nil {
+		return budget.Window.Duration
+	}
+	return DEFAULT_BUDGET_WINDOW
+}
+
+// Remove forgets the pending changes of a load balancer.
+func (this *ChangeLimiter) Remove(key string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.pending, key)
+}
+
+// ChangeTimes provides the change times recorded in a status.
+func ChangeTimes(changes []metav1.Time) []time.Time {
+	result := []time.Time{}
+	for _, t := range changes {
+		result = append(result, t.Time)
+	}
+	return result
+}
+
+// RecordsHash provides a hash identifying the targets of a set of DNS names.
+func RecordsHash(records map[string]utils.StringSet) string {
+	names := []string{}
+	for n := range records {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, n := range names {
+		fmt.Fprintf(h, "%s=%s\n", strings.ToLower(n), strings.Join(sortedStrings(records[n]), ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// IsThrottled checks whether a change of the targets has been deferred.
+func (this *Watch) IsThrottled() bool {
+	return this.deferred > 0
+}
+
+// Deferred provides the time until a deferred change can be published.
+func (this *Watch) Deferred() time.Duration {
+	return this.deferred
+}
+
+// HoldsChanges checks whether the published DNS records are kept
+// unchanged, either by a freeze or by an exhausted change budget.
+func (this *Watch) HoldsChanges() bool {
+	return this.IsFrozen() || this.IsThrottled()
+}
+
+// records provides the targets of all DNS names published for the load
+// balancer.
+func (this *Watch) records() map[string]utils.StringSet {
+	records := this.AdditionalNames()
+	records[this.dnsname] = this.updated
+	if this.srv != nil {
+		records[this.srv.DNSName] = this.srv.Targets
+	}
+	return records
+}
+
+// throttle applies the change budgets to a change of the published DNS
+// records. Besides the targets of the DNS name, changes of the region
+// specific names, the endpoint names and the SRV records are counted.
+// If a budget is exhausted, the published records are kept and the change
+// is deferred. Subsequent changes are merged, only the latest targets are
+// published when the budget permits it again.
+func (this *Watch) throttle(done *DNSDone, mod bool) bool {
+	status := &api.DNSLoadBalancerStatus{}
+	if this.DNSLB != nil {
+		status = this.DNSLB.Status()
+	}
+	hash := RecordsHash(this.records())
+	changed := mod || (status.RecordsHash != "" && status.RecordsHash != hash)
+	if !changed || this.Limiter == nil || len(this.current.Targets) == 0 {
+		// the initial publication is always permitted
+		done.SetChanges(hash, status.Changes)
+		return false
+	}
+	budget, d, changes := this.Limiter.Reserve(this.GetKey(), this.ChangeBudget, ChangeTimes(status.Changes), time.Now())
+	if d <= 0 {
+		recorded := []metav1.Time{}
+		for _, t := range changes {
+			recorded = append(recorded, metav1.NewTime(t.Truncate(time.Second)))
+		}
+		done.SetChanges(hash, recorded)
+		return false
+	}
+	desired := this.updated
+	this.updated = this.current.Targets
+	this.deferred = d
+	metrics.ReportDeferredChange(this.GetKey(), budget)
+	this.Infof("%s change budget exhausted: deferring targets %s for %s", budget, desired, d)
+	done.SetThrottled(&api.DNSLoadBalancerThrottle{
+		Budget:  budget,
+		Until:   metav1.NewTime(time.Now().Add(d).Truncate(time.Second)),
+		Targets: sortedStrings(desired),
+	}, this.current.Targets)
+	return true
+}
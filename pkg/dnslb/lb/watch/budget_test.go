@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("change budgets", func() {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d ...time.Duration) []time.Time {
+		result := []time.Time{}
+		for _, e := range d {
+			result = append(result, now.Add(-e))
+		}
+		return result
+	}
+	budget := &api.DNSLoadBalancerChangeBudget{MaxChanges: 2, Window: &metav1.Duration{Duration: 10 * time.Minute}}
+
+	It("permits changes within the budget of the load balancer and records them", func() {
+		limiter := NewChangeLimiter(0, 0, nil)
+		b, d, changes := limiter.Reserve("default/a", budget, ago(20*time.Minute, 5*time.Minute), now)
+		Expect(b).To(BeEmpty())
+		Expect(d).To(BeZero())
+		Expect(changes).To(Equal(ago(5*time.Minute, 0)))
+	})
+
+	It("defers changes exceeding the budget of the load balancer", func() {
+		limiter := NewChangeLimiter(0, 0, nil)
+		b, d, _ := limiter.Reserve("default/a", budget, ago(8*time.Minute, 5*time.Minute), now)
+		Expect(b).To(Equal(api.BUDGET_LOADBALANCER))
+		Expect(d).To(Equal(2 * time.Minute))
+	})
+
+	It("counts changes not yet recorded in the status", func() {
+		limiter := NewChangeLimiter(0, 0, nil)
+		_, d, _ := limiter.Reserve("default/a", budget, nil, now.Add(-time.Minute))
+		Expect(d).To(BeZero())
+		_, d, _ = limiter.Reserve("default/a", budget, nil, now.Add(-time.Second))
+		Expect(d).To(BeZero())
+		b, d, _ := limiter.Reserve("default/a", budget, nil, now)
+		Expect(b).To(Equal(api.BUDGET_LOADBALANCER))
+		Expect(d).To(Equal(9 * time.Minute))
+	})
+
+	It("uses the recorded changes after a restart", func() {
+		limiter := NewChangeLimiter(0, 0, nil)
+		_, d, changes := limiter.Reserve("default/a", budget, nil, now.Add(-time.Minute))
+		Expect(d).To(BeZero())
+		_, d, changes = limiter.Reserve("default/a", budget, changes, now.Add(-time.Second))
+		Expect(d).To(BeZero())
+
+		restarted := NewChangeLimiter(0, 0, nil)
+		b, _, _ := restarted.Reserve("default/a", budget, changes, now)
+		Expect(b).To(Equal(api.BUDGET_LOADBALANCER))
+	})
+
+	It("does not record changes without budget", func() {
+		limiter := NewChangeLimiter(0, 0, nil)
+		b, d, changes := limiter.Reserve("default/a", nil, ago(time.Minute), now)
+		Expect(b).To(BeEmpty())
+		Expect(d).To(BeZero())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("counts the recorded changes of all load balancers for the global budget", func() {
+		history := map[string][]time.Time{
+			"default/a": ago(30*time.Minute, 2*time.Hour),
+			"default/b": ago(20 * time.Minute),
+			"other/c":   ago(10 * time.Minute),
+		}
+		limiter := NewChangeLimiter(3, time.Hour, func() map[string][]time.Time { return history })
+		b, d, _ := limiter.Reserve("default/a", nil, history["default/a"], now)
+		Expect(b).To(Equal(api.BUDGET_GLOBAL))
+		Expect(d).To(Equal(30 * time.Minute))
+
+		delete(history, "other/c")
+		later := now.Add(CHANGE_HISTORY_REFRESH)
+		b, d, changes := limiter.Reserve("default/a", nil, history["default/a"], later)
+		Expect(b).To(BeEmpty())
+		Expect(d).To(BeZero())
+		Expect(changes).To(Equal([]time.Time{now.Add(-30 * time.Minute), later}))
+
+		// the change is counted before it is visible in the history
+		b, _, _ = limiter.Reserve("default/b", nil, history["default/b"], later)
+		Expect(b).To(Equal(api.BUDGET_GLOBAL))
+	})
+
+	It("reads the history of all load balancers once per refresh period", func() {
+		calls := 0
+		history := func() map[string][]time.Time {
+			calls++
+			return map[string][]time.Time{"default/b": ago(20 * time.Minute)}
+		}
+		limiter := NewChangeLimiter(3, time.Hour, history)
+		for i := 0; i < 3; i++ {
+			limiter.Reserve("default/a", nil, nil, now.Add(time.Duration(i)*time.Second))
+		}
+		Expect(calls).To(Equal(1))
+		b, _, _ := limiter.Reserve("default/c", nil, nil, now.Add(3*time.Second))
+		Expect(b).To(Equal(api.BUDGET_GLOBAL))
+		limiter.Reserve("default/c", nil, nil, now.Add(CHANGE_HISTORY_REFRESH))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("records changes for the longest window", func() {
+		limiter := NewChangeLimiter(10, 2*time.Hour, nil)
+		_, _, changes := limiter.Reserve("default/a", budget, ago(90*time.Minute, 150*time.Minute), now)
+		Expect(changes).To(Equal(ago(90*time.Minute, 0)))
+	})
+
+	Context("records hash", func() {
+		records := map[string]utils.StringSet{
+			"lb.example.org":    utils.NewStringSet("10.0.0.1", "10.0.0.2"),
+			"eu.lb.example.org": utils.NewStringSet("10.0.0.1"),
+		}
+
+		It("does not depend on the order or case", func() {
+			Expect(RecordsHash(records)).To(Equal(RecordsHash(map[string]utils.StringSet{
+				"EU.lb.example.org": utils.NewStringSet("10.0.0.1"),
+				"lb.example.org":    utils.NewStringSet("10.0.0.2", "10.0.0.1"),
+			})))
+		})
+
+		It("changes with the targets of additional names", func() {
+			Expect(RecordsHash(records)).NotTo(Equal(RecordsHash(map[string]utils.StringSet{
+				"lb.example.org":    utils.NewStringSet("10.0.0.1", "10.0.0.2"),
+				"eu.lb.example.org": utils.NewStringSet("10.0.0.2"),
+			})))
+			Expect(RecordsHash(records)).NotTo(Equal(RecordsHash(map[string]utils.StringSet{
+				"lb.example.org": utils.NewStringSet("10.0.0.1", "10.0.0.2"),
+			})))
+		})
+	})
+})
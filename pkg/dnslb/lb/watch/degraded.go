@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/server/metrics"
+)
+
+// ValidateMinHealthy checks the minHealthy setting of a load balancer.
+func ValidateMinHealthy(min *intstr.IntOrString) error {
+	v, err := intstr.GetValueFromIntOrPercent(min, 100, true)
+	if err != nil {
+		return fmt.Errorf("invalid minHealthy: %s", err)
+	}
+	if v < 0 {
+		return fmt.Errorf("invalid minHealthy: must not be negative")
+	}
+	return nil
+}
+
+// Degraded checks whether fewer endpoints than required by minHealthy
+// are healthy. Percentages of the configured endpoints are rounded up.
+func Degraded(min *intstr.IntOrString, healthy, total int) bool {
+	if min == nil {
+		return false
+	}
+	v, _ := intstr.GetValueFromIntOrPercent(min, total, true)
+	return healthy < v
+}
+
+// handleDegraded checks whether enough endpoints are healthy.
+func (this *Watch) handleDegraded(done *DNSDone) {
+	healthy := 0
+	for _, t := range this.Targets {
+		if t.Healthy {
+			healthy++
+		}
+	}
+	degraded := Degraded(this.MinHealthy, healthy, len(this.Targets))
+	if this.MinHealthy != nil {
+		done.SetDegraded(degraded, fmt.Sprintf("%d of %d endpoints healthy (minimum %s)", healthy, len(this.Targets), this.MinHealthy.String()))
+	}
+	metrics.ReportHealthyEndpoints(this.GetKey(), healthy, degraded)
+}
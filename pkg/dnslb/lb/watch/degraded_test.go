@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("degraded", func() {
+	It("is never degraded without minHealthy", func() {
+		Expect(Degraded(nil, 0, 3)).To(BeFalse())
+	})
+
+	It("is never degraded for a minimum of zero", func() {
+		Expect(Degraded(count(0), 0, 3)).To(BeFalse())
+	})
+
+	Context("with a number of endpoints", func() {
+		It("is not degraded with enough healthy endpoints", func() {
+			Expect(Degraded(count(2), 2, 3)).To(BeFalse())
+		})
+
+		It("is degraded with too few healthy endpoints", func() {
+			Expect(Degraded(count(2), 1, 3)).To(BeTrue())
+		})
+
+		It("is degraded if the minimum exceeds the endpoints", func() {
+			Expect(Degraded(count(4), 3, 3)).To(BeTrue())
+		})
+	})
+
+	Context("with a percentage", func() {
+		It("uses the percentage of the configured endpoints", func() {
+			Expect(Degraded(percent("50%"), 2, 4)).To(BeFalse())
+			Expect(Degraded(percent("50%"), 1, 4)).To(BeTrue())
+		})
+
+		It("rounds percentages up", func() {
+			Expect(Degraded(percent("50%"), 1, 3)).To(BeTrue())
+			Expect(Degraded(percent("50%"), 2, 3)).To(BeFalse())
+		})
+
+		It("requires all endpoints for 100%", func() {
+			Expect(Degraded(percent("100%"), 2, 3)).To(BeTrue())
+		})
+
+		It("is not degraded without endpoints", func() {
+			Expect(Degraded(percent("50%"), 0, 0)).To(BeFalse())
+		})
+	})
+})
@@ -8,15 +8,20 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
 	"github.com/gardener/dnslb-controller-manager/pkg/server/metrics"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type DNSDone struct {
@@ -26,9 +31,30 @@ type DNSDone struct {
 	message   string
 	hcount    int
 	ishealthy bool
+	checked   bool
+	resolves  bool
 	active    map[string]*lbutils.DNSLoadBalancerEndpointObject
 	healthy   map[string]*lbutils.DNSLoadBalancerEndpointObject
 	unhealthy map[string]*lbutils.DNSLoadBalancerEndpointObject
+	targets   map[string]*Target
+	regions   []*RegionTargets
+
+	degradedset bool
+	degraded    bool
+	degradedmsg string
+
+	namesset     bool
+	nameproblems []string
+
+	frozen     *api.DNSLoadBalancerFreeze
+	throttled  *api.DNSLoadBalancerThrottle
+	ttlset     bool
+	ttl        *int64
+	lastChange *metav1.Time
+
+	changesset  bool
+	changes     []metav1.Time
+	recordshash string
 }
 
 var _ source.DNSFeedback = &DNSDone{}
@@ -40,6 +66,7 @@ func NewStatusUpdate(w *Watch) *DNSDone {
 		active:    map[string]*lbutils.DNSLoadBalancerEndpointObject{},
 		healthy:   map[string]*lbutils.DNSLoadBalancerEndpointObject{},
 		unhealthy: map[string]*lbutils.DNSLoadBalancerEndpointObject{},
+		targets:   map[string]*Target{},
 	}
 }
 
@@ -67,6 +94,30 @@ func (this *DNSDone) SetHealthy(a bool) *DNSDone {
 	return this
 }
 
+// SetResolvable reports whether the DNS name of the load balancer could
+// be resolved for the health check.
+func (this *DNSDone) SetResolvable(a bool) *DNSDone {
+	this.checked = true
+	this.resolves = a
+	return this
+}
+
+// SetDegraded reports whether fewer endpoints than required are healthy.
+func (this *DNSDone) SetDegraded(degraded bool, msg string) *DNSDone {
+	this.degradedset = true
+	this.degraded = degraded
+	this.degradedmsg = msg
+	return this
+}
+
+// SetEndpointNameProblems reports the problems determining the endpoint
+// names of an endpoint name template.
+func (this *DNSDone) SetEndpointNameProblems(problems []string) *DNSDone {
+	this.namesset = true
+	this.nameproblems = problems
+	return this
+}
+
 func (this *DNSDone) SetMessage(msg string) *DNSDone {
 	this.message = msg
 	return this
@@ -76,6 +127,7 @@ func (this *DNSDone) AddHealthyTarget(target *Target) {
 	this.hcount++
 	if target.DNSEP != nil {
 		this.healthy[target.DNSEP.GetName()] = target.DNSEP
+		this.targets[target.DNSEP.GetName()] = target
 	}
 }
 
@@ -89,9 +141,52 @@ func (this *DNSDone) AddActiveTarget(target *Target) {
 func (this *DNSDone) AddUnhealthyTarget(target *Target) {
 	if target.DNSEP != nil {
 		this.unhealthy[target.DNSEP.GetName()] = target.DNSEP
+		this.targets[target.DNSEP.GetName()] = target
 	}
 }
 
+func (this *DNSDone) SetRegions(regions []*RegionTargets) {
+	this.regions = regions
+}
+
+// SetFrozen reports a freeze of DNS changes.
+func (this *DNSDone) SetFrozen(frozen *api.DNSLoadBalancerFreeze, published utils.StringSet) {
+	this.frozen = frozen
+	this.setPublished(published)
+}
+
+// SetThrottled reports a change deferred by a change budget.
+func (this *DNSDone) SetThrottled(throttled *api.DNSLoadBalancerThrottle, published utils.StringSet) {
+	this.throttled = throttled
+	this.setPublished(published)
+}
+
+// setPublished reports only targets with published DNS targets as active.
+func (this *DNSDone) setPublished(published utils.StringSet) {
+	this.active = map[string]*lbutils.DNSLoadBalancerEndpointObject{}
+	for name, t := range this.targets {
+		t.Active = isPublished(t, published)
+		if t.Active {
+			this.active[name] = t.DNSEP
+		}
+	}
+}
+
+// SetTTL sets the TTL in use reported in the status.
+func (this *DNSDone) SetTTL(ttl *int64, lastChange *metav1.Time) {
+	this.ttlset = true
+	this.ttl = ttl
+	this.lastChange = lastChange
+}
+
+// SetChanges sets the hash of the published records and the changes
+// counted by the change budgets reported in the status.
+func (this *DNSDone) SetChanges(hash string, changes []metav1.Time) {
+	this.changesset = true
+	this.recordshash = hash
+	this.changes = changes
+}
+
 func (this *DNSDone) HasHealthy() bool {
 	return this.hcount != 0
 }
@@ -120,64 +215,200 @@ func (this *DNSDone) updateStatus(state, message string) {
 }
 
 func (this *DNSDone) _updateLoadBalancerStatus(activeupd bool, state, message string) {
+	this.degradedEvent()
 	dnslb := this.dnslb.Copy()
-	status := dnslb.Status()
-	if state == "" {
-		if this.ishealthy {
-			state = api.STATE_HEALTHY
+	// the status is computed again for the current version of the load
+	// balancer after a conflict
+	mod, err := lbutils.ModifyStatus(dnslb, lbutils.FIELD_MANAGER_DNS, func(resources.ObjectData) (bool, error) {
+		old := dnslb.Status().DeepCopy()
+		status := dnslb.Status()
+		generation := dnslb.DNSLoadBalancer().Generation
+		status.ObservedGeneration = generation
+		status.Conditions = this.conditions(status.Conditions, state, message, generation)
+		if state == "" {
+			switch {
+			case !this.ishealthy:
+				state = api.STATE_UNREACHABLE
+			case this.degraded:
+				state = api.STATE_DEGRADED
+				if message == "" {
+					message = this.degradedmsg
+				}
+			default:
+				state = api.STATE_HEALTHY
+			}
+		}
+		status.State = &state
+		if message != "" {
+			status.Message = &message
 		} else {
-			state = api.STATE_UNREACHABLE
+			status.Message = nil
 		}
-	}
-	status.State = &state
-	if message != "" {
-		status.Message = &message
-	} else {
-		status.Message = nil
-	}
-	if activeupd {
-		if len(this.active) > 0 {
-			status.Active = []api.DNSLoadBalancerActive{}
-			keys := []string{}
-			for _, t := range this.active {
-				keys = append(keys, t.GetName())
+		if activeupd {
+			if len(this.active) > 0 {
+				status.Active = []api.DNSLoadBalancerActive{}
+				keys := []string{}
+				for _, t := range this.active {
+					keys = append(keys, t.GetName())
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					t := this.active[k]
+					active := api.DNSLoadBalancerActive{
+						Endpoint: t.GetName(),
+						CName:    t.Spec().CName,
+					}
+					if addrs := t.Spec().Addresses; len(addrs) > 0 {
+						active.IPAddress = addrs[0]
+					}
+					if t := this.targets[k]; t != nil {
+						active.Addresses = t.Addresses
+						active.Region = t.Region
+						if c := t.Cluster; c != nil {
+							active.Cluster = c.ClusterId
+							active.Zone = c.Zone
+						}
+					}
+					status.Active = append(status.Active, active)
+				}
+			} else {
+				status.Active = nil
 			}
-			sort.Strings(keys)
-			for _, k := range keys {
-				t := this.active[k]
-				status.Active = append(status.Active,
-					api.DNSLoadBalancerActive{
-						Endpoint:  t.GetName(),
-						IPAddress: t.Spec().IPAddress,
-						CName:     t.Spec().CName,
-					})
+			status.Frozen = this.frozen
+			status.Throttled = this.throttled
+			status.Regions = nil
+			for _, r := range this.regions {
+				status.Regions = append(status.Regions, api.DNSLoadBalancerRegion{
+					Region:   r.Region,
+					DNSName:  r.DNSName,
+					Targets:  sortedStrings(r.Published),
+					Fallback: r.Fallback,
+				})
 			}
 		} else {
-			status.Active = nil
+			if state != "" && state != api.STATE_PENDING {
+				status.Active = nil
+			}
 		}
-	} else {
-		if state != "" && state != api.STATE_PENDING {
-			status.Active = nil
+		if this.ttlset {
+			status.TTL = this.ttl
+			status.LastChange = this.lastChange
 		}
-	}
-	if !reflect.DeepEqual(this.dnslb.Status(), status) {
-		this.logger.Infof("old: %+v", this.dnslb.Status())
-		this.logger.Infof("new: %+v", dnslb.Status())
+		if this.changesset {
+			status.RecordsHash = this.recordshash
+			status.Changes = nil
+			if len(this.changes) > 0 {
+				status.Changes = this.changes
+			}
+		}
+		return !reflect.DeepEqual(old, status), nil
+	})
+	if mod {
 		this.logger.Infof("updating status for dns load balancer %s/%s", dnslb.GetNamespace(), dnslb.GetName())
-		err := dnslb.Update()
 		if err != nil {
 			this.logger.Errorf("cannot update dns load balancer status for %s/%s: %s", dnslb.GetNamespace(), dnslb.GetName(), err)
 		}
 	}
 }
 
+// conditions provides the conditions of the load balancer for a state
+// reported by the DNS source. An empty state denotes published records.
+func (this *DNSDone) conditions(conditions []api.Condition, state, message string, generation int64) []api.Condition {
+	set := func(ty string, ok bool, reason, msg string) {
+		conditions = lbutils.SetCondition(conditions, ty, lbutils.ConditionStatus(ok), reason, msg, generation)
+	}
+	ready, reason, msg := true, "Ready", ""
+	fail := func(r, m string) {
+		if ready {
+			ready, reason, msg = false, r, m
+		}
+	}
+
+	switch {
+	case state != "":
+		set(api.CONDITION_DNS_PUBLISHED, false, state, message)
+		fail(state, message)
+	case this.frozen != nil:
+		set(api.CONDITION_DNS_PUBLISHED, true, "Frozen", fmt.Sprintf("dns changes suspended: %s", this.frozen.Reason))
+	case this.throttled != nil:
+		set(api.CONDITION_DNS_PUBLISHED, true, "Throttled", fmt.Sprintf("change deferred by %s budget until %s",
+			this.throttled.Budget, this.throttled.Until.UTC().Format(time.RFC3339)))
+	default:
+		set(api.CONDITION_DNS_PUBLISHED, true, "Published", "")
+	}
+
+	total := len(this.healthy) + len(this.unhealthy)
+	switch {
+	case this.HasHealthy():
+		set(api.CONDITION_ENDPOINTS_VALID, true, "HealthyEndpoints", fmt.Sprintf("%d of %d endpoints healthy", len(this.healthy), total))
+	case total == 0:
+		set(api.CONDITION_ENDPOINTS_VALID, false, "NoEndpoints", "no endpoints configured")
+		fail("NoEndpoints", "no endpoints configured")
+	default:
+		set(api.CONDITION_ENDPOINTS_VALID, false, "NoHealthyEndpoints", fmt.Sprintf("none of %d endpoints healthy", total))
+		fail("NoHealthyEndpoints", "no healthy endpoints")
+	}
+
+	if this.checked {
+		switch {
+		case !this.resolves:
+			set(api.CONDITION_RESOLVABLE, false, "NotResolvable", "dns name not yet resolvable")
+			set(api.CONDITION_HEALTHY, false, "NotResolvable", "dns name not yet resolvable")
+			fail("NotResolvable", "dns name not yet resolvable")
+		case this.ishealthy:
+			set(api.CONDITION_RESOLVABLE, true, "Resolvable", "")
+			set(api.CONDITION_HEALTHY, true, api.STATE_HEALTHY, "")
+		default:
+			set(api.CONDITION_RESOLVABLE, true, "Resolvable", "")
+			set(api.CONDITION_HEALTHY, false, api.STATE_UNREACHABLE, "health check for dns name failed")
+			fail(api.STATE_UNREACHABLE, "health check for dns name failed")
+		}
+	} else {
+		fail(api.STATE_PENDING, "dns name not yet checked")
+	}
+	set(api.CONDITION_READY, ready, reason, msg)
+
+	if this.degradedset {
+		if this.degraded {
+			set(api.CONDITION_DEGRADED, true, "TooFewHealthyEndpoints", this.degradedmsg)
+		} else {
+			set(api.CONDITION_DEGRADED, false, "EnoughHealthyEndpoints", this.degradedmsg)
+		}
+	}
+	if this.namesset {
+		if len(this.nameproblems) > 0 {
+			set(api.CONDITION_ENDPOINT_NAMES, false, "InvalidEndpointNames", strings.Join(this.nameproblems, "; "))
+		} else {
+			set(api.CONDITION_ENDPOINT_NAMES, true, "Valid", "")
+		}
+	}
+	return conditions
+}
+
+// degradedEvent emits an event if the load balancer gets degraded or
+// recovers.
+func (this *DNSDone) degradedEvent() {
+	if !this.degradedset {
+		return
+	}
+	old := lbutils.GetCondition(this.dnslb.Status().Conditions, api.CONDITION_DEGRADED)
+	was := old != nil && old.Status == api.CONDITION_TRUE
+	switch {
+	case this.degraded && !was:
+		this.Eventf(corev1.EventTypeWarning, "degraded", "load balancer degraded: %s", this.degradedmsg)
+	case !this.degraded && was:
+		this.Eventf(corev1.EventTypeNormal, "degraded", "load balancer recovered: %s", this.degradedmsg)
+	}
+}
+
 func (this *DNSDone) _updateEndpointStatus(ep *lbutils.DNSLoadBalancerEndpointObject, healthy, active bool) {
 
 	state := api.STATE_INACTIVE
 	if active {
 		state = api.STATE_ACTIVE
 	}
-	mod, err := ep.Copy().UpdateState(state, "", &healthy)
+	reason := this.inactiveReason(healthy, active)
+	mod, err := ep.Copy().UpdateStateWith(lbutils.FIELD_MANAGER_DNS, state, "", &healthy, probeStatus(this.targets[ep.GetName()], healthy, reason))
 
 	if mod {
 		if err != nil {
@@ -188,6 +419,57 @@ func (this *DNSDone) _updateEndpointStatus(ep *lbutils.DNSLoadBalancerEndpointOb
 	}
 
 	metrics.ReportActiveEndpoint(this.dnslb.ObjectName(), ep.ObjectName(), active)
+	if t := this.targets[ep.GetName()]; t != nil && t.Cluster != nil {
+		c := t.Cluster
+		metrics.ReportEndpointLocation(this.dnslb.ObjectName(), ep.ObjectName(), c.ClusterId, t.Region, c.Zone, active)
+	}
+}
+
+// inactiveReason explains why an endpoint is not used.
+func (this *DNSDone) inactiveReason(healthy, active bool) string {
+	switch {
+	case active:
+		return ""
+	case !healthy:
+		return api.INACTIVE_UNHEALTHY
+	case this.frozen != nil:
+		return api.INACTIVE_FROZEN
+	case this.throttled != nil:
+		return api.INACTIVE_THROTTLED
+	default:
+		return api.INACTIVE_STANDBY
+	}
+}
+
+// probeStatus provides the update of the probe details in the status of
+// the endpoint of a target. The probes are kept in memory, the details are
+// written if the health of the endpoint changes and refreshed at most every
+// PROBE_STATUS_INTERVAL otherwise.
+func probeStatus(t *Target, healthy bool, reason string) func(status *api.DNSLoadBalancerEndpointStatus) {
+	return func(status *api.DNSLoadBalancerEndpointStatus) {
+		status.InactiveReason = reason
+		if t == nil || t.Probe == nil {
+			return
+		}
+		p := t.Probe
+		probed := metav1.NewTime(p.Time)
+		changed := status.LastTransitionTime == nil || status.Healthy != healthy
+		if !changed && status.LastProbeTime != nil && p.Time.Sub(status.LastProbeTime.Time) < PROBE_STATUS_INTERVAL {
+			return
+		}
+		if changed {
+			status.LastTransitionTime = &probed
+		}
+		status.LastProbeTime = &probed
+		status.LastProbeLatency = &metav1.Duration{Duration: p.Latency.Round(time.Millisecond)}
+		if !p.Healthy {
+			status.LastFailureReason = fmt.Sprintf("%s: %s", p.Reason, p.Error)
+		}
+		status.RecentProbes = nil
+		for _, r := range t.RecentProbes {
+			status.RecentProbes = append(status.RecentProbes, api.DNSLoadBalancerProbe{Time: metav1.NewTime(r.Time), Healthy: r.Healthy, Reason: r.Reason})
+		}
+	}
 }
 
 ///////////////////////////////////////
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// IsFrozen checks whether DNS changes are suspended for the load balancer.
+func (this *Watch) IsFrozen() bool {
+	return this.Freeze != ""
+}
+
+// freeze keeps the published targets while DNS changes are suspended.
+// The targets that would be published otherwise are reported in the status.
+func (this *Watch) freeze(done *DNSDone) {
+	desired := this.updated
+	this.updated = this.current.Targets
+	frozen := &api.DNSLoadBalancerFreeze{Reason: this.Freeze}
+	if !desired.Equals(this.current.Targets) {
+		frozen.Targets = sortedStrings(desired)
+		this.Infof("frozen (%s): keeping targets %s instead of %s", this.Freeze, this.current.Targets, desired)
+	}
+	done.SetFrozen(frozen, this.current.Targets)
+}
+
+// isPublished checks whether all DNS targets of a target are published.
+func isPublished(t *Target, published utils.StringSet) bool {
+	if len(t.Addresses) == 0 {
+		return published.Contains(t.GetHostName())
+	}
+	for _, a := range t.Addresses {
+		if !published.Contains(a) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// FALLBACK_ALL is reported as fallback for a region if none of its
+// configured fallback regions is healthy and all healthy targets are used.
+const FALLBACK_ALL = "*"
+
+// RegionTargets describes the selected targets for the region
+// specific DNS name of a load balancer of type Geo.
+type RegionTargets struct {
+	Region    string
+	DNSName   string
+	Targets   []*Target
+	Fallback  string
+	Published utils.StringSet
+}
+
+// RegionDNSName provides the region specific DNS name for a load balancer
+// DNS name. The region is mapped to a valid DNS label.
+func RegionDNSName(region, dnsname string) string {
+	return DNSLabel(region) + "." + dnsname
+}
+
+// DNSLabel maps a name to a valid DNS label.
+func DNSLabel(name string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '-'
+		}
+	}, strings.ToLower(name))
+	return strings.Trim(label, "-")
+}
+
+// handleRegions selects the targets for all known regions and determines
+// the DNS targets to publish for them.
+func (this *Watch) handleRegions(healthy []*Target) []*RegionTargets {
+	result := SelectRegions(this.Geo, this.dnsname, this.Targets, healthy)
+	for _, rt := range result {
+		rt.Published = this.targetSet(rt.Targets...)
+		if rt.Fallback != "" {
+			this.Infof("region %s of %s falls back to %s", rt.Region, this.dnsname, rt.Fallback)
+		}
+	}
+	return result
+}
+
+// SelectRegions selects the targets for all regions of the endpoints and
+// the Geo configuration of a load balancer. A region uses its own healthy
+// targets. If there is none, the first region of its fallback list with
+// healthy targets is used, and finally all healthy targets. The regions are
+// sorted by name.
+func SelectRegions(geo *api.DNSLoadBalancerGeo, dnsname string, targets, healthy []*Target) []*RegionTargets {
+	regions := utils.StringSet{}
+	byRegion := map[string][]*Target{}
+	for _, t := range targets {
+		if t.Region != "" {
+			regions.Add(t.Region)
+		}
+	}
+	for _, t := range healthy {
+		if t.Region != "" {
+			byRegion[t.Region] = append(byRegion[t.Region], t)
+		}
+	}
+	regions.AddAll(geo.Regions)
+	for r := range geo.Fallbacks {
+		regions.Add(r)
+	}
+
+	result := []*RegionTargets{}
+	for _, r := range sortedStrings(regions) {
+		rt := &RegionTargets{Region: r, DNSName: RegionDNSName(r, dnsname), Targets: byRegion[r]}
+		if len(rt.Targets) == 0 {
+			for _, f := range geo.Fallbacks[r] {
+				if len(byRegion[f]) > 0 {
+					rt.Targets = byRegion[f]
+					rt.Fallback = f
+					break
+				}
+			}
+		}
+		if len(rt.Targets) == 0 && len(healthy) > 0 {
+			rt.Targets = healthy
+			rt.Fallback = FALLBACK_ALL
+		}
+		result = append(result, rt)
+	}
+	return result
+}
+
+func sortedStrings(set utils.StringSet) []string {
+	list := set.AsArray()
+	sort.Strings(list)
+	return list
+}
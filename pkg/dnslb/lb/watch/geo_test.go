@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("regions", func() {
+	eu1 := &Target{IPAddress: "1.1.1.1", Region: "eu"}
+	eu2 := &Target{IPAddress: "1.1.1.2", Region: "eu"}
+	us := &Target{IPAddress: "2.2.2.2", Region: "us"}
+	ap := &Target{IPAddress: "3.3.3.3", Region: "ap"}
+	none := &Target{IPAddress: "4.4.4.4"}
+	all := []*Target{eu1, eu2, us, ap, none}
+
+	type selection struct {
+		region   string
+		targets  []*Target
+		fallback string
+	}
+
+	expect := func(result []*RegionTargets, expected ...selection) {
+		Expect(result).To(HaveLen(len(expected)))
+		for i, e := range expected {
+			Expect(result[i].Region).To(Equal(e.region))
+			Expect(result[i].DNSName).To(Equal(e.region + ".lb.example.org"))
+			Expect(result[i].Targets).To(Equal(e.targets))
+			Expect(result[i].Fallback).To(Equal(e.fallback))
+		}
+	}
+
+	It("uses the healthy targets of each region", func() {
+		expect(SelectRegions(&api.DNSLoadBalancerGeo{}, "lb.example.org", all, all),
+			selection{"ap", []*Target{ap}, ""},
+			selection{"eu", []*Target{eu1, eu2}, ""},
+			selection{"us", []*Target{us}, ""},
+		)
+	})
+
+	It("omits unhealthy targets of a region", func() {
+		expect(SelectRegions(&api.DNSLoadBalancerGeo{}, "lb.example.org", all, []*Target{eu2, us, ap}),
+			selection{"ap", []*Target{ap}, ""},
+			selection{"eu", []*Target{eu2}, ""},
+			selection{"us", []*Target{us}, ""},
+		)
+	})
+
+	Context("without healthy targets in a region", func() {
+		It("uses the first fallback region with healthy targets", func() {
+			geo := &api.DNSLoadBalancerGeo{Fallbacks: map[string][]string{"eu": {"ap", "us"}}}
+			expect(SelectRegions(geo, "lb.example.org", all, []*Target{us, none}),
+				selection{"ap", []*Target{us, none}, FALLBACK_ALL},
+				selection{"eu", []*Target{us}, "us"},
+				selection{"us", []*Target{us}, ""},
+			)
+		})
+
+		It("falls back to all healthy targets", func() {
+			expect(SelectRegions(&api.DNSLoadBalancerGeo{}, "lb.example.org", all, []*Target{us, none}),
+				selection{"ap", []*Target{us, none}, FALLBACK_ALL},
+				selection{"eu", []*Target{us, none}, FALLBACK_ALL},
+				selection{"us", []*Target{us}, ""},
+			)
+		})
+
+		It("selects no targets without healthy targets", func() {
+			geo := &api.DNSLoadBalancerGeo{Fallbacks: map[string][]string{"eu": {"us"}}}
+			expect(SelectRegions(geo, "lb.example.org", all, nil),
+				selection{"ap", nil, ""},
+				selection{"eu", nil, ""},
+				selection{"us", nil, ""},
+			)
+		})
+	})
+
+	It("publishes configured regions without endpoints", func() {
+		geo := &api.DNSLoadBalancerGeo{Regions: []string{"sa"}, Fallbacks: map[string][]string{"cn": {"ap"}}}
+		expect(SelectRegions(geo, "lb.example.org", all, all),
+			selection{"ap", []*Target{ap}, ""},
+			selection{"cn", []*Target{ap}, "ap"},
+			selection{"eu", []*Target{eu1, eu2}, ""},
+			selection{"sa", all, FALLBACK_ALL},
+			selection{"us", []*Target{us}, ""},
+		)
+	})
+
+	It("maps regions to dns labels", func() {
+		Expect(RegionDNSName("EU_West-1", "lb.example.org")).To(Equal("eu-west-1.lb.example.org"))
+	})
+})
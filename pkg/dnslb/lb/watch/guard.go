@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// GUARD_RECHECK_INTERVAL is the delay for the next evaluation of a load
+// balancer, if the removal of endpoints has been held back.
+const GUARD_RECHECK_INTERVAL = time.Minute
+
+// ValidateMaxUnavailable checks the maxUnavailable setting of a load balancer.
+// A value of zero is rejected, because it would keep all unhealthy
+// endpoints published as long as a healthy one is left.
+func ValidateMaxUnavailable(max *intstr.IntOrString) error {
+	v, err := intstr.GetValueFromIntOrPercent(max, 100, false)
+	if err != nil {
+		return fmt.Errorf("invalid maxUnavailable: %s", err)
+	}
+	if v < 1 {
+		return fmt.Errorf("invalid maxUnavailable: must be positive")
+	}
+	return nil
+}
+
+// HeldBack provides the number of unhealthy endpoints kept published by
+// the maxUnavailable guard.
+func (this *Watch) HeldBack() int {
+	return this.held
+}
+
+// guard limits the number of published endpoints removed by one evaluation
+// to maxUnavailable. Additional unhealthy endpoints are kept published and
+// are removed by subsequent evaluations. The guard does not apply if no
+// healthy endpoint is left, at all.
+func (this *Watch) guard(done *DNSDone, healthy []*Target) []*Target {
+	this.held = 0
+	if this.MaxUnavailable == nil || this.current == nil {
+		this.forgetHeld()
+		return healthy
+	}
+	held, removed := HoldBack(this.MaxUnavailable, this.Targets, this.current.Targets, healthy)
+	if len(held) == 0 {
+		this.forgetHeld()
+		return healthy
+	}
+	keys := []string{}
+	for _, t := range held {
+		t.Active = true
+		done.AddActiveTarget(t)
+		keys = append(keys, t.GetKey())
+	}
+	this.held = len(held)
+	msg := fmt.Sprintf("maxUnavailable %s: holding back removal of %d of %d unhealthy endpoints (%s)",
+		this.MaxUnavailable.String(), len(held), removed, strings.Join(keys, ", "))
+	this.Info(msg)
+	if this.Guarded == nil || this.Guarded.Changed(this.GetKey(), keys) {
+		done.Eventf(corev1.EventTypeWarning, "maxUnavailable", "%s", msg)
+	}
+	return append(append([]*Target{}, healthy...), held...)
+}
+
+func (this *Watch) forgetHeld() {
+	if this.Guarded != nil {
+		this.Guarded.Remove(this.GetKey())
+	}
+}
+
+// HeldBackEndpoints remembers the endpoints held back by the
+// maxUnavailable guard per load balancer in memory. It is used to report
+// the guard by an event only if the held back endpoints change, instead
+// of on every recheck.
+type HeldBackEndpoints struct {
+	lock sync.Mutex
+	held map[string]string
+}
+
+func NewHeldBackEndpoints() *HeldBackEndpoints {
+	return &HeldBackEndpoints{held: map[string]string{}}
+}
+
+// Changed records the keys of the endpoints held back for a load balancer
+// and reports whether they differ from the previously recorded ones.
+func (this *HeldBackEndpoints) Changed(lb string, keys []string) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, ",")
+	old, ok := this.held[lb]
+	this.held[lb] = key
+	return !ok || old != key
+}
+
+// Remove forgets the held back endpoints of a load balancer.
+func (this *HeldBackEndpoints) Remove(lb string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.held, lb)
+}
+
+// HoldBack determines the published targets to keep published although
+// they are not selected anymore, and the number of targets to remove.
+// Percentages of the published targets are rounded down, but at least one
+// target is removed per evaluation to make progress.
+func HoldBack(max *intstr.IntOrString, targets []*Target, published utils.StringSet, selected []*Target) ([]*Target, int) {
+	if len(selected) == 0 {
+		return nil, 0
+	}
+	keep := map[*Target]bool{}
+	for _, t := range selected {
+		keep[t] = true
+	}
+	count := 0
+	removed := []*Target{}
+	for _, t := range targets {
+		if !isPublished(t, published) {
+			continue
+		}
+		count++
+		if !keep[t] {
+			removed = append(removed, t)
+		}
+	}
+	n, _ := intstr.GetValueFromIntOrPercent(max, count, false)
+	if n < 1 {
+		n = 1
+	}
+	if len(removed) <= n {
+		return nil, len(removed)
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].GetKey() < removed[j].GetKey() })
+	return removed[n:], len(removed)
+}
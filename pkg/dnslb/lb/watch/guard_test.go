@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("removal guard", func() {
+	t1 := &Target{IPAddress: "10.0.0.1", Addresses: []string{"10.0.0.1"}}
+	t2 := &Target{IPAddress: "10.0.0.2", Addresses: []string{"10.0.0.2"}}
+	t3 := &Target{IPAddress: "10.0.0.3", Addresses: []string{"10.0.0.3"}}
+	t4 := &Target{IPAddress: "10.0.0.4", Addresses: []string{"10.0.0.4"}}
+	t5 := &Target{Name: "lb.example.org"}
+	all := []*Target{t1, t2, t3, t4, t5}
+	published := utils.NewStringSet("10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "lb.example.org")
+
+	It("removes unhealthy endpoints up to the limit", func() {
+		held, removed := HoldBack(count(2), all, published, []*Target{t1, t2, t5})
+		Expect(held).To(BeEmpty())
+		Expect(removed).To(Equal(2))
+	})
+
+	It("holds back removals exceeding the limit ordered by key", func() {
+		held, removed := HoldBack(count(1), all, published, []*Target{t1, t5})
+		Expect(held).To(Equal([]*Target{t3, t4}))
+		Expect(removed).To(Equal(3))
+	})
+
+	It("rounds percentages of the published endpoints down", func() {
+		held, removed := HoldBack(percent("50%"), all, published, []*Target{t5})
+		Expect(held).To(Equal([]*Target{t3, t4}))
+		Expect(removed).To(Equal(4))
+	})
+
+	It("removes at least one endpoint", func() {
+		held, removed := HoldBack(percent("10%"), all, published, []*Target{t1, t2})
+		Expect(held).To(Equal([]*Target{t4, t5}))
+		Expect(removed).To(Equal(3))
+	})
+
+	It("ignores endpoints not published", func() {
+		held, removed := HoldBack(count(1), all, utils.NewStringSet("10.0.0.1", "10.0.0.2"), []*Target{t1, t3, t4})
+		Expect(held).To(BeEmpty())
+		Expect(removed).To(Equal(1))
+	})
+
+	It("does not apply without selected endpoints", func() {
+		held, removed := HoldBack(count(1), all, published, nil)
+		Expect(held).To(BeEmpty())
+		Expect(removed).To(Equal(0))
+	})
+
+	It("rejects zero", func() {
+		Expect(ValidateMaxUnavailable(count(0))).NotTo(Succeed())
+		Expect(ValidateMaxUnavailable(percent("0%"))).NotTo(Succeed())
+		Expect(ValidateMaxUnavailable(count(1))).To(Succeed())
+		Expect(ValidateMaxUnavailable(percent("1%"))).To(Succeed())
+	})
+})
+
+var _ = Describe("held back endpoints", func() {
+	It("reports only changes of the held back endpoints", func() {
+		h := NewHeldBackEndpoints()
+		Expect(h.Changed("ns/lb", []string{"10.0.0.3", "10.0.0.4"})).To(BeTrue())
+		Expect(h.Changed("ns/lb", []string{"10.0.0.4", "10.0.0.3"})).To(BeFalse())
+		Expect(h.Changed("ns/other", []string{"10.0.0.3", "10.0.0.4"})).To(BeTrue())
+		Expect(h.Changed("ns/lb", []string{"10.0.0.4"})).To(BeTrue())
+		Expect(h.Changed("ns/lb", []string{"10.0.0.4"})).To(BeFalse())
+	})
+
+	It("reports the held back endpoints again after they have been removed", func() {
+		h := NewHeldBackEndpoints()
+		Expect(h.Changed("ns/lb", []string{"10.0.0.4"})).To(BeTrue())
+		h.Remove("ns/lb")
+		Expect(h.Changed("ns/lb", []string{"10.0.0.4"})).To(BeTrue())
+	})
+})
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"time"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// MIN_PROBE_INTERVAL is the minimum interval of health checks configured
+// by a policy.
+const MIN_PROBE_INTERVAL = 10 * time.Second
+
+// HealthCheck describes the health check used for the endpoints and the
+// DNS name of a load balancer. It is either taken from the load balancer
+// or from a referenced health check policy.
+type HealthCheck struct {
+	// Policy is the name of the policy, if the health check is taken from one
+	Policy     string
+	Type       api.DNSHealthCheckType
+	Port       int
+	Path       string
+	StatusCode int
+	Headers    map[string]string
+	// HealthyThreshold and UnhealthyThreshold are the numbers of
+	// consecutive probes required to change the health of an endpoint
+	HealthyThreshold   int
+	UnhealthyThreshold int
+	// Interval is the period between probes, if set
+	Interval   time.Duration
+	Timeout    time.Duration
+	Verify     bool
+	ServerName string
+}
+
+// NewHealthCheck provides the health check configured inline by a load
+// balancer.
+func NewHealthCheck(spec *api.DNSLoadBalancerHealthCheck) *HealthCheck {
+	return &HealthCheck{
+		Type:               api.HEALTHCHECK_HTTPS,
+		Path:               spec.Path,
+		StatusCode:         spec.StatusCode,
+		HealthyThreshold:   api.DEFAULT_HEALTHCHECK_THRESHOLD,
+		UnhealthyThreshold: api.DEFAULT_HEALTHCHECK_THRESHOLD,
+		Timeout:            DEFAULT_PROBE_TIMEOUT,
+	}
+}
+
+// NewPolicyHealthCheck provides the health check configured by a health
+// check policy.
+func NewPolicyHealthCheck(name string, spec *api.DNSHealthCheckPolicySpec) *HealthCheck {
+	defaulted := *spec
+	api.SetDefaultsDNSHealthCheckPolicySpec(&defaulted)
+	hc := &HealthCheck{
+		Policy:             name,
+		Type:               defaulted.Type,
+		Port:               defaulted.Port,
+		Path:               defaulted.Path,
+		StatusCode:         defaulted.StatusCode,
+		Headers:            defaulted.Headers,
+		HealthyThreshold:   defaulted.HealthyThreshold,
+		UnhealthyThreshold: defaulted.UnhealthyThreshold,
+		Timeout:            DEFAULT_PROBE_TIMEOUT,
+	}
+	if defaulted.Interval != nil {
+		hc.Interval = defaulted.Interval.Duration
+		if hc.Interval < MIN_PROBE_INTERVAL {
+			hc.Interval = MIN_PROBE_INTERVAL
+		}
+	}
+	if defaulted.Timeout != nil {
+		hc.Timeout = defaulted.Timeout.Duration
+	}
+	if tls := defaulted.TLS; tls != nil {
+		hc.Verify = tls.Verify
+		hc.ServerName = tls.ServerName
+	}
+	return hc
+}
+
+// Healthy determines the health of an endpoint from a probe, the recent
+// probes (oldest first) and the actual health of the endpoint. The health
+// only changes if the threshold of consecutive probes with the new outcome
+// is reached. Without recent probes the probe decides.
+func (this *HealthCheck) Healthy(probe *ProbeResult, recent []*ProbeResult, healthy bool) bool {
+	if len(recent) == 0 || probe.Healthy == healthy {
+		return probe.Healthy
+	}
+	threshold := this.HealthyThreshold
+	if healthy {
+		threshold = this.UnhealthyThreshold
+	}
+	count := 1
+	for i := len(recent) - 1; i >= 0 && count < threshold; i-- {
+		if recent[i].Healthy != probe.Healthy {
+			break
+		}
+		count++
+	}
+	if count < threshold {
+		return healthy
+	}
+	return probe.Healthy
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/validation"
+)
+
+var dnsNamePattern = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?\.)*[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// EndpointNameValues provides the values usable in an endpoint name
+// template. All values except the DNS name are mapped to valid DNS labels.
+func EndpointNameValues(t *Target, dnsname string) map[string]string {
+	values := map[string]string{
+		"dnsname":  dnsname,
+		"endpoint": "",
+		"cluster":  "",
+		"region":   DNSLabel(t.Region),
+		"zone":     "",
+		"provider": "",
+	}
+	if t.DNSEP != nil {
+		values["endpoint"] = DNSLabel(t.DNSEP.GetName())
+		cluster := t.DNSEP.GetLabel(api.LABEL_CLUSTER)
+		if cluster == "" && t.DNSEP.GetCluster() != nil {
+			cluster = t.DNSEP.GetCluster().GetId()
+		}
+		values["cluster"] = DNSLabel(cluster)
+	}
+	if t.Cluster != nil {
+		values["cluster"] = DNSLabel(t.Cluster.ClusterId)
+		values["zone"] = DNSLabel(t.Cluster.Zone)
+		values["provider"] = DNSLabel(t.Cluster.Provider)
+	}
+	return values
+}
+
+// EndpointName provides the DNS name of a target for an endpoint name
+// template. It must be a subdomain of the DNS name of the load balancer.
+func EndpointName(tmpl *template.Template, t *Target, dnsname string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, EndpointNameValues(t, dnsname)); err != nil {
+		return "", err
+	}
+	name := validation.NormalizeDNSName(buf.String())
+	if !dnsNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid dns name %q", name)
+	}
+	if !validation.IsSubDomain(name, dnsname) {
+		return "", fmt.Errorf("%q is not a subdomain of %q", name, validation.NormalizeDNSName(dnsname))
+	}
+	return name, nil
+}
+
+// EndpointNames determines the endpoint names of all targets for an
+// endpoint name template. If several targets map to the same name, the
+// first one (ordered by key) is used. Targets without valid name are
+// skipped, the reasons are returned as problems.
+func EndpointNames(tmplsrc string, dnsname string, targets []*Target) (map[string]*Target, []string) {
+	var problems []string
+	tmpl, err := template.New("endpoint").Option("missingkey=error").Parse(tmplsrc)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("invalid endpoint name template: %s", err)}
+	}
+	targets = append([]*Target{}, targets...)
+	sort.Slice(targets, func(i, j int) bool { return targets[i].GetKey() < targets[j].GetKey() })
+
+	names := map[string]*Target{}
+	for _, t := range targets {
+		name, err := EndpointName(tmpl, t, dnsname)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("cannot determine endpoint name for %s: %s", t.GetKey(), err))
+			continue
+		}
+		if names[name] != nil {
+			problems = append(problems, fmt.Sprintf("duplicate endpoint name %s for %s", name, t.GetKey()))
+			continue
+		}
+		names[name] = t
+	}
+	return names, problems
+}
+
+// handleEndpointNames determines the additional DNS names of all targets
+// for the endpoint name template, independent of their health. Problems
+// are reported by events and in the status of the load balancer.
+func (this *Watch) handleEndpointNames(done *DNSDone) map[string]utils.StringSet {
+	names, problems := EndpointNames(this.EndpointNameTemplate, this.dnsname, this.Targets)
+	for _, msg := range problems {
+		this.Warnf("%s", msg)
+		if this.DNSLB != nil {
+			this.DNSLB.Eventf(corev1.EventTypeWarning, "endpointNames", "%s", msg)
+		}
+	}
+	done.SetEndpointNameProblems(problems)
+
+	result := map[string]utils.StringSet{}
+	for name, t := range names {
+		set := this.targetSet(t)
+		if len(set) > 0 {
+			result[name] = set
+		}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("endpoint names", func() {
+	eu := &Target{IPAddress: "1.1.1.1", Region: "eu-west"}
+	us := &Target{IPAddress: "2.2.2.2", Region: "US_East"}
+	none := &Target{IPAddress: "3.3.3.3"}
+	all := []*Target{eu, us, none}
+
+	It("renders subdomains of the dns name", func() {
+		names, problems := EndpointNames("{{.region}}.{{.dnsname}}", "lb.example.org", all)
+		Expect(names).To(Equal(map[string]*Target{"eu-west.lb.example.org": eu, "us-east.lb.example.org": us}))
+		Expect(problems).To(HaveLen(1)) // empty region
+	})
+
+	It("accepts fixed subdomains with different case", func() {
+		names, problems := EndpointNames("{{.region}}.LB.example.org.", "lb.example.org.", all)
+		Expect(names).To(Equal(map[string]*Target{"eu-west.lb.example.org": eu, "us-east.lb.example.org": us}))
+		Expect(problems).To(HaveLen(1))
+	})
+
+	It("uses the first target for duplicate names", func() {
+		names, problems := EndpointNames("ep.{{.dnsname}}", "lb.example.org", all)
+		Expect(names).To(Equal(map[string]*Target{"ep.lb.example.org": eu}))
+		Expect(problems).To(HaveLen(2))
+	})
+
+	Context("names not below the dns name", func() {
+		It("skips names outside of the dns name", func() {
+			names, problems := EndpointNames("{{.region}}.example.org", "lb.example.org", all)
+			Expect(names).To(BeEmpty())
+			Expect(problems).To(HaveLen(3))
+		})
+
+		It("skips names with the dns name as suffix only", func() {
+			names, problems := EndpointNames("{{.region}}-{{.dnsname}}", "lb.example.org", all)
+			Expect(names).To(BeEmpty())
+			Expect(problems).To(HaveLen(3))
+		})
+
+		It("skips the dns name itself", func() {
+			names, problems := EndpointNames("{{.dnsname}}", "lb.example.org", all)
+			Expect(names).To(BeEmpty())
+			Expect(problems).To(HaveLen(3))
+		})
+	})
+
+	It("reports invalid templates", func() {
+		names, problems := EndpointNames("{{.region", "lb.example.org", all)
+		Expect(names).To(BeNil())
+		Expect(problems).To(ConsistOf(ContainSubstring("invalid endpoint name template")))
+	})
+})
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/healthstate"
+)
+
+const DEFAULT_PROBE_TIMEOUT = 10 * time.Second
+
+// PROBE_HISTORY is the number of recent probe outcomes kept for an
+// endpoint.
+const PROBE_HISTORY = 5
+
+// PROBE_TRANSITIONS is the maximum number of recent health transitions kept
+// for an endpoint to detect flapping.
+const PROBE_TRANSITIONS = 20
+
+// PROBE_STATUS_INTERVAL is the minimum period between two updates of the
+// probe details in the status of an endpoint with unchanged health.
+const PROBE_STATUS_INTERVAL = time.Minute
+
+// ProbeResult describes the outcome of a single health check.
+type ProbeResult struct {
+	Time       time.Time
+	Healthy    bool
+	StatusCode int
+	Latency    time.Duration
+	// Reason classifies a failed health check
+	Reason string
+	Error  string
+}
+
+func (this *ProbeResult) State() *healthstate.Probe {
+	if this == nil {
+		return nil
+	}
+	return &healthstate.Probe{
+		Time:          this.Time,
+		Healthy:       this.Healthy,
+		StatusCode:    this.StatusCode,
+		LatencyMillis: int64(this.Latency / time.Millisecond),
+		Error:         this.Error,
+	}
+}
+
+// Probe executes a health check for a host. If a DNS name is given, it is
+// used as host header for the request.
+func (this *Watch) Probe(hostname string, dns ...string) *ProbeResult {
+	hc := this.HealthCheck
+	if hc == nil {
+		hc = NewHealthCheck(&api.DNSLoadBalancerHealthCheck{})
+	}
+	if len(dns) == 0 {
+		dns = []string{hostname}
+	}
+	if hc.Type == api.HEALTHCHECK_TCP {
+		return this.probeTCP(hc, hostname)
+	}
+
+	serverName := hc.ServerName
+	if serverName == "" && hc.Verify {
+		serverName = dns[0]
+	}
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: !hc.Verify, ServerName: serverName},
+	}
+	client := &http.Client{Transport: tr, Timeout: hc.Timeout}
+	scheme := "https"
+	if hc.Type == api.HEALTHCHECK_HTTP {
+		scheme = "http"
+	}
+	host := hostname
+	if hc.Port != 0 {
+		host = net.JoinHostPort(hostname, strconv.Itoa(hc.Port))
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, host, hc.Path)
+
+	statusCode := hc.StatusCode
+	if statusCode == 0 {
+		statusCode = 200
+	}
+
+	result := &ProbeResult{Time: time.Now()}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		result.Reason = api.PROBE_CONNECTION_FAILED
+		result.Error = err.Error()
+		return result
+	}
+	if hostname != dns[0] {
+		req.Host = dns[0]
+	}
+	for k, v := range hc.Headers {
+		if http.CanonicalHeaderKey(k) == "Host" {
+			req.Host = v
+		} else {
+			req.Header.Set(k, v)
+		}
+	}
+
+	this.Debugf("health check for %q(%q)%s", hostname, dns[0], hc.Path)
+	resp, err := client.Do(req)
+	result.Latency = time.Now().Sub(result.Time)
+	if err != nil {
+		this.Debugf("request failed")
+		result.Reason = failureReason(err)
+		result.Error = err.Error()
+		return result
+	}
+
+	resp.Body.Close()
+	this.Debugf("found status %d", resp.StatusCode)
+	result.StatusCode = resp.StatusCode
+	result.Healthy = resp.StatusCode == statusCode
+	if !result.Healthy {
+		result.Reason = api.PROBE_STATUS_CODE
+		result.Error = fmt.Sprintf("unexpected status code %d (expected %d)", resp.StatusCode, statusCode)
+	}
+	return result
+}
+
+// probeTCP checks whether a connection to the port of a host can be
+// established.
+func (this *Watch) probeTCP(hc *HealthCheck, hostname string) *ProbeResult {
+	result := &ProbeResult{Time: time.Now()}
+	this.Debugf("tcp health check for %q:%d", hostname, hc.Port)
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(hostname, strconv.Itoa(hc.Port)), hc.Timeout)
+	result.Latency = time.Now().Sub(result.Time)
+	if err != nil {
+		this.Debugf("connect failed")
+		result.Reason = failureReason(err)
+		result.Error = err.Error()
+		return result
+	}
+	conn.Close()
+	result.Healthy = true
+	return result
+}
+
+// failureReason classifies the error of a failed request.
+func failureReason(err error) string {
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return api.PROBE_TIMEOUT
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "tls:") || strings.Contains(msg, "x509:") {
+		return api.PROBE_TLS_ERROR
+	}
+	return api.PROBE_CONNECTION_FAILED
+}
+
+// probeTarget executes the health check for a target and records the result.
+// The health of an endpoint considers the thresholds of the health check.
+func (this *Watch) probeTarget(target *Target) bool {
+	target.Probe = this.Probe(target.GetHostName(), this.dnsname)
+	target.Healthy = target.Probe.Healthy
+	target.RecentProbes = []*ProbeResult{target.Probe}
+	if this.Probes != nil {
+		target.Healthy, target.RecentProbes = this.Probes.Record(this.GetKey(), target.GetKey(), target.Probe, this.HealthCheck)
+	}
+	return target.Healthy
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Probe History
+////////////////////////////////////////////////////////////////////////////////
+
+type probes struct {
+	healthy bool
+	recent  []*ProbeResult
+	// transitions are the times of the probes differing from the previous
+	// probe, independent of the thresholds of the health check
+	transitions []time.Time
+}
+
+// ProbeHistory keeps the recent probes and the aggregated health of the
+// endpoints of all load balancers in memory. The probes are not recorded in
+// the status of the endpoints, it is only updated if the aggregated health
+// changes. After a restart the history starts empty.
+type ProbeHistory struct {
+	lock      sync.Mutex
+	endpoints map[string]map[string]*probes
+}
+
+func NewProbeHistory() *ProbeHistory {
+	return &ProbeHistory{endpoints: map[string]map[string]*probes{}}
+}
+
+// Record adds a probe of an endpoint of a load balancer to the history.
+// It provides the aggregated health of the endpoint according to the
+// thresholds of the health check and the recent probes (oldest first).
+func (this *ProbeHistory) Record(lb, ep string, probe *ProbeResult, hc *HealthCheck) (bool, []*ProbeResult) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	eps := this.endpoints[lb]
+	if eps == nil {
+		eps = map[string]*probes{}
+		this.endpoints[lb] = eps
+	}
+	p := eps[ep]
+	if p == nil {
+		p = &probes{healthy: probe.Healthy}
+		eps[ep] = p
+	}
+	if hc != nil {
+		p.healthy = hc.Healthy(probe, p.recent, p.healthy)
+	} else {
+		p.healthy = probe.Healthy
+	}
+	if n := len(p.recent); n > 0 && p.recent[n-1].Healthy != probe.Healthy {
+		p.transitions = append(p.transitions, probe.Time)
+		if n := len(p.transitions) - PROBE_TRANSITIONS; n > 0 {
+			p.transitions = p.transitions[n:]
+		}
+	}
+	p.recent = append(p.recent, probe)
+	if n := len(p.recent) - PROBE_HISTORY; n > 0 {
+		p.recent = p.recent[n:]
+	}
+	return p.healthy, append([]*ProbeResult{}, p.recent...)
+}
+
+// Transitions provides the times of the health transitions of an endpoint
+// of a load balancer after the given time (oldest first).
+func (this *ProbeHistory) Transitions(lb, ep string, since time.Time) []time.Time {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	p := this.endpoints[lb][ep]
+	if p == nil {
+		return nil
+	}
+	result := []time.Time{}
+	for _, t := range p.transitions {
+		if t.After(since) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Retain forgets the probes of all endpoints of a load balancer not
+// contained in the given set.
+func (this *ProbeHistory) Retain(lb string, eps utils.StringSet) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for ep := range this.endpoints[lb] {
+		if !eps.Contains(ep) {
+			delete(this.endpoints[lb], ep)
+		}
+	}
+}
+
+// Remove forgets the probes of all endpoints of a load balancer.
+func (this *ProbeHistory) Remove(lb string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.endpoints, lb)
+}
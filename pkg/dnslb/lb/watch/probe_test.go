@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("probe history", func() {
+	hc := &HealthCheck{HealthyThreshold: 2, UnhealthyThreshold: 3}
+	probe := func(healthy bool) *ProbeResult { return &ProbeResult{Healthy: healthy} }
+
+	It("takes the first probe", func() {
+		history := NewProbeHistory()
+		healthy, recent := history.Record("lb", "ep", probe(false), hc)
+		Expect(healthy).To(BeFalse())
+		Expect(recent).To(HaveLen(1))
+	})
+
+	It("changes the health only after the threshold", func() {
+		history := NewProbeHistory()
+		history.Record("lb", "ep", probe(true), hc)
+		for i := 1; i < 3; i++ {
+			healthy, _ := history.Record("lb", "ep", probe(false), hc)
+			Expect(healthy).To(BeTrue(), "probe %d", i)
+		}
+		healthy, _ := history.Record("lb", "ep", probe(false), hc)
+		Expect(healthy).To(BeFalse())
+		healthy, _ = history.Record("lb", "ep", probe(true), hc)
+		Expect(healthy).To(BeFalse())
+		healthy, _ = history.Record("lb", "ep", probe(true), hc)
+		Expect(healthy).To(BeTrue())
+	})
+
+	It("keeps only the recent probes", func() {
+		history := NewProbeHistory()
+		var recent []*ProbeResult
+		for i := 0; i < PROBE_HISTORY+2; i++ {
+			_, recent = history.Record("lb", "ep", probe(true), hc)
+		}
+		Expect(recent).To(HaveLen(PROBE_HISTORY))
+	})
+
+	It("forgets endpoints and load balancers", func() {
+		history := NewProbeHistory()
+		history.Record("lb", "ep", probe(true), hc)
+		history.Record("lb", "other", probe(true), hc)
+		history.Retain("lb", utils.NewStringSet("other"))
+		_, recent := history.Record("lb", "ep", probe(false), hc)
+		Expect(recent).To(HaveLen(1))
+		_, recent = history.Record("lb", "other", probe(true), hc)
+		Expect(recent).To(HaveLen(2))
+		history.Remove("lb")
+		_, recent = history.Record("lb", "other", probe(true), hc)
+		Expect(recent).To(HaveLen(1))
+	})
+
+	Context("health transitions", func() {
+		start := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+		at := func(m int, healthy bool) *ProbeResult {
+			return &ProbeResult{Time: start.Add(time.Duration(m) * time.Minute), Healthy: healthy}
+		}
+
+		It("records probes differing from the previous probe", func() {
+			history := NewProbeHistory()
+			history.Record("lb", "ep", at(0, true), hc)
+			history.Record("lb", "ep", at(1, false), hc)
+			history.Record("lb", "ep", at(2, false), hc)
+			history.Record("lb", "ep", at(3, true), hc)
+			Expect(history.Transitions("lb", "ep", start)).To(Equal([]time.Time{at(1, false).Time, at(3, true).Time}))
+		})
+
+		It("counts transitions not changing the health", func() {
+			history := NewProbeHistory()
+			history.Record("lb", "ep", at(0, true), hc)
+			history.Record("lb", "ep", at(1, false), hc)
+			healthy, _ := history.Record("lb", "ep", at(2, true), hc)
+			Expect(healthy).To(BeTrue())
+			Expect(history.Transitions("lb", "ep", start)).To(HaveLen(2))
+		})
+
+		It("provides only transitions after the given time", func() {
+			history := NewProbeHistory()
+			history.Record("lb", "ep", at(0, true), hc)
+			history.Record("lb", "ep", at(1, false), hc)
+			history.Record("lb", "ep", at(10, true), hc)
+			Expect(history.Transitions("lb", "ep", start.Add(5*time.Minute))).To(Equal([]time.Time{at(10, true).Time}))
+			Expect(history.Transitions("lb", "other", start)).To(BeEmpty())
+		})
+
+		It("keeps only the recent transitions", func() {
+			history := NewProbeHistory()
+			for i := 0; i < PROBE_TRANSITIONS+3; i++ {
+				history.Record("lb", "ep", at(i, i%2 == 0), hc)
+			}
+			Expect(history.Transitions("lb", "ep", start)).To(HaveLen(PROBE_TRANSITIONS))
+		})
+	})
+})
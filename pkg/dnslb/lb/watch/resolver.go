@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LOOKUP_TIMEOUT limits the duration of a single host name lookup.
+const LOOKUP_TIMEOUT = 5 * time.Second
+
+// NEGATIVE_CACHE_TTL is the maximum period failed lookups are cached.
+const NEGATIVE_CACHE_TTL = 15 * time.Second
+
+// LookupFunc resolves a host name to its addresses.
+type LookupFunc func(ctx context.Context, host string) ([]net.IP, error)
+
+// Resolver resolves host names of endpoints to their IPv4 and IPv6
+// addresses (CNAME flattening). Results are cached for the lookup
+// interval requested by the load balancer, failed lookups at most for
+// NEGATIVE_CACHE_TTL. Concurrent lookups of the same host name are
+// combined, lookups of different host names do not block each other.
+type Resolver struct {
+	lock     sync.Mutex
+	lookup   LookupFunc
+	timeout  time.Duration
+	cache    map[string]*resolution
+	inflight map[string]*lookupCall
+}
+
+type resolution struct {
+	addrs   []string
+	err     error
+	expires time.Time
+}
+
+// lookupCall is a lookup in progress. The result is available after done
+// has been closed.
+type lookupCall struct {
+	done   chan struct{}
+	result *resolution
+}
+
+func NewResolver() *Resolver {
+	return NewResolverWith(lookupIP, LOOKUP_TIMEOUT)
+}
+
+// NewResolverWith provides a resolver using the given lookup function and
+// timeout.
+func NewResolverWith(lookup LookupFunc, timeout time.Duration) *Resolver {
+	return &Resolver{
+		lookup:   lookup,
+		timeout:  timeout,
+		cache:    map[string]*resolution{},
+		inflight: map[string]*lookupCall{},
+	}
+}
+
+func lookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, len(addrs))
+	for i, a := range addrs {
+		ips[i] = a.IP
+	}
+	return ips, nil
+}
+
+// Lookup provides the sorted addresses for a host name. A cached result
+// is reused until it has expired.
+func (this *Resolver) Lookup(host string, interval time.Duration) ([]string, error) {
+	now := time.Now()
+	this.lock.Lock()
+	if r := this.cache[host]; r != nil && now.Before(r.expires) {
+		this.lock.Unlock()
+		return r.addrs, r.err
+	}
+	call := this.inflight[host]
+	if call == nil {
+		call = &lookupCall{done: make(chan struct{})}
+		this.inflight[host] = call
+		go this.resolve(host, interval, call)
+	}
+	this.lock.Unlock()
+
+	<-call.done
+	return call.result.addrs, call.result.err
+}
+
+// resolve executes a lookup outside of the lock and caches the result.
+func (this *Resolver) resolve(host string, interval time.Duration, call *lookupCall) {
+	ctx, cancel := context.WithTimeout(context.Background(), this.timeout)
+	defer cancel()
+
+	r := &resolution{}
+	ips, err := this.lookup(ctx, host)
+	if err != nil {
+		r.err = err
+		if interval > NEGATIVE_CACHE_TTL {
+			interval = NEGATIVE_CACHE_TTL
+		}
+	} else {
+		for _, ip := range ips {
+			r.addrs = append(r.addrs, ip.String())
+		}
+		sort.Strings(r.addrs)
+	}
+	now := time.Now()
+	r.expires = now.Add(interval)
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for h, c := range this.cache {
+		if now.After(c.expires.Add(10 * time.Minute)) {
+			delete(this.cache, h)
+		}
+	}
+	this.cache[host] = r
+	delete(this.inflight, host)
+	call.result = r
+	close(call.done)
+}
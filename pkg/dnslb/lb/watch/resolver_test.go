@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resolver", func() {
+	var calls int32
+	var release chan struct{}
+
+	lookup := func(ctx context.Context, host string) ([]net.IP, error) {
+		atomic.AddInt32(&calls, 1)
+		switch host {
+		case "slow.example.org":
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return []net.IP{net.ParseIP("10.0.0.2")}, nil
+		case "hanging.example.org":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		case "unknown.example.org":
+			return nil, fmt.Errorf("no such host")
+		}
+		return []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.0")}, nil
+	}
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&calls, 0)
+		release = make(chan struct{})
+	})
+
+	It("provides sorted addresses and caches them", func() {
+		r := NewResolverWith(lookup, time.Second)
+		addrs, err := r.Lookup("host.example.org", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addrs).To(Equal([]string{"10.0.0.0", "10.0.0.1"}))
+		_, err = r.Lookup("host.example.org", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	It("caches failed lookups only shortly", func() {
+		r := NewResolverWith(lookup, time.Second)
+		_, err := r.Lookup("unknown.example.org", time.Millisecond)
+		Expect(err).To(HaveOccurred())
+		time.Sleep(5 * time.Millisecond)
+		_, err = r.Lookup("unknown.example.org", time.Hour)
+		Expect(err).To(HaveOccurred())
+		_, err = r.Lookup("unknown.example.org", time.Hour)
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+	})
+
+	It("combines concurrent lookups of a host name", func() {
+		r := NewResolverWith(lookup, 10*time.Second)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				addrs, err := r.Lookup("slow.example.org", time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(addrs).To(Equal([]string{"10.0.0.2"}))
+			}()
+		}
+		Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(Equal(int32(1)))
+		close(release)
+		wg.Wait()
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+	})
+
+	It("does not block lookups of other host names", func() {
+		r := NewResolverWith(lookup, 10*time.Second)
+		go r.Lookup("slow.example.org", time.Minute)
+		Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(Equal(int32(1)))
+		addrs, err := r.Lookup("host.example.org", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addrs).To(HaveLen(2))
+		close(release)
+	})
+
+	It("limits the duration of a lookup", func() {
+		r := NewResolverWith(lookup, 50*time.Millisecond)
+		start := time.Now()
+		_, err := r.Lookup("hanging.example.org", time.Minute)
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+})
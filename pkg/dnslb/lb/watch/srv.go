@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+)
+
+// SRVTargets describes the SRV record and the per endpoint host records
+// for a load balancer with SRV configuration.
+type SRVTargets struct {
+	DNSName string
+	// Targets are the SRV record data in presentation format
+	Targets utils.StringSet
+	// Hosts maps the per endpoint host names to their targets
+	Hosts map[string]utils.StringSet
+}
+
+// SRVDNSName provides the DNS name of the SRV record for a load balancer
+// DNS name.
+func SRVDNSName(service, protocol, dnsname string) string {
+	return "_" + strings.ToLower(service) + "._" + strings.ToLower(protocol) + "." + dnsname
+}
+
+// EndpointDNSName provides the host name used for a target in SRV records.
+func EndpointDNSName(t *Target, dnsname string) string {
+	name := t.GetHostName()
+	if t.DNSEP != nil {
+		name = t.DNSEP.GetName()
+	}
+	return DNSLabel(name) + "." + dnsname
+}
+
+// handleSRV determines the SRV record for the selected targets.
+// Every target is published with its own host name.
+func (this *Watch) handleSRV(targets []*Target) *SRVTargets {
+	result := &SRVTargets{
+		DNSName: SRVDNSName(this.SRV.Service, this.SRV.Protocol, this.dnsname),
+		Targets: utils.StringSet{},
+		Hosts:   map[string]utils.StringSet{},
+	}
+	for _, t := range targets {
+		port := t.Port
+		if port == 0 {
+			port = this.SRV.Port
+		}
+		if port <= 0 || port > 65535 {
+			this.Warnf("no valid port for %s: omitted from SRV record", t)
+			continue
+		}
+		host := EndpointDNSName(t, this.dnsname)
+		set := this.targetSet(t)
+		if len(set) == 0 {
+			continue
+		}
+		result.Hosts[host] = set
+		result.Targets.Add(dnsmsg.SRVValue(uint16(t.Priority), uint16(t.Weight), uint16(port), host))
+	}
+	return result
+}
+
+// SRVRecords provides the SRV record and host records determined by Handle
+// for a load balancer with SRV configuration.
+func (this *Watch) SRVRecords() *SRVTargets {
+	return this.srv
+}
+
+// AdditionalNames provides the targets for all DNS names maintained for a
+// load balancer in addition to its DNS name and the SRV record: the region
+// specific names, the per endpoint host names of SRV records and the names
+// of the endpoint name template.
+func (this *Watch) AdditionalNames() map[string]utils.StringSet {
+	names := map[string]utils.StringSet{}
+	for n, t := range this.names {
+		names[n] = t
+	}
+	for _, r := range this.regions {
+		names[r.DNSName] = r.Published
+	}
+	if this.srv != nil {
+		for n, t := range this.srv.Hosts {
+			names[n] = t
+		}
+	}
+	return names
+}
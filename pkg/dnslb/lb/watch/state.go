@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/healthstate"
+)
+
+// Type provides the effective type of the load balancer.
+func (this *Watch) Type() string {
+	switch {
+	case this.Geo != nil:
+		return string(api.LBTYPE_GEO)
+	case this.Singleton:
+		return string(api.LBTYPE_EXCLUSIVE)
+	default:
+		return string(api.LBTYPE_BALANCED)
+	}
+}
+
+// reportState publishes the decisions of the actual handling to the
+// health state API.
+func (this *Watch) reportState(healthy bool) {
+	if this.DNSLB == nil {
+		return
+	}
+	lb := &healthstate.LoadBalancer{
+		Namespace:      this.DNSLB.GetNamespace(),
+		Name:           this.DNSLB.GetName(),
+		DNSName:        this.dnsname,
+		Type:           this.Type(),
+		Healthy:        healthy,
+		HealthyTargets: []string{},
+		ActiveTargets:  sortedStrings(this.updated),
+		Endpoints:      []healthstate.Endpoint{},
+		Updated:        time.Now(),
+	}
+	for _, t := range this.Targets {
+		ep := healthstate.Endpoint{
+			Host:      t.GetHostName(),
+			Addresses: t.Addresses,
+			Region:    t.Region,
+			Healthy:   t.Healthy,
+			Active:    t.Active,
+			LastProbe: t.Probe.State(),
+		}
+		if t.DNSEP != nil {
+			ep.Namespace = t.DNSEP.GetNamespace()
+			ep.Name = t.DNSEP.GetName()
+		}
+		if t.Healthy {
+			lb.HealthyTargets = append(lb.HealthyTargets, t.GetHostName())
+		}
+		lb.Endpoints = append(lb.Endpoints, ep)
+	}
+	for _, r := range this.regions {
+		lb.Regions = append(lb.Regions, healthstate.Region{Region: r.Region, DNSName: r.DNSName, Targets: sortedStrings(r.Published)})
+	}
+	healthstate.Default().Set(lb)
+}
+
+// RemoveState removes a load balancer from the health state API.
+func RemoveState(name resources.ObjectName) {
+	healthstate.Default().Remove(name.Namespace(), name.Name())
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+const DEFAULT_SETTLE_WINDOW = 5 * time.Minute
+
+// FLAPPING_TRANSITIONS is the number of health transitions of an endpoint
+// within the settle window considered as flapping. A single transition is
+// a regular health change handled by the change of the published targets.
+const FLAPPING_TRANSITIONS = 2
+
+// TTL provides the TTL to use for the published records.
+func (this *Watch) TTL() *int64 {
+	return this.ttl
+}
+
+// Settling provides the remaining period until the steady TTL is used
+// again, or zero if the steady TTL is already in use.
+func (this *Watch) Settling() time.Duration {
+	return this.settling
+}
+
+// flapping checks the health transitions of the probes of the endpoints
+// within the settle window. Transitions are counted independent of the
+// thresholds of the health check, so an endpoint may flap without ever
+// changing its health. It provides the time of the latest transition of a
+// flapping endpoint, or nil if no endpoint is flapping.
+func (this *Watch) flapping(window time.Duration, now time.Time) *metav1.Time {
+	if this.Probes == nil {
+		return nil
+	}
+	var latest *metav1.Time
+	for _, t := range this.Targets {
+		transitions := this.Probes.Transitions(this.GetKey(), t.GetKey(), now.Add(-window))
+		if len(transitions) < FLAPPING_TRANSITIONS {
+			continue
+		}
+		this.Infof("endpoint %s is flapping: %d health transitions within %s", t.GetKey(), len(transitions), window)
+		if last := metav1.NewTime(transitions[len(transitions)-1]); latest == nil || latest.Before(&last) {
+			latest = &last
+		}
+	}
+	return latest
+}
+
+// handleTTL determines the TTL for the published records. While DNS
+// changes are frozen the TTL in use is kept.
+func (this *Watch) handleTTL(changed bool) {
+	spec := this.DNSLB.Spec()
+	this.ttl = spec.TTL
+	this.settling = 0
+	this.lastChange = this.DNSLB.Status().LastChange
+	if this.IsFrozen() {
+		if ttl := this.DNSLB.Status().TTL; ttl != nil {
+			// keep the ttl in use
+			this.ttl = ttl
+			return
+		}
+	}
+	if this.AdaptiveTTL == nil {
+		this.lastChange = nil
+		return
+	}
+	now := time.Now()
+	if t := this.flapping(SettleWindow(this.AdaptiveTTL), now); t != nil {
+		if this.lastChange == nil || this.lastChange.Before(t) {
+			this.lastChange = t
+		}
+	}
+	this.ttl, this.lastChange, this.settling = SelectTTL(spec.TTL, this.AdaptiveTTL, this.lastChange, changed, now)
+}
+
+// SettleWindow provides the period targets have to be stable to use the
+// steady TTL again.
+func SettleWindow(adaptive *api.DNSLoadBalancerAdaptiveTTL) time.Duration {
+	if adaptive.SettleWindow != nil {
+		return adaptive.SettleWindow.Duration
+	}
+	return DEFAULT_SETTLE_WINDOW
+}
+
+// SelectTTL determines the TTL for an adaptive TTL configuration. After a
+// change of the published targets the transition TTL is used until the targets have been stable for the
+// settle window. It returns the TTL, the time of the last change and the
+// remaining settle period.
+func SelectTTL(ttl *int64, adaptive *api.DNSLoadBalancerAdaptiveTTL, lastChange *metav1.Time, changed bool, now time.Time) (*int64, *metav1.Time, time.Duration) {
+	if changed {
+		t := metav1.NewTime(now)
+		lastChange = &t
+	}
+	if adaptive.Steady != nil {
+		ttl = adaptive.Steady
+	}
+	if lastChange != nil {
+		if remaining := lastChange.Add(SettleWindow(adaptive)).Sub(now); remaining > 0 {
+			transition := adaptive.Transition
+			return &transition, lastChange, remaining
+		}
+	}
+	return ttl, lastChange, 0
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/lb/watch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("adaptive ttl", func() {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	ttl := func(v int64) *int64 { return &v }
+	ago := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+	window := &metav1.Duration{Duration: 10 * time.Minute}
+
+	It("uses the ttl of the load balancer without changes", func() {
+		result, lastChange, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Transition: 30}, nil, false, now)
+		Expect(result).To(Equal(ttl(300)))
+		Expect(lastChange).To(BeNil())
+		Expect(settling).To(BeZero())
+	})
+
+	It("uses the steady ttl without changes", func() {
+		result, _, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Steady: ttl(600), Transition: 30}, nil, false, now)
+		Expect(result).To(Equal(ttl(600)))
+		Expect(settling).To(BeZero())
+	})
+
+	It("keeps the default ttl without ttl of the load balancer", func() {
+		result, lastChange, settling := SelectTTL(nil, &api.DNSLoadBalancerAdaptiveTTL{Transition: 30}, ago(time.Hour), false, now)
+		Expect(result).To(BeNil())
+		Expect(lastChange).To(Equal(ago(time.Hour)))
+		Expect(settling).To(BeZero())
+	})
+
+	Context("after a change", func() {
+		It("uses the transition ttl", func() {
+			result, lastChange, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Transition: 30}, ago(time.Hour), true, now)
+			Expect(result).To(Equal(ttl(30)))
+			Expect(lastChange).To(Equal(ago(0)))
+			Expect(settling).To(Equal(DEFAULT_SETTLE_WINDOW))
+		})
+
+		It("keeps the transition ttl within the default settle window", func() {
+			result, lastChange, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Transition: 30}, ago(2*time.Minute), false, now)
+			Expect(result).To(Equal(ttl(30)))
+			Expect(lastChange).To(Equal(ago(2 * time.Minute)))
+			Expect(settling).To(Equal(3 * time.Minute))
+		})
+
+		It("keeps the transition ttl within a configured settle window", func() {
+			result, lastChange, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Transition: 30, SettleWindow: window}, ago(6*time.Minute), false, now)
+			Expect(result).To(Equal(ttl(30)))
+			Expect(lastChange).To(Equal(ago(6 * time.Minute)))
+			Expect(settling).To(Equal(4 * time.Minute))
+		})
+
+		It("returns to the steady ttl after the settle window", func() {
+			result, lastChange, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Steady: ttl(600), Transition: 30, SettleWindow: window}, ago(10*time.Minute), false, now)
+			Expect(result).To(Equal(ttl(600)))
+			Expect(lastChange).To(Equal(ago(10 * time.Minute)))
+			Expect(settling).To(BeZero())
+		})
+
+		It("restarts the settle window with every change", func() {
+			result, lastChange, settling := SelectTTL(ttl(300), &api.DNSLoadBalancerAdaptiveTTL{Transition: 30, SettleWindow: window}, ago(9*time.Minute), true, now)
+			Expect(result).To(Equal(ttl(30)))
+			Expect(lastChange).To(Equal(ago(0)))
+			Expect(settling).To(Equal(10 * time.Minute))
+		})
+	})
+
+	It("uses the configured settle window", func() {
+		Expect(SettleWindow(&api.DNSLoadBalancerAdaptiveTTL{})).To(Equal(DEFAULT_SETTLE_WINDOW))
+		Expect(SettleWindow(&api.DNSLoadBalancerAdaptiveTTL{SettleWindow: window})).To(Equal(10 * time.Minute))
+	})
+})
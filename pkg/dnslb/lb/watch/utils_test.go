@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// count provides an absolute number of endpoints as used by
+// maxUnavailable and minHealthy.
+func count(v int) *intstr.IntOrString {
+	i := intstr.FromInt(v)
+	return &i
+}
+
+// percent provides a percentage of endpoints as used by maxUnavailable and
+// minHealthy.
+func percent(v string) *intstr.IntOrString {
+	i := intstr.FromString(v)
+	return &i
+}
@@ -6,18 +6,20 @@ package watch
 
 import (
 	"bytes"
-	"crypto/tls"
 	"fmt"
 	"net"
-	"net/http"
+	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 
-	//api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	//api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 	lbutils "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
 	"github.com/gardener/dnslb-controller-manager/pkg/server/metrics"
 )
@@ -27,9 +29,25 @@ import (
 ////////////////////////////////////////////////////////////////////////////////
 
 type Target struct {
-	Name      string
+	Name string
+	// IPAddress is the first address of an ip address target, used for probing
 	IPAddress string
 	DNSEP     *lbutils.DNSLoadBalancerEndpointObject
+	Cluster   *api.DNSLoadBalancerClusterSpec
+	Region    string
+	// Addresses are all addresses of an ip address target or the resolved
+	// addresses of a host name target with CNAME flattening
+	Addresses []string
+	Port      int
+	Priority  int
+	Weight    int
+
+	// health state determined by Handle
+	Probe *ProbeResult
+	// RecentProbes are the probes kept in the probe history (oldest first)
+	RecentProbes []*ProbeResult
+	Healthy      bool
+	Active       bool
 }
 
 func (t *Target) GetHostName() string {
@@ -65,39 +83,135 @@ func (t *Target) String() string {
 // Watch Request
 ////////////////////////////////////////////////////////////////////////////////
 
+const DEFAULT_LOOKUP_INTERVAL = 2 * time.Minute
+const MIN_LOOKUP_INTERVAL = 30 * time.Second
+
 type Watch struct {
 	logger.LogContext
 	nxdomain net.IP
 
-	dnsname    string
-	HealthPath string
-	StatusCode int
-	Targets    []*Target
-	Singleton  bool
-	DNSLB      *lbutils.DNSLoadBalancerObject
+	dnsname string
+	// HealthCheck is the health check of the load balancer, it is replaced
+	// by the health check of a referenced policy
+	HealthCheck *HealthCheck
+	Targets     []*Target
+	Singleton   bool
+	Geo         *api.DNSLoadBalancerGeo
+	SRV         *api.DNSLoadBalancerSRV
+	// EndpointNameTemplate is used for additional per endpoint DNS names
+	EndpointNameTemplate string
+	AdaptiveTTL          *api.DNSLoadBalancerAdaptiveTTL
+	DNSLB                *lbutils.DNSLoadBalancerObject
+	// Freeze is the reason for suspending DNS changes, if set
+	Freeze string
+	// Limiter enforces the change budgets, if set
+	Limiter *ChangeLimiter
+	// Probes keeps the recent probes of the endpoints, if set
+	Probes *ProbeHistory
+	// Guarded remembers the endpoints held back by the guard, if set
+	Guarded      *HeldBackEndpoints
+	ChangeBudget *api.DNSLoadBalancerChangeBudget
+	// MaxUnavailable limits the removals of published endpoints
+	MaxUnavailable *intstr.IntOrString
+	// MinHealthy is the number of healthy endpoints below the load
+	// balancer is degraded
+	MinHealthy *intstr.IntOrString
+
+	// LookupInterval is set if CNAME flattening is enabled
+	LookupInterval time.Duration
+	resolver       *Resolver
 
 	current *source.DNSCurrentState
 	updated utils.StringSet
+	regions []*RegionTargets
+	srv     *SRVTargets
+	names   map[string]utils.StringSet
+
+	ttl        *int64
+	settling   time.Duration
+	lastChange *metav1.Time
+	deferred   time.Duration
+	held       int
 }
 
-func NewWatch(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, current *source.DNSCurrentState, nxdomain net.IP) (*Watch, error) {
+func NewWatch(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject, current *source.DNSCurrentState, nxdomain net.IP, resolver *Resolver) (*Watch, error) {
 	singleton, err := IsSingleton(logger, lb)
 	if err != nil {
 		return nil, err
 	}
-	spec := lb.Spec()
-	return &Watch{
+	// load balancers created before the schema defaults or without the
+	// mutating webhook may lack defaulted fields, the spec is defaulted
+	// locally without updating the object
+	spec := *lb.Spec()
+	api.SetDefaultsDNSLoadBalancerSpec(&spec)
+	w := &Watch{
 		LogContext: logger,
 
-		dnsname:    spec.DNSName,
-		HealthPath: spec.HealthPath,
-		Singleton:  singleton,
-		StatusCode: spec.StatusCode,
-		DNSLB:      lb.Copy(),
+		dnsname:     spec.DNSName,
+		HealthCheck: NewHealthCheck(&spec.HealthCheck),
+		Singleton:   singleton,
+		DNSLB:       lb.Copy(),
 
 		current:  current,
 		nxdomain: nxdomain,
-	}, nil
+		ttl:      spec.TTL,
+	}
+	if spec.Type == api.LBTYPE_GEO {
+		w.Geo = spec.Geo
+		if w.Geo == nil {
+			w.Geo = &api.DNSLoadBalancerGeo{}
+		}
+	}
+	w.EndpointNameTemplate = spec.EndpointNameTemplate
+	if b := spec.ChangeBudget; b != nil {
+		if b.MaxChanges <= 0 {
+			lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, "invalid change budget: maxChanges must be positive")
+			return nil, fmt.Errorf("invalid change budget: maxChanges must be positive")
+		}
+		w.ChangeBudget = b
+	}
+	// The guard does not apply to Exclusive load balancers: only one
+	// endpoint is published, so a failover replaces it instead of reducing
+	// the capacity, and holding it back would publish two endpoints.
+	if spec.MaxUnavailable != nil && !singleton {
+		if err := ValidateMaxUnavailable(spec.MaxUnavailable); err != nil {
+			lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, err.Error())
+			return nil, err
+		}
+		w.MaxUnavailable = spec.MaxUnavailable
+	}
+	if spec.MinHealthy != nil {
+		if err := ValidateMinHealthy(spec.MinHealthy); err != nil {
+			lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, err.Error())
+			return nil, err
+		}
+		w.MinHealthy = spec.MinHealthy
+	}
+	if spec.SRV != nil {
+		if spec.SRV.Service == "" || spec.SRV.Protocol == "" {
+			lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, "invalid srv configuration: service and protocol required")
+			return nil, fmt.Errorf("invalid srv configuration: service and protocol required")
+		}
+		w.SRV = spec.SRV
+	}
+	if a := spec.AdaptiveTTL; a != nil {
+		if a.Transition <= 0 {
+			lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, "invalid adaptive ttl: transition ttl required")
+			return nil, fmt.Errorf("invalid adaptive ttl: transition ttl required")
+		}
+		w.AdaptiveTTL = a
+	}
+	if f := spec.CNameFlattening; f != nil && f.Enabled {
+		w.resolver = resolver
+		w.LookupInterval = DEFAULT_LOOKUP_INTERVAL
+		if f.LookupInterval != nil {
+			w.LookupInterval = f.LookupInterval.Duration
+			if w.LookupInterval < MIN_LOOKUP_INTERVAL {
+				w.LookupInterval = MIN_LOOKUP_INTERVAL
+			}
+		}
+	}
+	return w, nil
 }
 
 func (this *Watch) String() string {
@@ -114,19 +228,36 @@ func (this *Watch) GetKey() string {
 	return this.DNSLB.ObjectName().String()
 }
 
-func (this *Watch) check(targets ...*Target) bool {
+// targetSet provides the DNS targets to publish for a set of targets.
+// With CNAME flattening host names are replaced by their addresses,
+// unresolvable host names are omitted.
+func (this *Watch) targetSet(targets ...*Target) utils.StringSet {
 	set := utils.StringSet{}
 	for _, t := range targets {
-		set.Add(t.GetHostName())
+		if t.Name == "" && len(t.Addresses) > 0 {
+			set.AddAll(t.Addresses)
+			continue
+		}
+		if t.Name == "" || this.resolver == nil {
+			set.Add(t.GetHostName())
+			continue
+		}
+		addrs, err := this.resolver.Lookup(t.Name, this.LookupInterval)
+		if err != nil || len(addrs) == 0 {
+			this.Warnf("cannot resolve %s for %s: %v", t.Name, this.dnsname, err)
+		}
+		t.Addresses = addrs
+		set.AddAll(addrs)
 	}
-	return set.Equals(this.current.Targets)
+	return set
+}
+
+func (this *Watch) check(targets ...*Target) bool {
+	return this.targetSet(targets...).Equals(this.current.Targets)
 }
 
 func (this *Watch) apply(targets ...*Target) bool {
-	set := utils.StringSet{}
-	for _, t := range targets {
-		set.Add(t.GetHostName())
-	}
+	set := this.targetSet(targets...)
 	this.updated = set
 	return !set.Equals(this.current.Targets)
 }
@@ -135,6 +266,12 @@ func (this *Watch) Get() utils.StringSet {
 	return this.updated
 }
 
+// Regions provides the selected targets for the region specific
+// DNS names of a load balancer of type Geo.
+func (this *Watch) Regions() []*RegionTargets {
+	return this.regions
+}
+
 func (this *Watch) GetDNSState(dnsname string) *source.DNSState {
 	return this.current.Names[dnsname]
 }
@@ -148,16 +285,21 @@ func (this *Watch) Handle() (utils.StringSet, source.DNSFeedback) {
 	if len(this.Targets) == 0 {
 		ctx.StateInfof(this.dnsname, "no endpoints configured for %s", this)
 		done.Error(true, fmt.Errorf("no endpoints configured"))
+		this.reportState(false)
 		return nil, nil
 	}
 
+	healthy := false
 	ips, err := net.LookupIP(this.dnsname)
 	if err != nil || bytes.Equal(ips[0], this.nxdomain) {
 		ctx = ctx.StateInfof(this.dnsname, "%s not yet resolvable", this)
+		done.SetResolvable(false)
 		done.SetHealthy(false)
 		metrics.ReportLB(this.GetKey(), this.dnsname, false)
 	} else {
+		done.SetResolvable(true)
 		if this.IsHealthy(this.dnsname) {
+			healthy = true
 			done.SetHealthy(true)
 			ctx = ctx.StateInfof(this.dnsname, "%s is healthy", this)
 			metrics.ReportLB(this.GetKey(), this.dnsname, true)
@@ -171,7 +313,7 @@ func (this *Watch) Handle() (utils.StringSet, source.DNSFeedback) {
 	if this.Singleton {
 		for _, target := range this.Targets {
 			active := this.check(target)
-			if this.IsHealthy(target.GetHostName(), this.dnsname) {
+			if this.probeTarget(target) {
 				metrics.ReportEndpoint(this.GetKey(), target.GetKey(), target.GetHostName(), true)
 				if len(healthyTargets) == 0 {
 					healthyTargets = append(healthyTargets, target)
@@ -194,14 +336,16 @@ func (this *Watch) Handle() (utils.StringSet, source.DNSFeedback) {
 			}
 		}
 		if len(healthyTargets) != 0 {
+			healthyTargets[0].Active = true
 			done.AddActiveTarget(healthyTargets[0])
 		}
 	} else {
 
 		for _, target := range this.Targets {
-			if this.IsHealthy(target.GetHostName(), this.dnsname) {
+			if this.probeTarget(target) {
 				metrics.ReportEndpoint(this.GetKey(), target.GetKey(), target.GetHostName(), true)
 				ctx.StateInfof(target.GetHostName(), "target %s is healthy", target.GetHostName())
+				target.Active = true
 				done.AddActiveTarget(target)
 				done.AddHealthyTarget(target)
 				healthyTargets = append(healthyTargets, target)
@@ -213,7 +357,36 @@ func (this *Watch) Handle() (utils.StringSet, source.DNSFeedback) {
 		}
 	}
 
-	mod := this.apply(healthyTargets...)
+	if this.Probes != nil {
+		keys := utils.StringSet{}
+		for _, target := range this.Targets {
+			keys.Add(target.GetKey())
+		}
+		this.Probes.Retain(this.GetKey(), keys)
+	}
+
+	this.handleDegraded(done)
+
+	if this.Geo != nil {
+		this.regions = this.handleRegions(healthyTargets)
+		done.SetRegions(this.regions)
+	}
+	if this.SRV != nil {
+		this.srv = this.handleSRV(healthyTargets)
+	}
+	if this.EndpointNameTemplate != "" {
+		this.names = this.handleEndpointNames(done)
+	}
+
+	mod := this.apply(this.guard(done, healthyTargets)...)
+	if this.IsFrozen() {
+		this.freeze(done)
+		mod = false
+	} else if this.throttle(done, mod) {
+		mod = false
+	}
+	this.handleTTL(mod)
+	done.SetTTL(this.ttl, this.lastChange)
 	if mod {
 		done.SetMessage(fmt.Sprintf("replacing targets for %s: %s -> %s", this.dnsname, this.current.Targets, this.updated))
 		this.Info(done.message)
@@ -224,72 +397,25 @@ func (this *Watch) Handle() (utils.StringSet, source.DNSFeedback) {
 		}
 	}
 
+	this.reportState(healthy)
 	return this.updated, done
 }
 
 func (this *Watch) IsHealthy(hostname string, dns ...string) bool {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-	client := &http.Client{Transport: tr}
-	url := fmt.Sprintf("https://%s%s", hostname, this.HealthPath)
-
-	statusCode := this.StatusCode
-	if statusCode == 0 {
-		statusCode = 200
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return false
-	}
-	if len(dns) > 0 {
-		req.Host = dns[0]
-	} else {
-		dns = []string{hostname}
-	}
-
-	this.Debugf("health check for %q(%q)%s", hostname, dns[0], this.HealthPath)
-	resp, err := client.Do(req)
-	if err != nil {
-		this.Debugf("request failed")
-		return false
-	}
-
-	resp.Body.Close()
-	this.Debugf("found status %d", resp.StatusCode)
-	return resp.StatusCode == statusCode
+	return this.Probe(hostname, dns...).Healthy
 }
 
 func IsSingleton(logger logger.LogContext, lb *lbutils.DNSLoadBalancerObject) (bool, error) {
-	singleton := false
-	spec := lb.Spec()
-	if spec.Singleton != nil {
-		singleton = *spec.Singleton
-		if spec.Type != "" {
-			lb.Copy().UpdateState(api.STATE_ERROR, "invalid load balancer type: singleton and type specified")
-			return false, fmt.Errorf("invalid load balancer type: singleton and type specified")
-		}
-	}
+	spec := *lb.Spec()
+	api.SetDefaultsDNSLoadBalancerSpec(&spec)
 	switch spec.Type {
 	case api.LBTYPE_EXCLUSIVE:
-		singleton = true
-	case api.LBTYPE_BALANCED:
-		singleton = false
-	case "": // fill-in default
-		newlb := lb.Copy()
-		if singleton {
-			newlb.Spec().Type = api.LBTYPE_EXCLUSIVE
-		} else {
-			newlb.Spec().Type = api.LBTYPE_BALANCED
-		}
-		newlb.Spec().Singleton = nil
-		logger.Infof("adapt lb type for %s/%s", newlb.GetNamespace(), newlb.GetName())
-		newlb.Update()
+		return true, nil
+	case api.LBTYPE_BALANCED, api.LBTYPE_GEO:
+		return false, nil
 	default:
 		msg := "invalid load balancer type"
-		lb.Copy().UpdateState(api.STATE_ERROR, msg)
-		return false, fmt.Errorf(msg)
+		lb.Copy().UpdateState(lbutils.FIELD_MANAGER_DNS, api.STATE_ERROR, msg)
+		return false, fmt.Errorf("%s", msg)
 	}
-	return singleton, nil
 }
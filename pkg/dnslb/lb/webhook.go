@@ -0,0 +1,496 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/scope"
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/validation"
+	lbv1beta1 "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	"github.com/gardener/dnslb-controller-manager/pkg/crds"
+	"github.com/gardener/dnslb-controller-manager/pkg/server/webhook"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+const WEBHOOK_PATH = "/validate"
+const WEBHOOK_NAME = "validation." + api.GroupName
+const WEBHOOK_ENDPOINT_NAME = "endpoints.validation." + api.GroupName
+const WEBHOOK_MUTATE_PATH = "/mutate"
+const WEBHOOK_MUTATE_NAME = "defaulting." + api.GroupName
+const WEBHOOK_CONVERT_PATH = "/convert"
+const WEBHOOK_CONFIGURATION = "dnslb-controller-manager"
+const WEBHOOK_CERT_VALIDITY = 10 * 365 * 24 * time.Hour
+
+// WEBHOOK_CERT_SECRET_SUFFIX is appended to the name of the webhook service
+// for the secret keeping the generated certificate.
+const WEBHOOK_CERT_SECRET_SUFFIX = "-cert"
+
+var KEY_WEBHOOK = reflect.TypeOf((*Webhook)(nil))
+
+// Webhook is the validating admission webhook for load balancers, endpoints
+// and health check policies, the defaulting admission webhook for load
+// balancers and the conversion webhook for the versions of the API group.
+// It is served by CONTROLLER_WEBHOOK on all replicas.
+type Webhook struct {
+	server  *webhook.Server
+	addr    string
+	service resources.ObjectName
+	cluster resources.Cluster
+	lbs     resources.Interface
+	// writers are the users and groups permitted to set the origin labels
+	// of endpoints, all users if empty
+	writers utils.StringSet
+	once    sync.Once
+}
+
+type sharedWebhookValue struct {
+	webhook *Webhook
+	err     error
+}
+
+// sharedWebhook provides the webhook shared by the reconcilers of a
+// controller. It is nil if no port is configured.
+func sharedWebhook(c controller.Interface) (*Webhook, error) {
+	shared := c.GetOrCreateSharedValue(KEY_WEBHOOK, func() interface{} {
+		w, err := NewWebhook(c)
+		return &sharedWebhookValue{w, err}
+	}).(*sharedWebhookValue)
+	return shared.webhook, shared.err
+}
+
+func NewWebhook(c controller.Interface) (*Webhook, error) {
+	port, _ := c.GetIntOption(OPT_WEBHOOK_PORT)
+	if port <= 0 {
+		return nil, nil
+	}
+	this := &Webhook{addr: ":" + strconv.Itoa(port), cluster: c.GetMainCluster()}
+	writers, _ := c.GetStringArrayOption(OPT_ENDPOINT_WRITERS)
+	if len(writers) > 0 {
+		this.writers = utils.NewStringSetByArray(writers)
+	}
+	service, err := webhookService(c)
+	if err != nil {
+		return nil, err
+	}
+	this.service = service
+	certs, err := webhookCertificates(c, service)
+	if err != nil {
+		return nil, err
+	}
+	this.server = webhook.NewServer(c, certs)
+	return this, nil
+}
+
+// webhookService provides the configured webhook service, or nil.
+func webhookService(c controller.Interface) (resources.ObjectName, error) {
+	name, _ := c.GetStringOption(OPT_WEBHOOK_SERVICE)
+	if name == "" {
+		return nil, nil
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid webhook service %q (expected <namespace>/<name>)", name)
+	}
+	return resources.NewObjectName(parts[0], parts[1]), nil
+}
+
+// webhookCertificates provides the certificates of the webhook server. They
+// are read from the configured directory. Otherwise a self-signed
+// certificate for the service is generated once and kept in a secret in
+// the namespace of the service, so all replicas serve the same certificate
+// and the CA bundle of the registered configurations stays valid.
+func webhookCertificates(c controller.Interface, service resources.ObjectName) (webhook.Certificates, error) {
+	if dir, _ := c.GetStringOption(OPT_WEBHOOK_CERT_DIR); dir != "" {
+		return webhook.NewFileCertificates(dir)
+	}
+	if service == nil {
+		return nil, fmt.Errorf("webhook requires option %s or %s", OPT_WEBHOOK_CERT_DIR, OPT_WEBHOOK_SERVICE)
+	}
+	secrets, err := c.GetMainCluster().Resources().GetByExample(&corev1.Secret{})
+	if err != nil {
+		return nil, err
+	}
+	name := resources.NewObjectName(service.Namespace(), service.Name()+WEBHOOK_CERT_SECRET_SUFFIX)
+	secret := &corev1.Secret{}
+	_, err = secrets.GetInto(name, secret)
+	if errors.IsNotFound(err) {
+		ns, n := service.Namespace(), service.Name()
+		certPEM, keyPEM, caPEM, err := webhook.GenerateCertificatePEMs([]string{
+			fmt.Sprintf("%s.%s.svc", n, ns),
+			fmt.Sprintf("%s.%s.svc.cluster.local", n, ns),
+			fmt.Sprintf("%s.%s", n, ns),
+			n,
+		}, WEBHOOK_CERT_VALIDITY)
+		if err != nil {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace(), Name: name.Name()},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				webhook.CERT_FILE: certPEM,
+				webhook.KEY_FILE:  keyPEM,
+				webhook.CA_FILE:   caPEM,
+			},
+		}
+		_, err = secrets.Create(secret)
+		if err == nil {
+			c.Infof("generated webhook certificate in secret %s", name)
+			return webhook.NewCertificates(certPEM, keyPEM, caPEM)
+		}
+		if !errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("cannot create webhook certificate secret %s: %s", name, err)
+		}
+		// created concurrently by another replica
+		secret = &corev1.Secret{}
+		_, err = secrets.GetInto(name, secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read webhook certificate secret %s: %s", name, err)
+	}
+	return webhook.NewCertificates(secret.Data[webhook.CERT_FILE], secret.Data[webhook.KEY_FILE], secret.Data[webhook.CA_FILE])
+}
+
+// webhookConversion provides the conversion webhook configuration for the
+// custom resource definitions. It is nil if no webhook service is
+// configured.
+func webhookConversion(c controller.Interface) (*crds.Conversion, error) {
+	service, err := webhookService(c)
+	if err != nil || service == nil {
+		return nil, err
+	}
+	certs, err := webhookCertificates(c, service)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := certs.CABundle()
+	if err != nil {
+		return nil, err
+	}
+	return &crds.Conversion{Service: service, Path: WEBHOOK_CONVERT_PATH, CABundle: bundle}, nil
+}
+
+// Start starts serving admission and conversion requests and registers the
+// webhook configurations. It is called once only.
+func (this *Webhook) Start(logger logger.LogContext) {
+	this.once.Do(func() {
+		lbs, err := this.cluster.Resources().GetByExample(&api.DNSLoadBalancer{})
+		if err != nil {
+			logger.Errorf("cannot start webhook server: %s", err)
+			return
+		}
+		this.lbs = lbs
+		this.server.Handle(WEBHOOK_PATH, webhook.ValidationHandler(logger, this.Validate))
+		this.server.Handle(WEBHOOK_MUTATE_PATH, webhook.MutationHandler(logger, this.Default))
+		this.server.Handle(WEBHOOK_CONVERT_PATH, webhook.ConversionHandler(logger, lbv1beta1.Convert))
+		if err := this.server.Start(this.addr); err != nil {
+			logger.Errorf("cannot start webhook server: %s", err)
+			return
+		}
+		if this.service != nil {
+			if err := this.register(logger); err != nil {
+				logger.Errorf("cannot register webhook configuration: %s", err)
+			}
+		}
+	})
+}
+
+// register creates or updates the webhook configurations for the service
+// with the CA bundle of the serving certificate. Endpoints are usually
+// written by the endpoint controllers of the source clusters, which should
+// not be blocked while the webhook is not available. Therefore their
+// validation is ignored in this case.
+func (this *Webhook) register(logger logger.LogContext) error {
+	bundle, err := this.server.Certificates().CABundle()
+	if err != nil {
+		return err
+	}
+	config := webhook.NewConfiguration(webhook.ValidatingGroupVersionKind, WEBHOOK_CONFIGURATION)
+	config.Webhooks = []webhook.Webhook{
+		this.webhook(WEBHOOK_NAME, WEBHOOK_PATH, bundle, webhook.FAILURE_POLICY_FAIL, api.LoadBalancerResourcePlural, api.HealthCheckPolicyResourcePlural),
+		this.webhook(WEBHOOK_ENDPOINT_NAME, WEBHOOK_PATH, bundle, webhook.FAILURE_POLICY_IGNORE, api.LoadBalancerEndpointResourcePlural),
+	}
+	if err := webhook.Register(this.cluster, config); err != nil {
+		return err
+	}
+	mconfig := webhook.NewConfiguration(webhook.MutatingGroupVersionKind, WEBHOOK_CONFIGURATION)
+	mconfig.Webhooks = []webhook.Webhook{
+		this.webhook(WEBHOOK_MUTATE_NAME, WEBHOOK_MUTATE_PATH, bundle, webhook.FAILURE_POLICY_FAIL, api.LoadBalancerResourcePlural),
+	}
+	if err := webhook.Register(this.cluster, mconfig); err != nil {
+		return err
+	}
+	logger.Infof("registered webhook configurations %s for service %s", WEBHOOK_CONFIGURATION, this.service)
+	return nil
+}
+
+func (this *Webhook) webhook(name, path string, bundle []byte, policy string, plurals ...string) webhook.Webhook {
+	return webhook.Webhook{
+		Name: name,
+		ClientConfig: webhook.ClientConfig{
+			Service: &webhook.ServiceReference{
+				Namespace: this.service.Namespace(),
+				Name:      this.service.Name(),
+				Path:      &path,
+			},
+			CABundle: bundle,
+		},
+		Rules: []webhook.Rule{
+			{
+				Operations:  []string{webhook.OPERATION_CREATE, webhook.OPERATION_UPDATE},
+				APIGroups:   []string{api.GroupName},
+				APIVersions: []string{"*"},
+				Resources:   plurals,
+			},
+		},
+		FailurePolicy:           policy,
+		SideEffects:             webhook.SIDE_EFFECTS_NONE,
+		AdmissionReviewVersions: webhook.AdmissionReviewVersions,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// WebhookReconciler serves the webhook of CONTROLLER_WEBHOOK. The
+// controller runs on all replicas without lease, so the webhook is
+// available independently of the DNS controllers.
+func WebhookReconciler(c controller.Interface) (reconcile.Interface, error) {
+	webhook, err := sharedWebhook(c)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		c.Infof("webhook not configured (option %s)", OPT_WEBHOOK_PORT)
+	}
+	return &webhook_reconciler{controller: c, webhook: webhook}, nil
+}
+
+type webhook_reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	webhook    *Webhook
+}
+
+func (this *webhook_reconciler) Start() {
+	if this.webhook != nil {
+		this.webhook.Start(this.controller)
+	}
+}
+
+// Reconcile does nothing, the load balancers are only watched to validate
+// the references and DNS names with the cache.
+func (this *webhook_reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	return reconcile.Succeeded(logger)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Default fills in the defaults of load balancers of admission requests.
+// For v1beta1 the deprecated singleton flag is migrated to the type.
+func (this *Webhook) Default(req *webhook.AdmissionRequest) ([]webhook.PatchOperation, error) {
+	if req.Operation != webhook.OPERATION_CREATE && req.Operation != webhook.OPERATION_UPDATE {
+		return nil, nil
+	}
+	if req.Kind.Kind != api.LoadBalancerResourceKind {
+		return nil, nil
+	}
+	if req.Kind.Version == lbv1beta1.Version {
+		lb := &lbv1beta1.DNSLoadBalancer{}
+		if err := json.Unmarshal(req.Object, lb); err != nil {
+			return nil, fmt.Errorf("cannot decode %s: %s", req.Kind.Kind, err)
+		}
+		return defaultingPatchV1beta1(&lb.Spec), nil
+	}
+	lb := &api.DNSLoadBalancer{}
+	if err := json.Unmarshal(req.Object, lb); err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %s", req.Kind.Kind, err)
+	}
+	return defaultingPatch(&lb.Spec), nil
+}
+
+// defaultingPatch provides the JSON patch defaulting a load balancer spec.
+func defaultingPatch(spec *api.DNSLoadBalancerSpec) []webhook.PatchOperation {
+	var patch []webhook.PatchOperation
+	defaulted := *spec
+	api.SetDefaultsDNSLoadBalancerSpec(&defaulted)
+	if defaulted.Type != spec.Type {
+		patch = append(patch, webhook.PatchOperation{Op: "add", Path: "/spec/type", Value: defaulted.Type})
+	}
+	if defaulted.HealthCheck != spec.HealthCheck {
+		patch = append(patch, webhook.PatchOperation{Op: "add", Path: "/spec/healthCheck", Value: defaulted.HealthCheck})
+	}
+	return patch
+}
+
+// defaultingPatchV1beta1 provides the JSON patch defaulting a v1beta1 load
+// balancer spec.
+func defaultingPatchV1beta1(spec *lbv1beta1.DNSLoadBalancerSpec) []webhook.PatchOperation {
+	var patch []webhook.PatchOperation
+	defaulted := *spec
+	lbv1beta1.SetDefaultsDNSLoadBalancerSpec(&defaulted)
+	if defaulted.Type != spec.Type {
+		patch = append(patch, webhook.PatchOperation{Op: "add", Path: "/spec/type", Value: defaulted.Type})
+	}
+	if spec.Singleton != nil && defaulted.Singleton == nil {
+		patch = append(patch, webhook.PatchOperation{Op: "remove", Path: "/spec/singleton"})
+	}
+	if defaulted.StatusCode != spec.StatusCode {
+		patch = append(patch, webhook.PatchOperation{Op: "add", Path: "/spec/statusCode", Value: defaulted.StatusCode})
+	}
+	if defaulted.HealthPath != spec.HealthPath {
+		patch = append(patch, webhook.PatchOperation{Op: "add", Path: "/spec/healthPath", Value: defaulted.HealthPath})
+	}
+	return patch
+}
+
+// Validate checks load balancers, endpoints and health check policies of
+// admission requests.
+// Updates without spec changes, like status updates, are always accepted.
+// Objects of older versions are converted to v1 for the validation.
+func (this *Webhook) Validate(req *webhook.AdmissionRequest) error {
+	if req.Operation != webhook.OPERATION_CREATE && req.Operation != webhook.OPERATION_UPDATE {
+		return nil
+	}
+	switch req.Kind.Kind {
+	case api.LoadBalancerResourceKind:
+		lb, old := &api.DNSLoadBalancer{}, &api.DNSLoadBalancer{}
+		if unchanged, err := decode(req, lb, old); err != nil || unchanged(&lb.Spec, &old.Spec) {
+			return err
+		}
+		return this.validateLoadBalancer(namespace(req, lb), lb).ToAggregate()
+	case api.LoadBalancerEndpointResourceKind:
+		ep, old := &api.DNSLoadBalancerEndpoint{}, &api.DNSLoadBalancerEndpoint{}
+		unchanged, err := decode(req, ep, old)
+		if err != nil {
+			return err
+		}
+		if err := this.validateOrigin(req, ep, old); err != nil {
+			return err
+		}
+		if unchanged(&ep.Spec, &old.Spec) {
+			return nil
+		}
+		return this.validateEndpoint(namespace(req, ep), ep).ToAggregate()
+	case api.HealthCheckPolicyResourceKind:
+		policy, old := &api.DNSHealthCheckPolicy{}, &api.DNSHealthCheckPolicy{}
+		if unchanged, err := decode(req, policy, old); err != nil || unchanged(&policy.Spec, &old.Spec) {
+			return err
+		}
+		return validation.ValidateHealthCheckPolicySpec(&policy.Spec, field.NewPath("spec")).ToAggregate()
+	}
+	return nil
+}
+
+func (this *Webhook) validateLoadBalancer(namespace string, lb *api.DNSLoadBalancer) field.ErrorList {
+	path := field.NewPath("spec")
+	allErrs := validation.ValidateLoadBalancerSpec(&lb.Spec, path)
+	if lb.Spec.DNSName == "" {
+		return allErrs
+	}
+	dnsname := validation.NormalizeDNSName(lb.Spec.DNSName)
+	list, err := this.lbs.ListCached(labels.Everything())
+	if err != nil {
+		return allErrs
+	}
+	for _, o := range list {
+		if o.GetNamespace() == namespace && o.GetName() == lb.GetName() {
+			continue
+		}
+		if validation.NormalizeDNSName(o.Data().(*api.DNSLoadBalancer).Spec.DNSName) == dnsname {
+			allErrs = append(allErrs, field.Duplicate(path.Child("dnsname"), fmt.Sprintf("%s (used by load balancer %s)", lb.Spec.DNSName, o.ObjectName())))
+			break
+		}
+	}
+	return allErrs
+}
+
+func (this *Webhook) validateEndpoint(namespace string, ep *api.DNSLoadBalancerEndpoint) field.ErrorList {
+	path := field.NewPath("spec")
+	allErrs := validation.ValidateLoadBalancerEndpointSpec(&ep.Spec, path)
+	if ep.Spec.LoadBalancer == "" {
+		return allErrs
+	}
+	_, err := this.lbs.GetCached(resources.NewObjectName(namespace, ep.Spec.LoadBalancer))
+	if errors.IsNotFound(err) {
+		allErrs = append(allErrs, field.NotFound(path.Child("loadbalancer"), ep.Spec.LoadBalancer))
+	}
+	return allErrs
+}
+
+// validateOrigin checks whether the user of a request may set the origin
+// labels of an endpoint used by the access control of load balancers.
+func (this *Webhook) validateOrigin(req *webhook.AdmissionRequest, ep, old *api.DNSLoadBalancerEndpoint) error {
+	if this.writers == nil {
+		return nil
+	}
+	var oldep metav1.Object
+	if req.Operation == webhook.OPERATION_UPDATE {
+		oldep = old
+	}
+	if !scope.OriginLabelsModified(ep, oldep) {
+		return nil
+	}
+	if this.writers.Contains(req.UserInfo.Username) {
+		return nil
+	}
+	for _, g := range req.UserInfo.Groups {
+		if this.writers.Contains(g) {
+			return nil
+		}
+	}
+	return fmt.Errorf("user %q is not permitted to set the origin labels %s and %s", req.UserInfo.Username, api.LABEL_CLUSTER, api.LABEL_NAMESPACE)
+}
+
+// decode decodes the object of an admission request, and for updates the
+// old object, with version v1. It provides a function to check whether the
+// specs are unchanged.
+func decode(req *webhook.AdmissionRequest, obj, old interface{}) (func(spec, oldspec interface{}) bool, error) {
+	if err := unmarshalV1(req, req.Object, obj); err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %s", req.Kind.Kind, err)
+	}
+	if req.Operation != webhook.OPERATION_UPDATE || len(req.OldObject) == 0 {
+		return func(interface{}, interface{}) bool { return false }, nil
+	}
+	if err := unmarshalV1(req, req.OldObject, old); err != nil {
+		return nil, fmt.Errorf("cannot decode old %s: %s", req.Kind.Kind, err)
+	}
+	return func(spec, oldspec interface{}) bool { return reflect.DeepEqual(spec, oldspec) }, nil
+}
+
+func unmarshalV1(req *webhook.AdmissionRequest, data []byte, obj interface{}) error {
+	if req.Kind.Version != api.Version {
+		var err error
+		if data, err = lbv1beta1.Convert(data, api.SchemeGroupVersion.String()); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, obj)
+}
+
+func namespace(req *webhook.AdmissionRequest, obj metav1.Object) string {
+	if obj.GetNamespace() != "" {
+		return obj.GetNamespace()
+	}
+	return req.Namespace
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+)
+
+const DEFAULT_TIMEOUT = 10 * time.Second
+
+// MAX_UNSIGNED_MESSAGES is the maximum number of consecutive unsigned
+// messages of a zone transfer (RFC 8945 5.3.1).
+const MAX_UNSIGNED_MESSAGES = 99
+
+// Client sends queries, zone transfers and dynamic updates via TCP to an
+// authoritative DNS server. If a key is configured all requests are signed
+// and all responses are verified.
+type Client struct {
+	Server  string
+	Key     *dnsmsg.TSIGKey
+	Timeout time.Duration
+}
+
+// RcodeError is reported for responses with an error code.
+type RcodeError struct {
+	Rcode int
+}
+
+func (this *RcodeError) Error() string {
+	return fmt.Sprintf("request failed: %s", dnsmsg.RcodeString(this.Rcode))
+}
+
+func NewClient(server string, key *dnsmsg.TSIGKey) *Client {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &Client{Server: server, Key: key, Timeout: DEFAULT_TIMEOUT}
+}
+
+// exchange sends a request and calls the handler for every response message
+// until the handler signals completion. If a key is configured, the first
+// response must be signed with the MAC of the request. Subsequent messages
+// of a zone transfer may be unsigned, but at most MAX_UNSIGNED_MESSAGES in
+// a row and the final message must be signed (RFC 8945 5.3.1).
+func (this *Client) exchange(m *dns.Msg, handler func(r *dns.Msg) (bool, error)) error {
+	timeout := this.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+	m.Id = dns.Id()
+	conn, err := dns.DialTimeout("tcp", this.Server, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	var mac string
+	if this.Key != nil {
+		var msg []byte
+		m.SetTsig(this.Key.Name, this.Key.Algorithm, dnsmsg.TSIG_FUDGE, time.Now().Unix())
+		msg, mac, err = dns.TsigGenerate(m, this.Key.Secret, "", false)
+		if err != nil {
+			return err
+		}
+		_, err = conn.Write(msg)
+	} else {
+		err = conn.WriteMsg(m)
+	}
+	if err != nil {
+		return err
+	}
+
+	unsigned := 0
+	for first := true; ; first = false {
+		raw, err := conn.ReadMsgHeader(nil)
+		if err != nil {
+			return err
+		}
+		r := &dns.Msg{}
+		if err := r.Unpack(raw); err != nil {
+			return err
+		}
+		if r.Id != m.Id || !r.Response {
+			return fmt.Errorf("unexpected response from %s", this.Server)
+		}
+		if this.Key != nil {
+			signed, err := this.verify(raw, r, mac, first)
+			if err == nil && !signed {
+				if unsigned++; first || unsigned > MAX_UNSIGNED_MESSAGES {
+					err = fmt.Errorf("response from %s not signed", this.Server)
+				}
+			}
+			if err != nil {
+				if r.Rcode != dns.RcodeSuccess {
+					return &RcodeError{r.Rcode}
+				}
+				return err
+			}
+			if signed {
+				mac, unsigned = r.IsTsig().MAC, 0
+			}
+		}
+		if r.Rcode != dns.RcodeSuccess {
+			return &RcodeError{r.Rcode}
+		}
+		done, err := handler(r)
+		if err != nil {
+			return err
+		}
+		if done {
+			if unsigned > 0 {
+				return fmt.Errorf("final response from %s not signed", this.Server)
+			}
+			return nil
+		}
+	}
+}
+
+// verify checks the signature of a signed response. The first response is
+// signed with the MAC of the request, subsequent messages of a zone transfer
+// with the MAC of the previous signed message and the timers only.
+// miekg/dns verifies single messages only, so a signed message following
+// unsigned ones is rejected if its digest covers them.
+func (this *Client) verify(raw []byte, r *dns.Msg, mac string, first bool) (bool, error) {
+	t := r.IsTsig()
+	if t == nil {
+		return false, nil
+	}
+	if !dnsmsg.EqualNames(t.Hdr.Name, this.Key.Name) || !dnsmsg.EqualNames(t.Algorithm, this.Key.Algorithm) {
+		return true, fmt.Errorf("TSIG verification failed: %s", dnsmsg.RcodeString(dns.RcodeBadKey))
+	}
+	if t.Error != dns.RcodeSuccess {
+		return true, fmt.Errorf("TSIG verification failed: %s", dnsmsg.RcodeString(int(t.Error)))
+	}
+	if err := dns.TsigVerify(raw, this.Key.Secret, mac, !first); err != nil {
+		return true, fmt.Errorf("TSIG verification failed: %s", err)
+	}
+	return true, nil
+}
+
+// Query provides the records of the given type for a DNS name. A non
+// existing name results in an empty list.
+func (this *Client) Query(name string, rtype uint16) ([]dns.RR, error) {
+	var result []dns.RR
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn(name), rtype)
+	m.RecursionDesired = false
+	err := this.exchange(m, func(r *dns.Msg) (bool, error) {
+		for _, rr := range r.Answer {
+			if rr.Header().Rrtype == rtype && dnsmsg.EqualNames(rr.Header().Name, name) {
+				result = append(result, rr)
+			}
+		}
+		return true, nil
+	})
+	if e, ok := err.(*RcodeError); ok && e.Rcode == dns.RcodeNameError {
+		return nil, nil
+	}
+	return result, err
+}
+
+// Transfer provides all records of a zone (AXFR).
+func (this *Client) Transfer(zone string) ([]dns.RR, error) {
+	var result []dns.RR
+	soa := 0
+	m := &dns.Msg{}
+	m.SetAxfr(dns.Fqdn(zone))
+	err := this.exchange(m, func(r *dns.Msg) (bool, error) {
+		for _, rr := range r.Answer {
+			if rr.Header().Rrtype == dns.TypeSOA {
+				soa++
+				if soa == 2 {
+					return true, nil
+				}
+				continue
+			}
+			result = append(result, rr)
+		}
+		if soa == 0 {
+			return false, fmt.Errorf("zone transfer for %s does not start with SOA", zone)
+		}
+		return false, nil
+	})
+	return result, err
+}
+
+// Update sends a dynamic update for a zone with the given prerequisites
+// and updates.
+func (this *Client) Update(zone string, prerequisites, updates []dns.RR) error {
+	m := &dns.Msg{}
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Answer = prerequisites
+	m.Ns = updates
+	return this.exchange(m, func(r *dns.Msg) (bool, error) { return true, nil })
+}
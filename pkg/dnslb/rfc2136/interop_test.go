@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136_test
+
+import (
+	"os"
+	"strings"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/rfc2136"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("interoperability", func() {
+	// The test against an external server (for example BIND) is executed
+	// only if configured by environment variables:
+	//   DNSLB_TEST_RFC2136_SERVER  address of the server
+	//   DNSLB_TEST_RFC2136_ZONE    zone permitting dynamic updates and AXFR
+	//   DNSLB_TEST_RFC2136_KEY     TSIG key as <algorithm>:<name>:<secret>
+	Describe("external server", func() {
+		It("should publish, update and delete records", func() {
+			server := os.Getenv("DNSLB_TEST_RFC2136_SERVER")
+			if server == "" {
+				Skip("DNSLB_TEST_RFC2136_SERVER not set")
+			}
+			domain := os.Getenv("DNSLB_TEST_RFC2136_ZONE")
+			parts := strings.SplitN(os.Getenv("DNSLB_TEST_RFC2136_KEY"), ":", 3)
+			Expect(parts).To(HaveLen(3), "DNSLB_TEST_RFC2136_KEY")
+			key, err := NewTSIGKey(parts[1], parts[0], parts[2])
+			Expect(err).NotTo(HaveOccurred())
+
+			zone := NewZone(NewClient(server, key), domain, "dnslb-test")
+			name := "dnslb-interop-test." + strings.TrimSuffix(domain, ".")
+			defer zone.Delete(name)
+
+			_, err = zone.Reconcile(name, utils.NewStringSet("192.0.2.1", "192.0.2.2"), 60, "default/test")
+			Expect(err).NotTo(HaveOccurred())
+			set, err := zone.Get(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Targets).To(Equal(utils.NewStringSet("192.0.2.1", "192.0.2.2")))
+			Expect(set.Owned).To(BeTrue())
+
+			mod, err := zone.Reconcile(name, utils.NewStringSet("2001:db8::1"), 60, "default/test")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mod).To(BeTrue())
+			set, err = zone.Get(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Targets).To(Equal(utils.NewStringSet("2001:db8::1")))
+
+			owned, err := zone.Owned()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owned).To(HaveKeyWithValue(name, "default/test"))
+
+			Expect(zone.Delete(name)).To(Succeed())
+			set, err = zone.Get(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Targets).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136_test
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/miekg/dns"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/rfc2136"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const secret = "c2VjcmV0LWtleS1mb3ItdGVzdGluZy1vbmx5"
+
+var _ = Describe("rfc2136", func() {
+	var key *TSIGKey
+
+	values := func(rrs []dns.RR) []string {
+		var result []string
+		for _, rr := range rrs {
+			result = append(result, Value(rr))
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		var err error
+		key, err = NewTSIGKey("dnslb-key", "hmac-sha256", secret)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("zone", func() {
+		var srv *server
+		var zone *Zone
+
+		BeforeEach(func() {
+			var err error
+			srv, err = newServer("example.org", key)
+			Expect(err).NotTo(HaveOccurred())
+			zone = NewZone(NewClient(srv.Addr(), key), "example.org", "test")
+		})
+
+		AfterEach(func() {
+			srv.Close()
+		})
+
+		It("should publish, update and delete records", func() {
+			mod, err := zone.Reconcile("lb.example.org", utils.NewStringSet("10.0.0.1", "10.0.0.2"), 60, "default/lb")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mod).To(BeTrue())
+			Expect(srv.Records("lb.example.org", dns.TypeA)).To(HaveLen(2))
+
+			set, err := zone.Get("lb.example.org")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Targets).To(Equal(utils.NewStringSet("10.0.0.1", "10.0.0.2")))
+			Expect(set.Owned).To(BeTrue())
+			Expect(set.Ref).To(Equal("default/lb"))
+
+			mod, err = zone.Reconcile("lb.example.org", utils.NewStringSet("10.0.0.1", "10.0.0.2"), 60, "default/lb")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mod).To(BeFalse())
+			Expect(srv.Updates()).To(Equal(1))
+
+			mod, err = zone.Reconcile("lb.example.org", utils.NewStringSet("2001:db8::1"), 60, "default/lb")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mod).To(BeTrue())
+			Expect(srv.Records("lb.example.org", dns.TypeA)).To(BeEmpty())
+			Expect(srv.Records("lb.example.org", dns.TypeAAAA)).To(HaveLen(1))
+
+			owned, err := zone.Owned()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owned).To(Equal(map[string]string{"lb.example.org": "default/lb"}))
+
+			Expect(zone.Delete("lb.example.org")).To(Succeed())
+			Expect(srv.Records("lb.example.org", dns.TypeAAAA)).To(BeEmpty())
+			Expect(srv.Records(OwnerName("lb.example.org"), dns.TypeTXT)).To(BeEmpty())
+		})
+
+		It("should publish host names as CNAME", func() {
+			_, err := zone.Reconcile("www.example.org", utils.NewStringSet("lb.other.org"), 60, "default/www")
+			Expect(err).NotTo(HaveOccurred())
+			set, err := zone.Get("www.example.org")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Targets).To(Equal(utils.NewStringSet("lb.other.org")))
+		})
+
+		It("should publish SRV records", func() {
+			targets := utils.NewStringSet(SRVValue(0, 1, 5060, "ep1.example.org"), SRVValue(0, 2, 5060, "ep2.example.org"))
+			_, err := zone.Reconcile("_sip._tcp.example.org", targets, 60, "default/sip")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(srv.Records("_sip._tcp.example.org", dns.TypeSRV)).To(HaveLen(2))
+			set, err := zone.Get("_sip._tcp.example.org")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Targets).To(Equal(targets))
+
+			mod, err := zone.Reconcile("_sip._tcp.example.org", targets, 60, "default/sip")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mod).To(BeFalse())
+		})
+
+		It("should reject mixed CNAME and address targets", func() {
+			_, err := zone.Reconcile("www.example.org", utils.NewStringSet("lb.other.org", "10.0.0.1"), 60, "default/www")
+			Expect(err).To(HaveOccurred())
+			Expect(srv.Updates()).To(Equal(0))
+		})
+
+		It("should not modify foreign records", func() {
+			srv.Add(NewA("foreign.example.org", 60, net.ParseIP("10.1.1.1")))
+			_, err := zone.Reconcile("foreign.example.org", utils.NewStringSet("10.0.0.1"), 60, "default/lb")
+			Expect(err).To(HaveOccurred())
+			Expect(zone.Delete("foreign.example.org")).To(Succeed())
+			Expect(srv.Records("foreign.example.org", dns.TypeA)).To(HaveLen(1))
+		})
+
+		It("should not publish names taken over concurrently", func() {
+			srv.AddBeforeUpdate(NewTXT(OwnerName("lb.example.org"), 60, HERITAGE+",owner=other,ref=default/other"))
+			_, err := zone.Reconcile("lb.example.org", utils.NewStringSet("10.0.0.1"), 60, "default/lb")
+			Expect(err).To(MatchError(ContainSubstring("ownership changed concurrently")))
+			Expect(srv.Records("lb.example.org", dns.TypeA)).To(BeEmpty())
+		})
+
+		It("should not modify names taken over concurrently", func() {
+			_, err := zone.Reconcile("lb.example.org", utils.NewStringSet("10.0.0.1"), 60, "default/lb")
+			Expect(err).NotTo(HaveOccurred())
+			srv.AddBeforeUpdate(NewTXT(OwnerName("lb.example.org"), 60, HERITAGE+",owner=other,ref=default/other"))
+			_, err = zone.Reconcile("lb.example.org", utils.NewStringSet("10.0.0.2"), 60, "default/lb")
+			Expect(err).To(MatchError(ContainSubstring("ownership changed concurrently")))
+			Expect(values(srv.Records("lb.example.org", dns.TypeA))).To(Equal([]string{"10.0.0.1"}))
+		})
+
+		It("should not delete names taken over concurrently", func() {
+			_, err := zone.Reconcile("lb.example.org", utils.NewStringSet("10.0.0.1"), 60, "default/lb")
+			Expect(err).NotTo(HaveOccurred())
+			srv.AddBeforeUpdate(NewTXT(OwnerName("lb.example.org"), 60, HERITAGE+",owner=other,ref=default/other"))
+			Expect(zone.Delete("lb.example.org")).To(MatchError(ContainSubstring("ownership changed concurrently")))
+			Expect(values(srv.Records("lb.example.org", dns.TypeA))).To(Equal([]string{"10.0.0.1"}))
+		})
+
+		It("should reject names outside of the zone", func() {
+			_, err := zone.Reconcile("lb.example.com", utils.NewStringSet("10.0.0.1"), 60, "default/lb")
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("zone transfer", func() {
+			BeforeEach(func() {
+				for i := 1; i <= 10; i++ {
+					_, err := zone.Reconcile(fmt.Sprintf("lb%d.example.org", i), utils.NewStringSet("10.0.0.1"), 60, "default/lb")
+					Expect(err).NotTo(HaveOccurred())
+				}
+				srv.Transfer.Size = 2
+			})
+
+			It("should verify all messages", func() {
+				owned, err := zone.Owned()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(owned).To(HaveLen(10))
+			})
+
+			It("should accept unsigned intermediate messages", func() {
+				srv.Transfer.Unsigned = func(i, n int) bool { return i > 0 && i < n-1 }
+				owned, err := zone.Owned()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(owned).To(HaveLen(10))
+			})
+
+			It("should reject an unsigned final message", func() {
+				srv.Transfer.Unsigned = func(i, n int) bool { return i == n-1 }
+				_, err := zone.Owned()
+				Expect(err).To(MatchError(ContainSubstring("not signed")))
+			})
+
+			It("should reject too many unsigned messages", func() {
+				for i := 11; i <= 2*MAX_UNSIGNED_MESSAGES; i++ {
+					srv.Add(NewA(fmt.Sprintf("lb%d.example.org", i), 60, net.ParseIP("10.0.0.1")))
+				}
+				srv.Transfer.Unsigned = func(i, n int) bool { return i > 0 && i < n-1 }
+				_, err := zone.Owned()
+				Expect(err).To(MatchError(ContainSubstring("not signed")))
+			})
+
+			It("should reject forged messages", func() {
+				srv.Transfer.Forged = func(i, n int) bool { return i == 2 }
+				_, err := zone.Owned()
+				Expect(err).To(MatchError(ContainSubstring("TSIG verification failed")))
+			})
+		})
+
+		It("should fail for wrong keys", func() {
+			other, _ := NewTSIGKey("dnslb-key", "hmac-sha256", "b3RoZXI=")
+			zone = NewZone(NewClient(srv.Addr(), other), "example.org", "test")
+			_, err := zone.Get("lb.example.org")
+			Expect(err).To(MatchError(ContainSubstring("NOTAUTH")))
+		})
+	})
+})
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136_test
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+)
+
+// server is an in-process authoritative DNS server for a single zone
+// supporting queries, zone transfers and signed dynamic updates.
+type server struct {
+	// Transfer configures the messages of zone transfers.
+	Transfer transfer
+
+	lock    sync.Mutex
+	zone    string
+	records []dns.RR
+	pending []dns.RR
+	updates int
+	server  *dns.Server
+}
+
+// transfer configures how records of zone transfers are split into
+// messages and how these messages are signed.
+type transfer struct {
+	// Size is the number of records per message, all records are sent
+	// with a single message if zero.
+	Size int
+	// Unsigned selects the messages sent without signature.
+	Unsigned func(i, n int) bool
+	// Forged selects the messages signed with a wrong key.
+	Forged func(i, n int) bool
+}
+
+func newServer(zone string, key *TSIGKey) (*server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &server{zone: dns.Fqdn(zone)}
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		Listener:          l,
+		Handler:           s,
+		TsigSecret:        map[string]string{key.Name: key.Secret},
+		NotifyStartedFunc: func() { close(started) },
+		// the default rejects dynamic updates
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go s.server.ActivateAndServe()
+	<-started
+	return s, nil
+}
+
+func (this *server) Addr() string {
+	return this.server.Listener.Addr().String()
+}
+
+func (this *server) Close() {
+	this.server.Shutdown()
+}
+
+func (this *server) Records(name string, rtype uint16) []dns.RR {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var result []dns.RR
+	for _, rr := range this.records {
+		if EqualNames(rr.Header().Name, name) && rr.Header().Rrtype == rtype {
+			result = append(result, rr)
+		}
+	}
+	return result
+}
+
+func (this *server) Add(rr dns.RR) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.records = append(this.records, rr)
+}
+
+// AddBeforeUpdate adds records right before the next update is processed
+// to simulate a concurrent modification.
+func (this *server) AddBeforeUpdate(rrs ...dns.RR) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.pending = append(this.pending, rrs...)
+}
+
+func (this *server) Updates() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.updates
+}
+
+func (this *server) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+
+	t := req.IsTsig()
+	if t == nil || w.TsigStatus() != nil {
+		resp.Rcode = dns.RcodeNotAuth
+		w.WriteMsg(resp)
+		return
+	}
+
+	this.lock.Lock()
+	q := req.Question[0]
+	switch {
+	case !dns.IsSubDomain(this.zone, q.Name):
+		resp.Rcode = dns.RcodeNotZone
+	case req.Opcode == dns.OpcodeUpdate:
+		this.records = append(this.records, this.pending...)
+		this.pending = nil
+		resp.Rcode = this.check(req.Answer)
+		if resp.Rcode == dns.RcodeSuccess {
+			this.update(req.Ns)
+		}
+	case q.Qtype == dns.TypeAXFR:
+		soa := NewSOA(this.zone, 60, "ns."+this.zone, "admin."+this.zone, uint32(this.updates))
+		rrs := append(append([]dns.RR{soa}, this.records...), soa)
+		this.lock.Unlock()
+		this.transfer(w, req, t, rrs)
+		return
+	default:
+		found := false
+		for _, rr := range this.records {
+			if EqualNames(rr.Header().Name, q.Name) {
+				found = true
+				if rr.Header().Rrtype == q.Qtype {
+					resp.Answer = append(resp.Answer, rr)
+				}
+			}
+		}
+		if !found {
+			resp.Rcode = dns.RcodeNameError
+		}
+	}
+	this.lock.Unlock()
+
+	resp.SetTsig(t.Hdr.Name, t.Algorithm, TSIG_FUDGE, time.Now().Unix())
+	w.WriteMsg(resp)
+}
+
+// check evaluates the prerequisites of an update (RFC 2136 3.2).
+func (this *server) check(prerequisites []dns.RR) int {
+	sets := map[string][]dns.RR{}
+	for _, p := range prerequisites {
+		h := p.Header()
+		var found []dns.RR
+		for _, rr := range this.records {
+			if EqualNames(rr.Header().Name, h.Name) && (h.Rrtype == dns.TypeANY || rr.Header().Rrtype == h.Rrtype) {
+				found = append(found, rr)
+			}
+		}
+		switch h.Class {
+		case dns.ClassANY:
+			if len(found) == 0 {
+				if h.Rrtype == dns.TypeANY {
+					return dns.RcodeNameError
+				}
+				return dns.RcodeNXRrset
+			}
+		case dns.ClassNONE:
+			if len(found) > 0 {
+				if h.Rrtype == dns.TypeANY {
+					return dns.RcodeYXDomain
+				}
+				return dns.RcodeYXRrset
+			}
+		default:
+			key := strings.ToLower(h.Name) + "/" + dns.Type(h.Rrtype).String()
+			sets[key] = append(sets[key], p)
+			if len(found) == 0 {
+				return dns.RcodeNXRrset
+			}
+		}
+	}
+	for _, set := range sets {
+		h := set[0].Header()
+		count := 0
+		for _, rr := range this.records {
+			if EqualNames(rr.Header().Name, h.Name) && rr.Header().Rrtype == h.Rrtype {
+				count++
+				matched := false
+				for _, p := range set {
+					matched = matched || dns.IsDuplicate(rr, p)
+				}
+				if !matched {
+					return dns.RcodeNXRrset
+				}
+			}
+		}
+		if count != len(set) {
+			return dns.RcodeNXRrset
+		}
+	}
+	return dns.RcodeSuccess
+}
+
+func (this *server) update(updates []dns.RR) {
+	this.updates++
+	for _, u := range updates {
+		h := u.Header()
+		var kept []dns.RR
+		for _, rr := range this.records {
+			match := strings.EqualFold(rr.Header().Name, h.Name) && (h.Rrtype == dns.TypeANY || rr.Header().Rrtype == h.Rrtype)
+			if h.Class != dns.ClassANY {
+				c := dns.Copy(u)
+				c.Header().Class = dns.ClassINET
+				match = match && dns.IsDuplicate(rr, c)
+			}
+			if !match {
+				kept = append(kept, rr)
+			}
+		}
+		if h.Class == dns.ClassINET {
+			kept = append(kept, u)
+		}
+		this.records = kept
+	}
+}
+
+func (this *server) transfer(w dns.ResponseWriter, req *dns.Msg, t *dns.TSIG, rrs []dns.RR) {
+	size := this.Transfer.Size
+	if size <= 0 {
+		size = len(rrs)
+	}
+	n := (len(rrs) + size - 1) / size
+	for i := 0; i < n; i++ {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		resp.Authoritative = true
+		end := (i + 1) * size
+		if end > len(rrs) {
+			end = len(rrs)
+		}
+		resp.Answer = rrs[i*size : end]
+		if this.Transfer.Unsigned != nil && this.Transfer.Unsigned(i, n) {
+			w.WriteMsg(resp)
+			continue
+		}
+		resp.SetTsig(t.Hdr.Name, t.Algorithm, TSIG_FUDGE, time.Now().Unix())
+		if this.Transfer.Forged != nil && this.Transfer.Forged(i, n) {
+			raw, _, _ := dns.TsigGenerate(resp, "b3RoZXI=", t.MAC, i > 0)
+			w.Write(raw)
+			continue
+		}
+		w.TsigTimersOnly(i > 0)
+		w.WriteMsg(resp)
+	}
+}
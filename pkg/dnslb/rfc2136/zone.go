@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/dnslb/dnsmsg"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// OWNER_PREFIX is the label prepended to a DNS name to get the name of the
+// TXT record describing the owner of the records of this name.
+const OWNER_PREFIX = "_dnslb"
+
+const HERITAGE = "heritage=dnslb"
+
+// RECORD_TYPES are the types of the records published for DNS names.
+var RECORD_TYPES = []uint16{dns.TypeA, dns.TypeAAAA, dns.TypeCNAME, dns.TypeSRV}
+
+// Zone maintains the records for DNS names of a zone. Every name is
+// accompanied by an ownership TXT record. Records of names without
+// ownership record or owned by others are never modified. Updates are
+// guarded by prerequisites on the ownership record, so that changes made
+// concurrently by other owners are not overwritten.
+type Zone struct {
+	client *Client
+	zone   string
+	owner  string
+}
+
+// RecordSet describes the published targets of a DNS name.
+type RecordSet struct {
+	Targets utils.StringSet
+	TTL     int64
+	// Ref is the reference stored in the ownership record
+	Ref string
+	// Owned is set if the records are owned by this zone owner
+	Owned bool
+
+	owner []dns.RR
+}
+
+func NewZone(client *Client, zone, owner string) *Zone {
+	return &Zone{client: client, zone: dns.Fqdn(zone), owner: owner}
+}
+
+func (this *Zone) Name() string {
+	return this.zone
+}
+
+func OwnerName(name string) string {
+	return OWNER_PREFIX + "." + dns.Fqdn(name)
+}
+
+func (this *Zone) ownerText(ref string) string {
+	return fmt.Sprintf("%s,owner=%s,ref=%s", HERITAGE, this.owner, ref)
+}
+
+// parseOwner provides owner and reference of an ownership record text.
+func parseOwner(text string) (string, string, bool) {
+	owner, ref := "", ""
+	fields := strings.Split(text, ",")
+	if len(fields) == 0 || fields[0] != HERITAGE {
+		return "", "", false
+	}
+	for _, f := range fields[1:] {
+		switch {
+		case strings.HasPrefix(f, "owner="):
+			owner = f[len("owner="):]
+		case strings.HasPrefix(f, "ref="):
+			ref = f[len("ref="):]
+		}
+	}
+	return owner, ref, true
+}
+
+func (this *Zone) check(name string) error {
+	if !dns.IsSubDomain(this.zone, dns.Fqdn(name)) || dnsmsg.EqualNames(name, this.zone) {
+		return fmt.Errorf("dns name %s is not part of zone %s", name, this.zone)
+	}
+	return nil
+}
+
+// Get provides the currently published targets for a DNS name.
+func (this *Zone) Get(name string) (*RecordSet, error) {
+	if err := this.check(name); err != nil {
+		return nil, err
+	}
+	set := &RecordSet{Targets: utils.StringSet{}}
+	for _, t := range RECORD_TYPES {
+		rrs, err := this.client.Query(name, t)
+		if err != nil {
+			return nil, fmt.Errorf("cannot query %s: %s", name, err)
+		}
+		for _, rr := range rrs {
+			set.Targets.Add(strings.TrimSuffix(dnsmsg.Value(rr), "."))
+			set.TTL = int64(rr.Header().Ttl)
+		}
+	}
+	rrs, err := this.client.Query(OwnerName(name), dns.TypeTXT)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query owner of %s: %s", name, err)
+	}
+	for _, rr := range rrs {
+		owner, ref, ok := parseOwner(dnsmsg.Value(rr))
+		if ok {
+			set.Ref = ref
+			set.Owned = owner == this.owner
+		}
+	}
+	set.owner = rrs
+	return set, nil
+}
+
+// prerequisites assure that the ownership of a DNS name did not change
+// since the record set was read. Owned names require the unchanged
+// ownership record, other names must neither have an ownership record
+// nor records of the published types.
+func (this *Zone) prerequisites(name string, current *RecordSet) []dns.RR {
+	var rrs []dns.RR
+	if current.Owned {
+		for _, rr := range current.owner {
+			rr = dns.Copy(rr)
+			rr.Header().Ttl = 0
+			rrs = append(rrs, rr)
+		}
+		return rrs
+	}
+	for _, t := range RECORD_TYPES {
+		rrs = append(rrs, &dns.ANY{Hdr: dns.RR_Header{Name: dns.Fqdn(name), Rrtype: t, Class: dns.ClassNONE}})
+	}
+	return append(rrs, &dns.ANY{Hdr: dns.RR_Header{Name: OwnerName(name), Rrtype: dns.TypeTXT, Class: dns.ClassNONE}})
+}
+
+// deletions provides the updates deleting the records of a DNS name
+// together with its ownership record.
+func deletions(name string) []dns.RR {
+	var rrs []dns.RR
+	for _, t := range RECORD_TYPES {
+		rrs = append(rrs, dnsmsg.DeleteRRSet(name, t))
+	}
+	return append(rrs, dnsmsg.DeleteRRSet(OwnerName(name), dns.TypeTXT))
+}
+
+// update sends a dynamic update for a DNS name. Failed prerequisites
+// are reported as concurrent change of the ownership.
+func (this *Zone) update(name string, prerequisites, updates []dns.RR) error {
+	err := this.client.Update(this.zone, prerequisites, updates)
+	if e, ok := err.(*RcodeError); ok && (e.Rcode == dns.RcodeYXRrset || e.Rcode == dns.RcodeNXRrset) {
+		return fmt.Errorf("ownership changed concurrently")
+	}
+	return err
+}
+
+// records provides the records for a set of targets. Addresses are
+// published as A or AAAA records, SRV data as SRV records and a host
+// name as CNAME record.
+func records(name string, targets utils.StringSet, ttl uint32) ([]dns.RR, error) {
+	var rrs []dns.RR
+	cnames := 0
+	for _, t := range targets.AsArray() {
+		if ip := net.ParseIP(t); ip != nil {
+			if ip.To4() != nil {
+				rrs = append(rrs, dnsmsg.NewA(name, ttl, ip))
+			} else {
+				rrs = append(rrs, dnsmsg.NewAAAA(name, ttl, ip))
+			}
+		} else if priority, weight, port, target, ok := dnsmsg.ParseSRV(t); ok {
+			rrs = append(rrs, dnsmsg.NewSRV(name, ttl, priority, weight, port, target))
+		} else {
+			cnames++
+			rrs = append(rrs, dnsmsg.NewCNAME(name, ttl, t))
+		}
+	}
+	if cnames > 0 && len(rrs) > 1 {
+		return nil, fmt.Errorf("a CNAME record for %s cannot be combined with other targets (enable cname flattening)", name)
+	}
+	return rrs, nil
+}
+
+// Reconcile assures the published targets for a DNS name. An empty target
+// set removes the records. The reference is stored in the ownership record.
+// It reports whether an update was required.
+func (this *Zone) Reconcile(name string, targets utils.StringSet, ttl int64, ref string) (bool, error) {
+	current, err := this.Get(name)
+	if err != nil {
+		return false, err
+	}
+	if !current.Owned && (len(current.Targets) > 0 || current.Ref != "") {
+		return false, fmt.Errorf("dns name %s is not owned by %q", name, this.owner)
+	}
+	if len(targets) == 0 {
+		if !current.Owned {
+			return false, nil
+		}
+		return true, this.delete(name, current)
+	}
+	if current.Owned && current.Targets.Equals(targets) && current.TTL == ttl && current.Ref == ref {
+		return false, nil
+	}
+	rrs, err := records(name, targets, uint32(ttl))
+	if err != nil {
+		return false, err
+	}
+	updates := append(deletions(name), rrs...)
+	updates = append(updates, dnsmsg.NewTXT(OwnerName(name), uint32(ttl), this.ownerText(ref)))
+	if err := this.update(name, this.prerequisites(name, current), updates); err != nil {
+		return false, fmt.Errorf("cannot update %s: %s", name, err)
+	}
+	return true, nil
+}
+
+// Delete removes the records of a DNS name if it is owned.
+func (this *Zone) Delete(name string) error {
+	current, err := this.Get(name)
+	if err != nil {
+		return err
+	}
+	if !current.Owned {
+		return nil
+	}
+	return this.delete(name, current)
+}
+
+func (this *Zone) delete(name string, current *RecordSet) error {
+	if err := this.update(name, this.prerequisites(name, current), deletions(name)); err != nil {
+		return fmt.Errorf("cannot delete %s: %s", name, err)
+	}
+	return nil
+}
+
+// Owned lists all DNS names of the zone owned by this owner together
+// with their references. It requires zone transfers to be permitted.
+func (this *Zone) Owned() (map[string]string, error) {
+	rrs, err := this.client.Transfer(this.zone)
+	if err != nil {
+		return nil, fmt.Errorf("cannot transfer zone %s: %s", this.zone, err)
+	}
+	result := map[string]string{}
+	prefix := OWNER_PREFIX + "."
+	for _, rr := range rrs {
+		name := strings.ToLower(rr.Header().Name)
+		if rr.Header().Rrtype != dns.TypeTXT || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		owner, ref, ok := parseOwner(dnsmsg.Value(rr))
+		if ok && owner == this.owner {
+			result[strings.TrimSuffix(name[len(prefix):], ".")] = ref
+		}
+	}
+	return result, nil
+}
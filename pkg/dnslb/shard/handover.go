@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shard
+
+import (
+	"time"
+)
+
+// Handover describes a change of the members of a ring. Keys of members
+// of the previous ring still alive are not taken over before the handover
+// has been completed.
+type Handover struct {
+	Previous *Ring
+	Until    time.Time
+}
+
+// Handovers are the handovers of recent changes of the members.
+type Handovers []Handover
+
+// Prune removes the handovers completed at the given time.
+func (this Handovers) Prune(now time.Time) Handovers {
+	result := Handovers{}
+	for _, h := range this {
+		if now.Before(h.Until) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+// Until provides the end of the handover of a key to a member of a ring,
+// or zero if the key is not taken over from a member still alive. The
+// previous owner is alive if it is still a member of the ring.
+func (this Handovers) Until(ring *Ring, member, key string, now time.Time) time.Time {
+	var until time.Time
+	for _, h := range this {
+		if !now.Before(h.Until) {
+			continue
+		}
+		if owner := h.Previous.Owner(key); owner != "" && owner != member && ring.Contains(owner) && h.Until.After(until) {
+			until = h.Until
+		}
+	}
+	return until
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shard_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/shard"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("handover", func() {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	until := now.Add(30 * time.Second)
+
+	// movedTo provides a key owned by a member of the previous ring and
+	// by the given member of the new ring.
+	movedTo := func(previous, ring *Ring, member string) string {
+		for i := 0; ; i++ {
+			key := fmt.Sprintf("ns/lb%d", i)
+			if previous.Owner(key) != member && ring.Owner(key) == member {
+				return key
+			}
+		}
+	}
+
+	It("delays taking over keys of a live member", func() {
+		previous := NewRing("a")
+		ring := NewRing("a", "b")
+		handovers := Handovers{{Previous: previous, Until: until}}
+		key := movedTo(previous, ring, "b")
+		Expect(handovers.Until(ring, "b", key, now)).To(Equal(until))
+		Expect(handovers.Until(ring, "b", key, until)).To(BeZero())
+	})
+
+	It("takes over keys of a lost member immediately", func() {
+		previous := NewRing("a", "b")
+		ring := NewRing("b")
+		handovers := Handovers{{Previous: previous, Until: until}}
+		key := movedTo(previous, ring, "b")
+		Expect(handovers.Until(ring, "b", key, now)).To(BeZero())
+	})
+
+	It("does not delay keys of the member itself", func() {
+		ring := NewRing("a", "b")
+		handovers := Handovers{{Previous: ring, Until: until}}
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("ns/lb%d", i)
+			if ring.Owner(key) == "b" {
+				Expect(handovers.Until(ring, "b", key, now)).To(BeZero())
+			}
+		}
+	})
+
+	It("uses the latest handover of a key", func() {
+		previous := NewRing("a")
+		ring := NewRing("a", "b")
+		key := movedTo(previous, ring, "b")
+		later := until.Add(10 * time.Second)
+		handovers := Handovers{{Previous: previous, Until: later}, {Previous: ring, Until: until}}
+		Expect(handovers.Until(ring, "b", key, now)).To(Equal(later))
+	})
+
+	It("prunes completed handovers", func() {
+		handovers := Handovers{{Previous: NewRing("a"), Until: now}, {Previous: NewRing("b"), Until: until}}
+		Expect(handovers.Prune(now)).To(Equal(Handovers{handovers[1]}))
+	})
+})
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shard
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// VIRTUAL_NODES is the number of points per member on the hash ring.
+// It evens out the distribution of keys among a small number of members.
+const VIRTUAL_NODES = 64
+
+type point struct {
+	hash   uint32
+	member string
+}
+
+// Ring assigns keys to members by consistent hashing. If a member is
+// added or removed, only the keys of this member are reassigned.
+type Ring struct {
+	members []string
+	points  []point
+}
+
+func NewRing(members ...string) *Ring {
+	r := &Ring{}
+	seen := map[string]bool{}
+	for _, m := range members {
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		r.members = append(r.members, m)
+		for i := 0; i < VIRTUAL_NODES; i++ {
+			r.points = append(r.points, point{hash(m + "#" + strconv.Itoa(i)), m})
+		}
+	}
+	sort.Strings(r.members)
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].member < r.points[j].member
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+func hash(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+// Members provides the sorted list of members.
+func (this *Ring) Members() []string {
+	return this.members
+}
+
+// Contains checks whether a member is part of the ring.
+func (this *Ring) Contains(member string) bool {
+	if this == nil {
+		return false
+	}
+	i := sort.SearchStrings(this.members, member)
+	return i < len(this.members) && this.members[i] == member
+}
+
+// Equals checks whether two rings have the same members.
+func (this *Ring) Equals(r *Ring) bool {
+	if this == nil || r == nil {
+		return this == r
+	}
+	if len(this.members) != len(r.members) {
+		return false
+	}
+	for i, m := range this.members {
+		if r.members[i] != m {
+			return false
+		}
+	}
+	return true
+}
+
+// Owner provides the member responsible for a key or an empty string
+// for an empty ring.
+func (this *Ring) Owner(key string) string {
+	if this == nil || len(this.points) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(this.points), func(i int) bool { return this.points[i].hash >= h })
+	if i == len(this.points) {
+		i = 0
+	}
+	return this.points[i].member
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shard_test
+
+import (
+	"fmt"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/shard"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ring", func() {
+	keys := []string{}
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, fmt.Sprintf("ns%d/lb%d", i%7, i))
+	}
+	owners := func(r *Ring) map[string]string {
+		result := map[string]string{}
+		for _, k := range keys {
+			result[k] = r.Owner(k)
+		}
+		return result
+	}
+
+	It("has no owner for an empty ring", func() {
+		Expect(NewRing().Owner("a/b")).To(Equal(""))
+		var r *Ring
+		Expect(r.Owner("a/b")).To(Equal(""))
+	})
+
+	It("checks its members", func() {
+		r := NewRing("b", "a")
+		Expect(r.Contains("a")).To(BeTrue())
+		Expect(r.Contains("c")).To(BeFalse())
+		var empty *Ring
+		Expect(empty.Contains("a")).To(BeFalse())
+	})
+
+	It("ignores duplicate members and order", func() {
+		r1 := NewRing("b", "a", "a")
+		r2 := NewRing("a", "b")
+		Expect(r1.Members()).To(Equal([]string{"a", "b"}))
+		Expect(r1.Equals(r2)).To(BeTrue())
+		Expect(owners(r1)).To(Equal(owners(r2)))
+		Expect(r1.Equals(NewRing("a"))).To(BeFalse())
+	})
+
+	It("distributes keys among all members", func() {
+		count := map[string]int{}
+		for _, o := range owners(NewRing("r1", "r2", "r3")) {
+			count[o]++
+		}
+		Expect(count).To(HaveLen(3))
+		for _, c := range count {
+			Expect(c).To(BeNumerically(">", 150))
+		}
+	})
+
+	It("reassigns only the keys of a lost member", func() {
+		before := owners(NewRing("r1", "r2", "r3"))
+		after := owners(NewRing("r1", "r3"))
+		for k, o := range before {
+			if o != "r2" {
+				Expect(after[k]).To(Equal(o))
+			} else {
+				Expect(after[k]).NotTo(Equal("r2"))
+			}
+		}
+	})
+})
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+var DNSLoadBalancerClusterType = (*api.DNSLoadBalancerCluster)(nil)
+
+type DNSLoadBalancerClusterObject struct {
+	resources.Object
+}
+
+func (this *DNSLoadBalancerClusterObject) DNSLoadBalancerCluster() *api.DNSLoadBalancerCluster {
+	return this.Data().(*api.DNSLoadBalancerCluster)
+}
+
+func DNSLoadBalancerCluster(o resources.Object) *DNSLoadBalancerClusterObject {
+	if o.IsA(DNSLoadBalancerClusterType) {
+		return &DNSLoadBalancerClusterObject{o}
+	}
+	return nil
+}
+
+func (this *DNSLoadBalancerClusterObject) Spec() *api.DNSLoadBalancerClusterSpec {
+	return &this.DNSLoadBalancerCluster().Spec
+}
+func (this *DNSLoadBalancerClusterObject) Status() *api.DNSLoadBalancerClusterStatus {
+	return &this.DNSLoadBalancerCluster().Status
+}
+
+// ClusterObjectName provides a valid object name for objects describing
+// a source cluster. The cluster may be given by its id or by the value
+// of its cluster label. If the label value has to be modified to get a
+// valid name, a hash of the label value is appended to keep the names
+// of different clusters distinct.
+func ClusterObjectName(cluster string) string {
+	value := LabelValue(cluster)
+	name := strings.ToLower(strings.Replace(value, "_", "-", -1))
+	if name == value {
+		return name
+	}
+	if max := 63 - api.LABEL_HASH_LENGTH - 1; len(name) > max {
+		name = strings.TrimRight(name[:max], "-.")
+	}
+	return name + "-" + api.Hash(value)
+}
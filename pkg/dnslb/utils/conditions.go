@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// GetCondition provides the condition of the given type, or nil if not set.
+func GetCondition(conditions []api.Condition, ty string) *api.Condition {
+	for i := range conditions {
+		if conditions[i].Type == ty {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition sets a condition in a list of conditions and provides the
+// resulting list. The transition time is only updated if the status of the
+// condition changes, so setting an unchanged condition keeps the list equal.
+func SetCondition(conditions []api.Condition, ty string, status api.ConditionStatus, reason, msg string, generation int64) []api.Condition {
+	c := api.Condition{
+		Type:               ty,
+		Status:             status,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}
+	result := make([]api.Condition, 0, len(conditions)+1)
+	found := false
+	for _, old := range conditions {
+		if old.Type == ty {
+			if old.Status == status {
+				c.LastTransitionTime = old.LastTransitionTime
+			}
+			old = c
+			found = true
+		}
+		result = append(result, old)
+	}
+	if !found {
+		result = append(result, c)
+	}
+	return result
+}
+
+// ConditionStatus maps a boolean to a condition status.
+func ConditionStatus(b bool) api.ConditionStatus {
+	if b {
+		return api.CONDITION_TRUE
+	}
+	return api.CONDITION_FALSE
+}
@@ -6,8 +6,10 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	"github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1/scope"
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
@@ -47,8 +49,8 @@ func (this *DNSLoadBalancerEndpointObject) Status() *api.DNSLoadBalancerEndpoint
 func (this *DNSLoadBalancerEndpointObject) GetCName() string {
 	return this.DNSLoadBalancerEndpoint().Spec.CName
 }
-func (this *DNSLoadBalancerEndpointObject) GetIPAddress() string {
-	return this.DNSLoadBalancerEndpoint().Spec.IPAddress
+func (this *DNSLoadBalancerEndpointObject) GetAddresses() []string {
+	return this.DNSLoadBalancerEndpoint().Spec.Addresses
 }
 
 func (this *DNSLoadBalancerEndpointObject) GetLoadBalancerRef() *resources.ClusterObjectKey {
@@ -56,7 +58,7 @@ func (this *DNSLoadBalancerEndpointObject) GetLoadBalancerRef() *resources.Clust
 	if name == "" {
 		return nil
 	}
-	key := resources.NewClusterKey(this.GetCluster().GetId(), schema.GroupKind{api.GroupName, api.LoadBalancerResourceKind}, this.GetNamespace(), name)
+	key := resources.NewClusterKey(this.GetCluster().GetId(), schema.GroupKind{Group: api.GroupName, Kind: api.LoadBalancerResourceKind}, this.GetNamespace(), name)
 	return &key
 }
 
@@ -69,22 +71,71 @@ func (this *DNSLoadBalancerEndpointObject) Validate() error {
 	if errors.IsNotFound(err) || (err == nil && o.IsDeleting()) {
 		return fmt.Errorf("loadbalancer %q not found", lbref.ObjectName())
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	lb := DNSLoadBalancer(o)
+	access, err := scope.Eval(lb, lb.Spec().Access, this.GetCluster().GetId())
+	if err != nil {
+		return fmt.Errorf("loadbalancer %q: %s", lbref.ObjectName(), err)
+	}
+	if !access.ValidFor(this) {
+		return fmt.Errorf("endpoint not permitted by access control of loadbalancer %q", lbref.ObjectName())
+	}
+	return nil
 }
 
-func (this *DNSLoadBalancerEndpointObject) UpdateState(state, msg string, healthy *bool) (bool, error) {
-	mod := resources.NewModificationState(this.Object)
-	status := this.Status()
-	mod.AssureStringPtrValue(&status.State, state)
-	if msg == "" {
-		mod.AssureStringPtrPtr(&status.Message, nil)
+func (this *DNSLoadBalancerEndpointObject) UpdateState(manager, state, msg string, healthy *bool) (bool, error) {
+	return this.UpdateStateWith(manager, state, msg, healthy, nil)
+}
+
+// UpdateStateWith updates the state of the endpoint together with additional
+// status fields set by the given function. The function is called with the
+// previous status. The status is written with ModifyStatus using the given
+// field manager, so the update is repeated on the current version of the
+// endpoint after a conflict with another writer.
+func (this *DNSLoadBalancerEndpointObject) UpdateStateWith(manager, state, msg string, healthy *bool, update func(status *api.DNSLoadBalancerEndpointStatus)) (bool, error) {
+	return ModifyStatus(this, manager, func(resources.ObjectData) (bool, error) {
+		mod := resources.NewModificationState(this.Object)
+		status := this.Status()
+		if update != nil {
+			old := status.DeepCopy()
+			update(status)
+			mod.Modify(!reflect.DeepEqual(old, status))
+		}
+		mod.AssureStringPtrValue(&status.State, state)
+		if msg == "" {
+			mod.AssureStringPtrPtr(&status.Message, nil)
+
+		} else {
+			mod.AssureStringPtrPtr(&status.Message, &msg)
+		}
+		if healthy != nil {
+			mod.AssureBoolValue(&status.Healthy, *healthy)
+		}
+		generation := this.DNSLoadBalancerEndpoint().Generation
+		mod.AssureInt64Value(&status.ObservedGeneration, generation)
+		conditions := this.conditions(state, msg, healthy, generation)
+		mod.Modify(!reflect.DeepEqual(status.Conditions, conditions))
+		status.Conditions = conditions
+		return mod.Modified, nil
+	})
+}
 
-	} else {
-		mod.AssureStringPtrPtr(&status.Message, &msg)
+// conditions provides the conditions of the endpoint for a new state.
+func (this *DNSLoadBalancerEndpointObject) conditions(state, msg string, healthy *bool, generation int64) []api.Condition {
+	conditions := this.Status().Conditions
+	assigned := state == api.STATE_ACTIVE || state == api.STATE_INACTIVE
+	conditions = SetCondition(conditions, api.CONDITION_READY, ConditionStatus(assigned), state, msg, generation)
+	if assigned {
+		conditions = SetCondition(conditions, api.CONDITION_ACTIVE, ConditionStatus(state == api.STATE_ACTIVE), state, "", generation)
 	}
 	if healthy != nil {
-		mod.AssureBoolValue(&status.Healthy, *healthy)
+		reason := api.STATE_HEALTHY
+		if !*healthy {
+			reason = api.STATE_UNREACHABLE
+		}
+		conditions = SetCondition(conditions, api.CONDITION_HEALTHY, ConditionStatus(*healthy), reason, "", generation)
 	}
-	return mod.Modified, mod.Update()
-
+	return conditions
 }
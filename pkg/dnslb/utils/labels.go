@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+// LabelValue maps an arbitrary string to a valid label value (see
+// api.LabelValue).
+func LabelValue(s string) string {
+	return api.LabelValue(s)
+}
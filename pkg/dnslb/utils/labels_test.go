@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"strings"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("labels", func() {
+	Context("label value", func() {
+		It("keeps valid label values", func() {
+			Expect(LabelValue("")).To(Equal(""))
+			Expect(LabelValue("garden.cluster")).To(Equal("garden.cluster"))
+			Expect(LabelValue("EU_West-1")).To(Equal("EU_West-1"))
+		})
+
+		It("replaces unsupported characters and appends a hash", func() {
+			Expect(LabelValue("garden/cluster")).To(Equal("garden.cluster-" + api.Hash("garden/cluster")))
+			Expect(LabelValue("/")).To(Equal(api.Hash("/")))
+		})
+
+		It("maps different strings to different values", func() {
+			values := map[string]bool{}
+			for _, s := range []string{"garden.cluster", "garden/cluster", "garden:cluster", "-garden.cluster", "garden.cluster."} {
+				v := LabelValue(s)
+				Expect(api.IsLabelValue(v)).To(BeTrue(), s)
+				Expect(values).NotTo(HaveKey(v), s)
+				values[v] = true
+			}
+		})
+
+		It("shortens long values", func() {
+			long := strings.Repeat("a", 100)
+			v := LabelValue(long)
+			Expect(api.IsLabelValue(v)).To(BeTrue())
+			Expect(v).To(HaveSuffix("-" + api.Hash(long)))
+			Expect(LabelValue(long + "b")).NotTo(Equal(v))
+		})
+
+		It("keeps mapped values", func() {
+			v := LabelValue("garden/cluster")
+			Expect(LabelValue(v)).To(Equal(v))
+		})
+	})
+
+	Context("cluster object name", func() {
+		It("uses lower case label values", func() {
+			Expect(ClusterObjectName("garden.cluster")).To(Equal("garden.cluster"))
+		})
+
+		It("is the same for cluster ids and their label values", func() {
+			Expect(ClusterObjectName(LabelValue("garden/cluster"))).To(Equal(ClusterObjectName("garden/cluster")))
+		})
+
+		It("distinguishes clusters differing in case", func() {
+			Expect(ClusterObjectName("EU")).NotTo(Equal(ClusterObjectName("eu")))
+			Expect(ClusterObjectName("eu_west")).NotTo(Equal(ClusterObjectName("eu-west")))
+			Expect(ClusterObjectName("EU")).To(Equal("eu-" + api.Hash("EU")))
+		})
+	})
+})
@@ -5,7 +5,9 @@
 package utils
 
 import (
-	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1beta1"
+	"reflect"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
 )
@@ -42,15 +44,24 @@ func (this *DNSLoadBalancerObject) GetDNSName() string {
 	return this.DNSLoadBalancer().Spec.DNSName
 }
 
-func (this *DNSLoadBalancerObject) UpdateState(state, msg string) (bool, error) {
-	mod := resources.NewModificationState(this)
-	status := this.Status()
-	mod.AssureStringPtrValue(&status.State, api.STATE_ERROR)
-	if msg == "" {
-		mod.AssureStringPtrPtr(&status.Message, nil)
+// UpdateState updates the state of the load balancer. The status is
+// written with ModifyStatus using the given field manager.
+func (this *DNSLoadBalancerObject) UpdateState(manager, state, msg string) (bool, error) {
+	return ModifyStatus(this, manager, func(resources.ObjectData) (bool, error) {
+		mod := resources.NewModificationState(this)
+		status := this.Status()
+		mod.AssureStringPtrValue(&status.State, state)
+		if msg == "" {
+			mod.AssureStringPtrPtr(&status.Message, nil)
 
-	} else {
-		mod.AssureStringPtrPtr(&status.Message, &msg)
-	}
-	return mod.Modified, mod.Update()
+		} else {
+			mod.AssureStringPtrPtr(&status.Message, &msg)
+		}
+		generation := this.DNSLoadBalancer().Generation
+		mod.AssureInt64Value(&status.ObservedGeneration, generation)
+		conditions := SetCondition(status.Conditions, api.CONDITION_READY, ConditionStatus(state == api.STATE_HEALTHY), state, msg, generation)
+		mod.Modify(!reflect.DeepEqual(status.Conditions, conditions))
+		status.Conditions = conditions
+		return mod.Modified, nil
+	})
 }
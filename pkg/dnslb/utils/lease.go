@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	coordination "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+)
+
+func init() {
+	resources.Register(coordination.SchemeBuilder)
+}
+
+const HEARTBEAT_LEASE_PREFIX = "dnslb-source-"
+
+var LeaseType = (*coordination.Lease)(nil)
+
+// HeartbeatLeaseName provides the name of the heartbeat lease used for
+// a source cluster. The cluster is given by the value of its cluster label.
+func HeartbeatLeaseName(cluster string) string {
+	return HEARTBEAT_LEASE_PREFIX + ClusterObjectName(cluster)
+}
+
+type LeaseObject struct {
+	resources.Object
+}
+
+func Lease(o resources.Object) *LeaseObject {
+	if o.IsA(LeaseType) {
+		return &LeaseObject{o}
+	}
+	return nil
+}
+
+func (this *LeaseObject) Lease() *coordination.Lease {
+	return this.Data().(*coordination.Lease)
+}
+
+func (this *LeaseObject) Spec() *coordination.LeaseSpec {
+	return &this.Lease().Spec
+}
+
+func (this *LeaseObject) GetCluster() string {
+	return this.GetLabel(api.LABEL_CLUSTER)
+}
+
+// ExpiresAt provides the time the lease expires if it is not renewed.
+func (this *LeaseObject) ExpiresAt() time.Time {
+	spec := this.Spec()
+	if spec.RenewTime == nil {
+		return this.GetCreationTimestamp().Time
+	}
+	duration := time.Duration(0)
+	if spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*spec.LeaseDurationSeconds) * time.Second
+	}
+	return spec.RenewTime.Time.Add(duration)
+}
+
+func (this *LeaseObject) IsExpired(now time.Time) bool {
+	return this.ExpiresAt().Before(now)
+}
+
+// Renew updates the renew time of the lease and sets the duration and holder.
+func (this *LeaseObject) Renew(holder string, duration time.Duration) (bool, error) {
+	return this.CreateOrModify(func(data resources.ObjectData) (bool, error) {
+		spec := &data.(*coordination.Lease).Spec
+		now := metav1.NewMicroTime(time.Now())
+		seconds := int32(duration / time.Second)
+		spec.HolderIdentity = &holder
+		spec.LeaseDurationSeconds = &seconds
+		spec.RenewTime = &now
+		return true, nil
+	})
+}
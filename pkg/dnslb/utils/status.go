@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// Field managers of the status patches written by the controllers. They
+// are recorded by the api server for the fields set by a patch.
+const (
+	FIELD_MANAGER_DNS      = "dnslb-loadbalancer"       // DNS controller
+	FIELD_MANAGER_STATE    = "dnslb-loadbalancer-state" // state reconciler of the DNS controller
+	FIELD_MANAGER_ENDPOINT = "dnslb-endpoint"           // endpoint controller
+)
+
+// restClientProvider is implemented by the resources of the controller
+// manager library.
+type restClientProvider interface {
+	Client() restclient.Interface
+}
+
+// HasStatusSubresource checks whether the status of an object has to be
+// written with the status sub resource.
+func HasStatusSubresource(o resources.Object) bool {
+	return o.GetResource().Info().HasStatusSubResource()
+}
+
+// PatchStatus writes the changes of the status of an object compared to
+// its original version with a JSON merge patch. The patch is sent to the
+// status sub resource if the custom resource definition provides it. It
+// does not contain a resource version, so spec changes and status changes
+// of other fields by other writers do not conflict.
+func PatchStatus(o resources.Object, orig resources.ObjectData, manager string) error {
+	patch, err := StatusPatch(orig, o.Data())
+	if err != nil || patch == nil {
+		return err
+	}
+	res := o.GetResource()
+	provider, ok := res.(restClientProvider)
+	if !ok {
+		return fmt.Errorf("no rest client for resource %s", res.GroupVersionKind())
+	}
+	req := provider.Client().Patch(types.MergePatchType)
+	if res.Namespaced() {
+		req = req.Namespace(o.GetNamespace())
+	}
+	req = req.Resource(res.Name()).Name(o.GetName())
+	if HasStatusSubresource(o) {
+		req = req.SubResource("status")
+	}
+	data, err := req.Param("fieldManager", manager).Body(patch).Do().Raw()
+	if err != nil {
+		return err
+	}
+	result := &struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(data, result); err == nil && result.Metadata.ResourceVersion != "" {
+		// keep the object usable for subsequent updates
+		o.Data().SetResourceVersion(result.Metadata.ResourceVersion)
+	}
+	return nil
+}
+
+// ModifyStatus modifies the status of an object and writes the changes
+// with PatchStatus.
+func ModifyStatus(o resources.Object, manager string, modifier resources.Modifier) (bool, error) {
+	orig := o.Data().DeepCopyObject().(resources.ObjectData)
+	mod, err := modifier(o.Data())
+	if err != nil || !mod {
+		return mod, err
+	}
+	return true, PatchStatus(o, orig, manager)
+}
+
+// StatusPatch provides the JSON merge patch (RFC 7386) for the status
+// changes of an object. It is nil if the status is unchanged.
+func StatusPatch(orig, modified resources.ObjectData) ([]byte, error) {
+	o, err := statusOf(orig)
+	if err != nil {
+		return nil, err
+	}
+	m, err := statusOf(modified)
+	if err != nil {
+		return nil, err
+	}
+	patch := mergePatch(o, m)
+	if len(patch) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(map[string]interface{}{"status": patch})
+}
+
+func statusOf(obj resources.ObjectData) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := &struct {
+		Status map[string]interface{} `json:"status"`
+	}{}
+	return result.Status, json.Unmarshal(data, result)
+}
+
+// mergePatch provides the merge patch transforming an original JSON object
+// into a modified one. Removed fields are set to null, arrays are replaced
+// as a whole.
+func mergePatch(orig, modified map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k, v := range modified {
+		o, ok := orig[k]
+		if !ok {
+			patch[k] = v
+			continue
+		}
+		om, ok1 := o.(map[string]interface{})
+		vm, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			if p := mergePatch(om, vm); len(p) > 0 {
+				patch[k] = p
+			}
+			continue
+		}
+		if !reflect.DeepEqual(o, v) {
+			patch[k] = v
+		}
+	}
+	for k := range orig {
+		if _, ok := modified[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	api "github.com/gardener/dnslb-controller-manager/pkg/apis/loadbalancer/v1"
+	. "github.com/gardener/dnslb-controller-manager/pkg/dnslb/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("status patch", func() {
+	str := func(s string) *string { return &s }
+
+	var orig *api.DNSLoadBalancer
+
+	BeforeEach(func() {
+		orig = &api.DNSLoadBalancer{
+			Spec: api.DNSLoadBalancerSpec{DNSName: "lb.example.org"},
+			Status: api.DNSLoadBalancerStatus{
+				State:   str("Error"),
+				Message: str("no endpoint"),
+				Active:  []api.DNSLoadBalancerActive{{Endpoint: "a"}, {Endpoint: "b"}},
+			},
+		}
+	})
+
+	patch := func(modify func(lb *api.DNSLoadBalancer)) string {
+		modified := orig.DeepCopy()
+		modify(modified)
+		data, err := StatusPatch(orig, modified)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("is empty for an unchanged status", func() {
+		data, err := StatusPatch(orig, orig.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(BeNil())
+	})
+
+	It("ignores spec changes", func() {
+		Expect(patch(func(lb *api.DNSLoadBalancer) { lb.Spec.DNSName = "other.example.org" })).To(BeEmpty())
+	})
+
+	It("contains changed fields only", func() {
+		Expect(patch(func(lb *api.DNSLoadBalancer) { lb.Status.State = str("Healthy") })).To(MatchJSON(`{"status":{"state":"Healthy"}}`))
+	})
+
+	It("removes cleared fields", func() {
+		Expect(patch(func(lb *api.DNSLoadBalancer) {
+			lb.Status.State = str("Healthy")
+			lb.Status.Message = nil
+		})).To(MatchJSON(`{"status":{"state":"Healthy","message":null}}`))
+	})
+
+	It("replaces lists as a whole", func() {
+		Expect(patch(func(lb *api.DNSLoadBalancer) {
+			lb.Status.Active = lb.Status.Active[1:]
+		})).To(MatchJSON(`{"status":{"active":[{"endpoint":"b"}]}}`))
+	})
+
+	It("contains no resource version", func() {
+		orig.ResourceVersion = "42"
+		Expect(patch(func(lb *api.DNSLoadBalancer) {
+			lb.ResourceVersion = "43"
+			lb.Status.State = str("Healthy")
+		})).To(MatchJSON(`{"status":{"state":"Healthy"}}`))
+	})
+
+	It("is empty for an unchanged status with a resource version", func() {
+		orig.ResourceVersion = "42"
+		data, err := StatusPatch(orig, orig.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(BeNil())
+	})
+})
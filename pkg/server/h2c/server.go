@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package h2c
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/gardener/controller-manager-library/pkg/ctxutil"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// PREFACE_TIMEOUT is the maximum time to wait for the first bytes of a
+// connection to decide between HTTP/1 and HTTP/2.
+const PREFACE_TIMEOUT = 10 * time.Second
+
+const SHUTDOWN_TIMEOUT = 5 * time.Second
+
+// Handler dispatches gRPC requests (HTTP/2 with content type
+// application/grpc) to the gRPC handler and all other requests to the
+// given handler.
+func Handler(handler, grpc http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpc.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Start serves a handler on the given port like the HTTP server of the
+// controller manager library, but additionally accepts HTTP/2 without
+// TLS (h2c with prior knowledge) as required by gRPC clients. If the
+// server fails, the context is cancelled.
+func Start(ctx context.Context, port int, handler http.Handler) {
+	addr := fmt.Sprintf(":%d", port)
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Errorf("cannot start http server: %s", err)
+		ctxutil.Cancel(ctx)
+		return
+	}
+	go func() {
+		logger.Infof("HTTP server started (serving on %s)", addr)
+		if err := Serve(ctx, l, handler); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("http server failed: %s", err)
+		}
+		logger.Infof("HTTP server stopped")
+		ctxutil.Cancel(ctx)
+	}()
+}
+
+// Serve serves HTTP/1 and HTTP/2 without TLS on a listener until the
+// context is done. HTTP/2 connections are detected by the client preface.
+func Serve(ctx context.Context, l net.Listener, handler http.Handler) error {
+	h1 := &http.Server{Handler: handler}
+	h2 := &http2.Server{}
+	if err := http2.ConfigureServer(h1, h2); err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+		defer cancel()
+		h1.Shutdown(ctx)
+	}()
+	return h1.Serve(newListener(l, func(c net.Conn) {
+		h2.ServeConn(c, &http2.ServeConnOpts{BaseConfig: h1, Handler: handler})
+	}))
+}
+
+// listener passes HTTP/1 connections to the HTTP/1 server and serves
+// HTTP/2 connections directly.
+type listener struct {
+	net.Listener
+	http2 func(net.Conn)
+	conns chan net.Conn
+	errs  chan error
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newListener(l net.Listener, http2 func(net.Conn)) *listener {
+	this := &listener{
+		Listener: l,
+		http2:    http2,
+		conns:    make(chan net.Conn),
+		errs:     make(chan error),
+		done:     make(chan struct{}),
+	}
+	go this.accept()
+	return this
+}
+
+func (this *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-this.conns:
+		return c, nil
+	case err := <-this.errs:
+		return nil, err
+	case <-this.done:
+		return nil, fmt.Errorf("listener closed")
+	}
+}
+
+func (this *listener) Close() error {
+	var err error
+	this.once.Do(func() {
+		close(this.done)
+		err = this.Listener.Close()
+	})
+	return err
+}
+
+func (this *listener) accept() {
+	for {
+		c, err := this.Listener.Accept()
+		if err != nil {
+			select {
+			case this.errs <- err:
+			case <-this.done:
+				return
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				continue
+			}
+			return
+		}
+		go this.detect(c)
+	}
+}
+
+// detect reads the beginning of a connection. Reading stops at the
+// first byte not matching the HTTP/2 client preface.
+func (this *listener) detect(c net.Conn) {
+	r := bufio.NewReader(c)
+	c.SetReadDeadline(time.Now().Add(PREFACE_TIMEOUT))
+	h2 := true
+	for n := 1; h2 && n <= len(http2.ClientPreface); n++ {
+		b, err := r.Peek(n)
+		h2 = err == nil && b[n-1] == http2.ClientPreface[n-1]
+	}
+	c.SetReadDeadline(time.Time{})
+	conn := &conn{Conn: c, reader: r}
+	if h2 {
+		this.http2(conn)
+		return
+	}
+	select {
+	case this.conns <- conn:
+	case <-this.done:
+		c.Close()
+	}
+}
+
+// conn is a connection with already buffered input.
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (this *conn) Read(b []byte) (int, error) {
+	return this.reader.Read(b)
+}
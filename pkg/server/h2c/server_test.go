@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package h2c_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/server/h2c"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("h2c", func() {
+	var cancel context.CancelFunc
+	var url string
+
+	get := func(client *http.Client, contentType string) string {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", contentType)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	BeforeEach(func() {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		url = "http://" + l.Addr().String() + "/"
+		handler := func(name string) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, "%s HTTP/%d", name, r.ProtoMajor)
+			})
+		}
+		go Serve(ctx, l, Handler(handler("http"), handler("grpc")))
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should serve HTTP/1", func() {
+		Expect(get(http.DefaultClient, "application/grpc")).To(Equal("http HTTP/1"))
+	})
+
+	It("should serve HTTP/2 with prior knowledge", func() {
+		client := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}}
+		Expect(get(client, "application/json")).To(Equal("http HTTP/2"))
+		Expect(get(client, "application/grpc+proto")).To(Equal("grpc HTTP/2"))
+	})
+})
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthstate
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/server/healthstate/v1"
+)
+
+// GRPCServer serves the health state API of a store via gRPC (service
+// dnslb.healthstate.v1.HealthState, see v1/healthstate.proto).
+type GRPCServer struct {
+	store  *Store
+	server *grpc.Server
+}
+
+var _ v1.HealthStateServer = &GRPCServer{}
+
+var defaultGRPCServer *GRPCServer
+var defaultGRPCOnce sync.Once
+
+// DefaultGRPCServer provides the gRPC server for the default store.
+func DefaultGRPCServer() *GRPCServer {
+	defaultGRPCOnce.Do(func() {
+		defaultGRPCServer = NewGRPCServer(Default())
+	})
+	return defaultGRPCServer
+}
+
+func NewGRPCServer(store *Store) *GRPCServer {
+	this := &GRPCServer{store: store, server: grpc.NewServer()}
+	v1.RegisterHealthStateServer(this.server, this)
+	return this
+}
+
+// ServeHTTP serves gRPC requests received via HTTP/2. It is used to serve
+// the API on the port of the HTTP server (see package h2c).
+func (this *GRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	this.server.ServeHTTP(w, r)
+}
+
+func (this *GRPCServer) List(ctx context.Context, req *v1.ListRequest) (*v1.LoadBalancerList, error) {
+	lbs, version := this.store.List()
+	list := &v1.LoadBalancerList{ApiVersion: API_VERSION, Version: version, Items: []*v1.LoadBalancer{}}
+	for _, lb := range lbs {
+		list.Items = append(list.Items, toProto(lb))
+	}
+	return list, nil
+}
+
+func (this *GRPCServer) Get(ctx context.Context, req *v1.GetRequest) (*v1.LoadBalancer, error) {
+	lb := this.store.Get(req.Namespace, req.Name)
+	if lb == nil {
+		return nil, status.Errorf(codes.NotFound, "load balancer %s/%s not found", req.Namespace, req.Name)
+	}
+	return toProto(lb), nil
+}
+
+// Watch streams the events of a store watcher. Like for the HTTP API,
+// clients not consuming the events fast enough have to watch again.
+func (this *GRPCServer) Watch(req *v1.WatchRequest, stream v1.HealthState_WatchServer) error {
+	watcher := this.store.Watch()
+	defer watcher.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-watcher.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "events not consumed fast enough")
+			}
+			if err := stream.Send(eventToProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var eventTypes = map[string]v1.Event_Type{
+	EVENT_ADDED:    v1.Event_ADDED,
+	EVENT_MODIFIED: v1.Event_MODIFIED,
+	EVENT_DELETED:  v1.Event_DELETED,
+}
+
+func eventToProto(e *Event) *v1.Event {
+	return &v1.Event{Type: eventTypes[e.Type], Version: e.Version, LoadBalancer: toProto(e.LoadBalancer)}
+}
+
+func timeToProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, _ := ptypes.TimestampProto(t)
+	return ts
+}
+
+func toProto(lb *LoadBalancer) *v1.LoadBalancer {
+	result := &v1.LoadBalancer{
+		Namespace:      lb.Namespace,
+		Name:           lb.Name,
+		Dnsname:        lb.DNSName,
+		Type:           lb.Type,
+		Healthy:        lb.Healthy,
+		HealthyTargets: lb.HealthyTargets,
+		ActiveTargets:  lb.ActiveTargets,
+		Updated:        timeToProto(lb.Updated),
+	}
+	for _, r := range lb.Regions {
+		result.Regions = append(result.Regions, &v1.Region{Region: r.Region, Dnsname: r.DNSName, Targets: r.Targets})
+	}
+	for _, ep := range lb.Endpoints {
+		e := &v1.Endpoint{
+			Namespace: ep.Namespace,
+			Name:      ep.Name,
+			Host:      ep.Host,
+			Addresses: ep.Addresses,
+			Region:    ep.Region,
+			Healthy:   ep.Healthy,
+			Active:    ep.Active,
+		}
+		if p := ep.LastProbe; p != nil {
+			e.LastProbe = &v1.Probe{
+				Time:          timeToProto(p.Time),
+				Healthy:       p.Healthy,
+				StatusCode:    int32(p.StatusCode),
+				LatencyMillis: p.LatencyMillis,
+				Error:         p.Error,
+			}
+		}
+		result.Endpoints = append(result.Endpoints, e)
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthstate_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gardener/dnslb-controller-manager/pkg/server/h2c"
+	. "github.com/gardener/dnslb-controller-manager/pkg/server/healthstate"
+	v1 "github.com/gardener/dnslb-controller-manager/pkg/server/healthstate/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("health state grpc", func() {
+	var store *Store
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var conn *grpc.ClientConn
+	var client v1.HealthStateClient
+	var addr string
+
+	BeforeEach(func() {
+		store = NewStore()
+		ctx, cancel = context.WithCancel(context.Background())
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr = l.Addr().String()
+		go h2c.Serve(ctx, l, h2c.Handler(NewHandler(store), NewGRPCServer(store)))
+		conn, err = grpc.Dial(addr, grpc.WithInsecure())
+		Expect(err).NotTo(HaveOccurred())
+		client = v1.NewHealthStateClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		cancel()
+	})
+
+	It("should list and get load balancers", func() {
+		probed := time.Now().Round(time.Second)
+		store.Set(&LoadBalancer{
+			Namespace:     "default",
+			Name:          "a",
+			DNSName:       "a.example.org",
+			Healthy:       true,
+			ActiveTargets: []string{"10.0.0.1"},
+			Endpoints: []Endpoint{
+				{Name: "ep", Host: "10.0.0.1", Healthy: true, Active: true, LastProbe: &Probe{Time: probed, Healthy: true, StatusCode: 200, LatencyMillis: 12}},
+			},
+		})
+		store.Set(&LoadBalancer{Namespace: "default", Name: "b"})
+
+		list, err := client.List(ctx, &v1.ListRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.ApiVersion).To(Equal(API_VERSION))
+		Expect(list.Version).To(Equal(uint64(2)))
+		Expect(list.Items).To(HaveLen(2))
+
+		lb, err := client.Get(ctx, &v1.GetRequest{Namespace: "default", Name: "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lb.Dnsname).To(Equal("a.example.org"))
+		Expect(lb.ActiveTargets).To(Equal([]string{"10.0.0.1"}))
+		Expect(lb.Endpoints).To(HaveLen(1))
+		probe := lb.Endpoints[0].LastProbe
+		Expect(probe.StatusCode).To(Equal(int32(200)))
+		Expect(probe.LatencyMillis).To(Equal(int64(12)))
+		Expect(probe.Time.Seconds).To(Equal(probed.Unix()))
+
+		_, err = client.Get(ctx, &v1.GetRequest{Namespace: "default", Name: "c"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("should stream events", func() {
+		store.Set(&LoadBalancer{Namespace: "default", Name: "a"})
+
+		stream, err := client.Watch(ctx, &v1.WatchRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		e, err := stream.Recv()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Type).To(Equal(v1.Event_ADDED))
+		Expect(e.LoadBalancer.Name).To(Equal("a"))
+
+		store.Set(&LoadBalancer{Namespace: "default", Name: "a", ActiveTargets: []string{"10.0.0.1"}})
+		e, err = stream.Recv()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Type).To(Equal(v1.Event_MODIFIED))
+		Expect(e.Version).To(Equal(uint64(2)))
+
+		store.Remove("default", "a")
+		e, err = stream.Recv()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Type).To(Equal(v1.Event_DELETED))
+	})
+
+	It("should serve the HTTP API on the same port", func() {
+		store.Set(&LoadBalancer{Namespace: "default", Name: "a"})
+		resp, err := http.Get("http://" + addr + API_PATH)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		list := &List{}
+		Expect(json.NewDecoder(resp.Body).Decode(list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+)
+
+// API_PATH is the path of the load balancer resource of the API.
+const API_PATH = "/api/" + API_VERSION + "/loadbalancers"
+
+// KEEPALIVE_PERIOD is the period for empty lines sent on idle watch
+// streams to detect vanished clients.
+const KEEPALIVE_PERIOD = 30 * time.Second
+
+func init() {
+	h := NewHandler(Default())
+	server.RegisterHandler(API_PATH, h)
+	server.RegisterHandler(API_PATH+"/", h)
+}
+
+// Handler serves the health state API for a store:
+//
+//	GET /api/v1/loadbalancers                    list all load balancers
+//	GET /api/v1/loadbalancers?watch=true         stream of change events
+//	GET /api/v1/loadbalancers/<namespace>/<name> get a single load balancer
+//
+// Watch streams consist of JSON encoded events separated by newlines.
+type Handler struct {
+	store *Store
+}
+
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+func (this *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, API_PATH), "/")
+	if path == "" {
+		if r.URL.Query().Get("watch") == "true" {
+			this.watch(w, r)
+		} else {
+			lbs, version := this.store.List()
+			this.write(w, &List{APIVersion: API_VERSION, Version: version, Items: lbs})
+		}
+		return
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		http.Error(w, "invalid path", http.StatusNotFound)
+		return
+	}
+	lb := this.store.Get(parts[0], parts[1])
+	if lb == nil {
+		http.Error(w, fmt.Sprintf("load balancer %s/%s not found", parts[0], parts[1]), http.StatusNotFound)
+		return
+	}
+	this.write(w, lb)
+}
+
+func (this *Handler) write(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(obj)
+}
+
+func (this *Handler) watch(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	watcher := this.store.Watch()
+	defer watcher.Stop()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	keepalive := time.NewTicker(KEEPALIVE_PERIOD)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepalive.C:
+			if _, err := w.Write([]byte("\n")); err != nil {
+				return
+			}
+		case e, ok := <-watcher.Events():
+			if !ok {
+				return
+			}
+			if err := enc.Encode(e); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
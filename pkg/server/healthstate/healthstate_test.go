@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthstate_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/server/healthstate"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("health state", func() {
+	var store *Store
+	var server *httptest.Server
+
+	lb := func(name string, targets ...string) *LoadBalancer {
+		return &LoadBalancer{Namespace: "default", Name: name, DNSName: name + ".example.org", ActiveTargets: targets}
+	}
+
+	BeforeEach(func() {
+		store = NewStore()
+		server = httptest.NewServer(NewHandler(store))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list and get load balancers", func() {
+		store.Set(lb("b", "10.0.0.2"))
+		store.Set(lb("a", "10.0.0.1"))
+
+		resp, err := http.Get(server.URL + API_PATH)
+		Expect(err).NotTo(HaveOccurred())
+		list := &List{}
+		Expect(json.NewDecoder(resp.Body).Decode(list)).To(Succeed())
+		resp.Body.Close()
+		Expect(list.APIVersion).To(Equal(API_VERSION))
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[0].Name).To(Equal("a"))
+
+		resp, err = http.Get(server.URL + API_PATH + "/default/b")
+		Expect(err).NotTo(HaveOccurred())
+		got := &LoadBalancer{}
+		Expect(json.NewDecoder(resp.Body).Decode(got)).To(Succeed())
+		resp.Body.Close()
+		Expect(got.ActiveTargets).To(Equal([]string{"10.0.0.2"}))
+
+		resp, err = http.Get(server.URL + API_PATH + "/default/c")
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should stream changes", func() {
+		store.Set(lb("a", "10.0.0.1"))
+
+		resp, err := http.Get(server.URL + API_PATH + "?watch=true")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		dec := json.NewDecoder(bufio.NewReader(resp.Body))
+
+		e := &Event{}
+		Expect(dec.Decode(e)).To(Succeed())
+		Expect(e.Type).To(Equal(EVENT_ADDED))
+		Expect(e.LoadBalancer.Name).To(Equal("a"))
+
+		store.Set(lb("a", "10.0.0.2"))
+		e = &Event{}
+		Expect(dec.Decode(e)).To(Succeed())
+		Expect(e.Type).To(Equal(EVENT_MODIFIED))
+		Expect(e.LoadBalancer.ActiveTargets).To(Equal([]string{"10.0.0.2"}))
+
+		store.Remove("default", "a")
+		e = &Event{}
+		Expect(dec.Decode(e)).To(Succeed())
+		Expect(e.Type).To(Equal(EVENT_DELETED))
+	})
+
+	It("should close watchers not consuming events", func() {
+		w := store.Watch()
+		for i := 0; i <= WATCH_BUFFER; i++ {
+			store.Set(lb("a"))
+		}
+		n := 0
+		for range w.Events() {
+			n++
+		}
+		Expect(n).To(Equal(WATCH_BUFFER))
+	})
+})
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthstate
+
+import (
+	"sort"
+	"sync"
+)
+
+// WATCH_BUFFER is the number of events buffered for a watcher. Watchers
+// not consuming their events fast enough are closed.
+const WATCH_BUFFER = 100
+
+// Store keeps the latest health state of all load balancers and
+// distributes changes to registered watchers.
+type Store struct {
+	lock     sync.Mutex
+	lbs      map[string]*LoadBalancer
+	version  uint64
+	watchers map[*Watcher]struct{}
+}
+
+// Watcher receives the events of a store.
+type Watcher struct {
+	store  *Store
+	events chan *Event
+}
+
+var defaultStore = NewStore()
+
+// Default provides the store served by the HTTP endpoints.
+func Default() *Store {
+	return defaultStore
+}
+
+func NewStore() *Store {
+	return &Store{
+		lbs:      map[string]*LoadBalancer{},
+		watchers: map[*Watcher]struct{}{},
+	}
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Set updates the health state of a load balancer.
+func (this *Store) Set(lb *LoadBalancer) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	k := key(lb.Namespace, lb.Name)
+	t := EVENT_MODIFIED
+	if this.lbs[k] == nil {
+		t = EVENT_ADDED
+	}
+	this.lbs[k] = lb
+	this.notify(t, lb)
+}
+
+// Remove removes the health state of a load balancer.
+func (this *Store) Remove(namespace, name string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	k := key(namespace, name)
+	if this.lbs[k] == nil {
+		return
+	}
+	delete(this.lbs, k)
+	this.notify(EVENT_DELETED, &LoadBalancer{Namespace: namespace, Name: name})
+}
+
+// Get provides the health state of a load balancer.
+func (this *Store) Get(namespace, name string) *LoadBalancer {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.lbs[key(namespace, name)]
+}
+
+// List provides the health state of all load balancers ordered by
+// namespace and name, together with the current version of the store.
+func (this *Store) List() ([]*LoadBalancer, uint64) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.list(), this.version
+}
+
+func (this *Store) list() []*LoadBalancer {
+	result := make([]*LoadBalancer, 0, len(this.lbs))
+	for _, lb := range this.lbs {
+		result = append(result, lb)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return key(result[i].Namespace, result[i].Name) < key(result[j].Namespace, result[j].Name)
+	})
+	return result
+}
+
+// Watch registers a new watcher. It initially receives an ADDED event for
+// every known load balancer, followed by all subsequent changes.
+func (this *Store) Watch() *Watcher {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	lbs := this.list()
+	w := &Watcher{store: this, events: make(chan *Event, len(lbs)+WATCH_BUFFER)}
+	for _, lb := range lbs {
+		w.events <- &Event{Type: EVENT_ADDED, Version: this.version, LoadBalancer: lb}
+	}
+	this.watchers[w] = struct{}{}
+	return w
+}
+
+// notify must be called with the lock held.
+func (this *Store) notify(t string, lb *LoadBalancer) {
+	this.version++
+	e := &Event{Type: t, Version: this.version, LoadBalancer: lb}
+	for w := range this.watchers {
+		select {
+		case w.events <- e:
+		default:
+			// slow consumer: the client has to watch again
+			delete(this.watchers, w)
+			close(w.events)
+		}
+	}
+}
+
+// Events provides the event channel. It is closed if the watcher is
+// stopped or cannot keep up with the changes.
+func (this *Watcher) Events() <-chan *Event {
+	return this.events
+}
+
+// Stop unregisters the watcher.
+func (this *Watcher) Stop() {
+	this.store.lock.Lock()
+	defer this.store.lock.Unlock()
+	if _, ok := this.store.watchers[this]; ok {
+		delete(this.store.watchers, this)
+		close(this.events)
+	}
+}
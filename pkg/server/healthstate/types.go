@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthstate
+
+import (
+	"time"
+)
+
+// API_VERSION is the version of the health state API. It is part of the
+// URL path of all endpoints and reported with every response.
+const API_VERSION = "v1"
+
+// LoadBalancer describes the health state of a load balancer as decided
+// by the last reconciliation.
+type LoadBalancer struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	DNSName   string `json:"dnsname"`
+	Type      string `json:"type"`
+	// Healthy reports whether the DNS name itself passes the health check
+	Healthy bool `json:"healthy"`
+	// HealthyTargets are the hosts of all healthy endpoints
+	HealthyTargets []string `json:"healthyTargets"`
+	// ActiveTargets are the targets published for the DNS name
+	ActiveTargets []string   `json:"activeTargets"`
+	Regions       []Region   `json:"regions,omitempty"`
+	Endpoints     []Endpoint `json:"endpoints"`
+	Updated       time.Time  `json:"updated"`
+}
+
+// Region describes the targets published for a region specific DNS name.
+type Region struct {
+	Region  string   `json:"region"`
+	DNSName string   `json:"dnsname"`
+	Targets []string `json:"targets"`
+}
+
+// Endpoint describes the health state of an endpoint of a load balancer.
+type Endpoint struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Host      string   `json:"host"`
+	Addresses []string `json:"addresses,omitempty"`
+	Region    string   `json:"region,omitempty"`
+	Healthy   bool     `json:"healthy"`
+	Active    bool     `json:"active"`
+	LastProbe *Probe   `json:"lastProbe,omitempty"`
+}
+
+// Probe describes the result of a health check.
+type Probe struct {
+	Time       time.Time `json:"time"`
+	Healthy    bool      `json:"healthy"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	// LatencyMillis is the duration of the health check in milliseconds
+	LatencyMillis int64  `json:"latencyMillis"`
+	Error         string `json:"error,omitempty"`
+}
+
+const (
+	EVENT_ADDED    = "ADDED"
+	EVENT_MODIFIED = "MODIFIED"
+	EVENT_DELETED  = "DELETED"
+)
+
+// Event describes a change of the health state of a load balancer.
+// For deletions only namespace and name of the load balancer are set.
+type Event struct {
+	Type         string        `json:"type"`
+	Version      uint64        `json:"version"`
+	LoadBalancer *LoadBalancer `json:"loadBalancer"`
+}
+
+// List is the response of the list call.
+type List struct {
+	APIVersion string          `json:"apiVersion"`
+	Version    uint64          `json:"version"`
+	Items      []*LoadBalancer `json:"items"`
+}
@@ -0,0 +1,857 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: healthstate.proto
+
+// Health state API of the DNS load balancer controller. It provides the
+// same information as the HTTP/JSON API.
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Event_Type int32
+
+const (
+	Event_ADDED    Event_Type = 0
+	Event_MODIFIED Event_Type = 1
+	Event_DELETED  Event_Type = 2
+)
+
+var Event_Type_name = map[int32]string{
+	0: "ADDED",
+	1: "MODIFIED",
+	2: "DELETED",
+}
+
+var Event_Type_value = map[string]int32{
+	"ADDED":    0,
+	"MODIFIED": 1,
+	"DELETED":  2,
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{8, 0}
+}
+
+type ListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{0}
+}
+
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+type GetRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{1}
+}
+
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRequest.Size(m)
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{2}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+type LoadBalancerList struct {
+	ApiVersion           string          `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	Version              uint64          `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Items                []*LoadBalancer `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *LoadBalancerList) Reset()         { *m = LoadBalancerList{} }
+func (m *LoadBalancerList) String() string { return proto.CompactTextString(m) }
+func (*LoadBalancerList) ProtoMessage()    {}
+func (*LoadBalancerList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{3}
+}
+
+func (m *LoadBalancerList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadBalancerList.Unmarshal(m, b)
+}
+func (m *LoadBalancerList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadBalancerList.Marshal(b, m, deterministic)
+}
+func (m *LoadBalancerList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadBalancerList.Merge(m, src)
+}
+func (m *LoadBalancerList) XXX_Size() int {
+	return xxx_messageInfo_LoadBalancerList.Size(m)
+}
+func (m *LoadBalancerList) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadBalancerList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadBalancerList proto.InternalMessageInfo
+
+func (m *LoadBalancerList) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return ""
+}
+
+func (m *LoadBalancerList) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *LoadBalancerList) GetItems() []*LoadBalancer {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// LoadBalancer describes the health state of a load balancer as decided
+// by the last reconciliation.
+type LoadBalancer struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Dnsname   string `protobuf:"bytes,3,opt,name=dnsname,proto3" json:"dnsname,omitempty"`
+	Type      string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// healthy reports whether the DNS name itself passes the health check
+	Healthy bool `protobuf:"varint,5,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// healthy_targets are the hosts of all healthy endpoints
+	HealthyTargets []string `protobuf:"bytes,6,rep,name=healthy_targets,json=healthyTargets,proto3" json:"healthy_targets,omitempty"`
+	// active_targets are the targets published for the DNS name
+	ActiveTargets        []string             `protobuf:"bytes,7,rep,name=active_targets,json=activeTargets,proto3" json:"active_targets,omitempty"`
+	Regions              []*Region            `protobuf:"bytes,8,rep,name=regions,proto3" json:"regions,omitempty"`
+	Endpoints            []*Endpoint          `protobuf:"bytes,9,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Updated              *timestamp.Timestamp `protobuf:"bytes,10,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LoadBalancer) Reset()         { *m = LoadBalancer{} }
+func (m *LoadBalancer) String() string { return proto.CompactTextString(m) }
+func (*LoadBalancer) ProtoMessage()    {}
+func (*LoadBalancer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{4}
+}
+
+func (m *LoadBalancer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadBalancer.Unmarshal(m, b)
+}
+func (m *LoadBalancer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadBalancer.Marshal(b, m, deterministic)
+}
+func (m *LoadBalancer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadBalancer.Merge(m, src)
+}
+func (m *LoadBalancer) XXX_Size() int {
+	return xxx_messageInfo_LoadBalancer.Size(m)
+}
+func (m *LoadBalancer) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadBalancer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadBalancer proto.InternalMessageInfo
+
+func (m *LoadBalancer) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *LoadBalancer) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LoadBalancer) GetDnsname() string {
+	if m != nil {
+		return m.Dnsname
+	}
+	return ""
+}
+
+func (m *LoadBalancer) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LoadBalancer) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *LoadBalancer) GetHealthyTargets() []string {
+	if m != nil {
+		return m.HealthyTargets
+	}
+	return nil
+}
+
+func (m *LoadBalancer) GetActiveTargets() []string {
+	if m != nil {
+		return m.ActiveTargets
+	}
+	return nil
+}
+
+func (m *LoadBalancer) GetRegions() []*Region {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *LoadBalancer) GetEndpoints() []*Endpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *LoadBalancer) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+// Region describes the targets published for a region specific DNS name.
+type Region struct {
+	Region               string   `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Dnsname              string   `protobuf:"bytes,2,opt,name=dnsname,proto3" json:"dnsname,omitempty"`
+	Targets              []string `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Region) Reset()         { *m = Region{} }
+func (m *Region) String() string { return proto.CompactTextString(m) }
+func (*Region) ProtoMessage()    {}
+func (*Region) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{5}
+}
+
+func (m *Region) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Region.Unmarshal(m, b)
+}
+func (m *Region) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Region.Marshal(b, m, deterministic)
+}
+func (m *Region) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Region.Merge(m, src)
+}
+func (m *Region) XXX_Size() int {
+	return xxx_messageInfo_Region.Size(m)
+}
+func (m *Region) XXX_DiscardUnknown() {
+	xxx_messageInfo_Region.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Region proto.InternalMessageInfo
+
+func (m *Region) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *Region) GetDnsname() string {
+	if m != nil {
+		return m.Dnsname
+	}
+	return ""
+}
+
+func (m *Region) GetTargets() []string {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+// Endpoint describes the health state of an endpoint of a load balancer.
+type Endpoint struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Host                 string   `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Addresses            []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Region               string   `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	Healthy              bool     `protobuf:"varint,6,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Active               bool     `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	LastProbe            *Probe   `protobuf:"bytes,8,opt,name=last_probe,json=lastProbe,proto3" json:"last_probe,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Endpoint) Reset()         { *m = Endpoint{} }
+func (m *Endpoint) String() string { return proto.CompactTextString(m) }
+func (*Endpoint) ProtoMessage()    {}
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{6}
+}
+
+func (m *Endpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endpoint.Unmarshal(m, b)
+}
+func (m *Endpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endpoint.Marshal(b, m, deterministic)
+}
+func (m *Endpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endpoint.Merge(m, src)
+}
+func (m *Endpoint) XXX_Size() int {
+	return xxx_messageInfo_Endpoint.Size(m)
+}
+func (m *Endpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endpoint proto.InternalMessageInfo
+
+func (m *Endpoint) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Endpoint) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Endpoint) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Endpoint) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *Endpoint) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *Endpoint) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *Endpoint) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *Endpoint) GetLastProbe() *Probe {
+	if m != nil {
+		return m.LastProbe
+	}
+	return nil
+}
+
+// Probe describes the result of a health check.
+type Probe struct {
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Healthy              bool                 `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	StatusCode           int32                `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	LatencyMillis        int64                `protobuf:"varint,4,opt,name=latency_millis,json=latencyMillis,proto3" json:"latency_millis,omitempty"`
+	Error                string               `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Probe) Reset()         { *m = Probe{} }
+func (m *Probe) String() string { return proto.CompactTextString(m) }
+func (*Probe) ProtoMessage()    {}
+func (*Probe) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{7}
+}
+
+func (m *Probe) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Probe.Unmarshal(m, b)
+}
+func (m *Probe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Probe.Marshal(b, m, deterministic)
+}
+func (m *Probe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Probe.Merge(m, src)
+}
+func (m *Probe) XXX_Size() int {
+	return xxx_messageInfo_Probe.Size(m)
+}
+func (m *Probe) XXX_DiscardUnknown() {
+	xxx_messageInfo_Probe.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Probe proto.InternalMessageInfo
+
+func (m *Probe) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *Probe) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *Probe) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *Probe) GetLatencyMillis() int64 {
+	if m != nil {
+		return m.LatencyMillis
+	}
+	return 0
+}
+
+func (m *Probe) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// Event describes a change of the health state of a load balancer.
+// For deletions only namespace and name of the load balancer are set.
+type Event struct {
+	Type                 Event_Type    `protobuf:"varint,1,opt,name=type,proto3,enum=dnslb.healthstate.v1.Event_Type" json:"type,omitempty"`
+	Version              uint64        `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	LoadBalancer         *LoadBalancer `protobuf:"bytes,3,opt,name=load_balancer,json=loadBalancer,proto3" json:"load_balancer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce8030d1320dbdb7, []int{8}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetType() Event_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Event_ADDED
+}
+
+func (m *Event) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Event) GetLoadBalancer() *LoadBalancer {
+	if m != nil {
+		return m.LoadBalancer
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("dnslb.healthstate.v1.Event_Type", Event_Type_name, Event_Type_value)
+	proto.RegisterType((*ListRequest)(nil), "dnslb.healthstate.v1.ListRequest")
+	proto.RegisterType((*GetRequest)(nil), "dnslb.healthstate.v1.GetRequest")
+	proto.RegisterType((*WatchRequest)(nil), "dnslb.healthstate.v1.WatchRequest")
+	proto.RegisterType((*LoadBalancerList)(nil), "dnslb.healthstate.v1.LoadBalancerList")
+	proto.RegisterType((*LoadBalancer)(nil), "dnslb.healthstate.v1.LoadBalancer")
+	proto.RegisterType((*Region)(nil), "dnslb.healthstate.v1.Region")
+	proto.RegisterType((*Endpoint)(nil), "dnslb.healthstate.v1.Endpoint")
+	proto.RegisterType((*Probe)(nil), "dnslb.healthstate.v1.Probe")
+	proto.RegisterType((*Event)(nil), "dnslb.healthstate.v1.Event")
+}
+
+func init() { proto.RegisterFile("healthstate.proto", fileDescriptor_ce8030d1320dbdb7) }
+
+var fileDescriptor_ce8030d1320dbdb7 = []byte{
+	// 764 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdd, 0x6e, 0xeb, 0x44,
+	0x10, 0xc6, 0xb1, 0x9d, 0x9f, 0xc9, 0x0f, 0x61, 0x75, 0x74, 0x64, 0xe5, 0x1c, 0xd1, 0x60, 0x09,
+	0xc8, 0x05, 0xb5, 0x69, 0xa8, 0x10, 0x02, 0x84, 0x44, 0x49, 0x28, 0xa5, 0xad, 0x00, 0x13, 0x81,
+	0xc4, 0x4d, 0xb4, 0xb1, 0x07, 0xc7, 0xc2, 0xf1, 0x9a, 0xdd, 0x4d, 0xa4, 0xdc, 0x23, 0xf1, 0x38,
+	0xbc, 0x09, 0x4f, 0xc3, 0x0d, 0x77, 0x68, 0xd7, 0x36, 0x71, 0x51, 0x42, 0x0b, 0x77, 0x3b, 0xdf,
+	0x7e, 0x33, 0xf3, 0xed, 0xcc, 0xec, 0xc0, 0x6b, 0x6b, 0xa4, 0xa9, 0x5c, 0x0b, 0x49, 0x25, 0x7a,
+	0x39, 0x67, 0x92, 0x91, 0x67, 0x51, 0x26, 0xd2, 0x95, 0x57, 0xbf, 0xd8, 0x5d, 0x8c, 0xce, 0x62,
+	0xc6, 0xe2, 0x14, 0x7d, 0xcd, 0x59, 0x6d, 0x7f, 0xf4, 0x65, 0xb2, 0x41, 0x21, 0xe9, 0x26, 0x2f,
+	0xdc, 0xdc, 0x3e, 0x74, 0xef, 0x12, 0x21, 0x03, 0xfc, 0x79, 0x8b, 0x42, 0xba, 0x9f, 0x00, 0x5c,
+	0x63, 0x65, 0x91, 0x97, 0xd0, 0xc9, 0xe8, 0x06, 0x45, 0x4e, 0x43, 0x74, 0x8c, 0xb1, 0x31, 0xe9,
+	0x04, 0x07, 0x80, 0x10, 0xb0, 0x94, 0xe1, 0x34, 0xf4, 0x85, 0x3e, 0xbb, 0x03, 0xe8, 0x7d, 0x4f,
+	0x65, 0xb8, 0xae, 0xe2, 0xfd, 0x6a, 0xc0, 0xf0, 0x8e, 0xd1, 0xe8, 0x8a, 0xa6, 0x34, 0x0b, 0x91,
+	0xab, 0x5c, 0xe4, 0x0c, 0xba, 0x34, 0x4f, 0x96, 0x3b, 0xe4, 0x22, 0x61, 0x59, 0x19, 0x18, 0x68,
+	0x9e, 0x7c, 0x57, 0x20, 0xc4, 0x81, 0x56, 0x75, 0xa9, 0x82, 0x5b, 0x41, 0x65, 0x92, 0x0f, 0xc0,
+	0x4e, 0x24, 0x6e, 0x84, 0x63, 0x8e, 0xcd, 0x49, 0x77, 0xea, 0x7a, 0xc7, 0x5e, 0xed, 0xd5, 0x33,
+	0x06, 0x85, 0x83, 0xfb, 0x8b, 0x09, 0xbd, 0x3a, 0xfe, 0xdf, 0x1f, 0xa7, 0x64, 0x45, 0x99, 0xd0,
+	0xb0, 0xa9, 0xe1, 0xca, 0x54, 0x6c, 0xb9, 0xcf, 0xd1, 0xb1, 0x0a, 0xb6, 0x3a, 0x2b, 0x76, 0x21,
+	0x6b, 0xef, 0xd8, 0x63, 0x63, 0xd2, 0x0e, 0x2a, 0x93, 0xbc, 0x0d, 0xaf, 0x96, 0xc7, 0xa5, 0xa4,
+	0x3c, 0x46, 0x29, 0x9c, 0xe6, 0xd8, 0x9c, 0x74, 0x82, 0x41, 0x09, 0x2f, 0x0a, 0x94, 0xbc, 0x09,
+	0x03, 0x1a, 0xca, 0x64, 0x87, 0x7f, 0xf3, 0x5a, 0x9a, 0xd7, 0x2f, 0xd0, 0x8a, 0xf6, 0x3e, 0xb4,
+	0x38, 0xc6, 0x09, 0xcb, 0x84, 0xd3, 0xd6, 0x65, 0x79, 0x79, 0xbc, 0x2c, 0x81, 0x26, 0x05, 0x15,
+	0x99, 0x7c, 0x0c, 0x1d, 0xcc, 0xa2, 0x9c, 0x25, 0x99, 0x14, 0x4e, 0x47, 0x7b, 0xbe, 0x7e, 0xdc,
+	0x73, 0x5e, 0xd2, 0x82, 0x83, 0x03, 0xb9, 0x84, 0xd6, 0x36, 0x8f, 0xa8, 0xc4, 0xc8, 0x81, 0xb1,
+	0x31, 0xe9, 0x4e, 0x47, 0x5e, 0x31, 0x6c, 0x5e, 0x35, 0x6c, 0xde, 0xa2, 0x1a, 0xb6, 0xa0, 0xa2,
+	0xba, 0x0b, 0x68, 0x16, 0x32, 0xc8, 0x73, 0x68, 0x16, 0x42, 0xca, 0xe2, 0x97, 0x56, 0xbd, 0xca,
+	0x8d, 0x87, 0x55, 0x76, 0xa0, 0x55, 0xd5, 0xc1, 0xd4, 0x75, 0xa8, 0x4c, 0xf7, 0x0f, 0x03, 0xda,
+	0x95, 0xc6, 0xff, 0xd1, 0x58, 0x02, 0xd6, 0x9a, 0x09, 0x59, 0x76, 0x55, 0x9f, 0x55, 0x14, 0x1a,
+	0x45, 0x1c, 0x85, 0x40, 0xe1, 0x58, 0x3a, 0xdd, 0x01, 0xa8, 0x89, 0xb7, 0xff, 0x29, 0xbe, 0x6a,
+	0x7a, 0xf3, 0x61, 0xd3, 0x9f, 0x43, 0xb3, 0xe8, 0x9a, 0xd3, 0xd2, 0x17, 0xa5, 0x45, 0x3e, 0x04,
+	0x48, 0xa9, 0x90, 0xcb, 0x9c, 0xb3, 0x15, 0x3a, 0x6d, 0x5d, 0xc9, 0x17, 0xc7, 0xbb, 0xf0, 0xb5,
+	0xa2, 0x04, 0x1d, 0x45, 0xd7, 0x47, 0xf7, 0x37, 0x03, 0x6c, 0x7d, 0x22, 0x1e, 0x58, 0xea, 0x67,
+	0x3b, 0xc6, 0xa3, 0x9d, 0xd0, 0xbc, 0xba, 0xce, 0xc6, 0x43, 0x9d, 0x67, 0xd0, 0x55, 0x09, 0xb7,
+	0x62, 0x19, 0xb2, 0xa8, 0x18, 0x74, 0x3b, 0x80, 0x02, 0xfa, 0x8c, 0x45, 0xa8, 0x86, 0x32, 0xa5,
+	0x12, 0xb3, 0x70, 0xbf, 0xdc, 0x24, 0x69, 0x9a, 0x08, 0x3d, 0xf5, 0x66, 0xd0, 0x2f, 0xd1, 0x7b,
+	0x0d, 0x92, 0x67, 0x60, 0x23, 0xe7, 0x8c, 0x97, 0x05, 0x2a, 0x0c, 0xf7, 0x77, 0x03, 0xec, 0xf9,
+	0x0e, 0x33, 0x49, 0x2e, 0xcb, 0x2f, 0xa3, 0x14, 0x0f, 0xa6, 0xe3, 0x13, 0x73, 0xa7, 0xa8, 0xde,
+	0x62, 0x9f, 0xe3, 0xe1, 0x53, 0x9d, 0xd8, 0x0c, 0xd7, 0xd0, 0x4f, 0x19, 0x8d, 0x96, 0xab, 0xf2,
+	0x7f, 0x6b, 0xe5, 0x4f, 0xdb, 0x10, 0xbd, 0xb4, 0x66, 0xb9, 0xef, 0x80, 0xa5, 0x12, 0x92, 0x0e,
+	0xd8, 0x9f, 0xce, 0x66, 0xf3, 0xd9, 0xf0, 0x15, 0xd2, 0x83, 0xf6, 0xfd, 0x57, 0xb3, 0x9b, 0xcf,
+	0x6f, 0xe6, 0xb3, 0xa1, 0x41, 0xba, 0xd0, 0x9a, 0xcd, 0xef, 0xe6, 0x8b, 0xf9, 0x6c, 0xd8, 0x98,
+	0xfe, 0x69, 0x40, 0xf7, 0x0b, 0x1d, 0xfb, 0x5b, 0x15, 0x9b, 0x7c, 0x03, 0x96, 0xde, 0x71, 0x6f,
+	0x9c, 0xc8, 0x7b, 0xd8, 0xb5, 0xa3, 0xb7, 0x1e, 0x97, 0xa6, 0x43, 0xdd, 0x82, 0x79, 0x8d, 0x92,
+	0x9c, 0x28, 0xd1, 0x61, 0x5d, 0x8f, 0x9e, 0xf0, 0x56, 0xf2, 0x25, 0xd8, 0x7a, 0x41, 0x93, 0x13,
+	0xe4, 0xfa, 0xf6, 0x1e, 0xbd, 0xf8, 0x97, 0xae, 0xbc, 0x6b, 0x5c, 0xdd, 0xfe, 0x70, 0x13, 0x27,
+	0x72, 0xbd, 0x5d, 0x79, 0x21, 0xdb, 0xf8, 0x31, 0xe5, 0x11, 0x66, 0xc8, 0x7d, 0xed, 0x73, 0x1e,
+	0xb2, 0x4c, 0x72, 0x96, 0xa6, 0xc8, 0xcf, 0x37, 0x34, 0xa3, 0x31, 0x72, 0x3f, 0xff, 0x29, 0xf6,
+	0x05, 0xf2, 0x1d, 0x72, 0xbf, 0x16, 0xd1, 0xdf, 0x5d, 0x7c, 0xb4, 0xbb, 0x58, 0x35, 0xf5, 0xac,
+	0xbe, 0xf7, 0xd7, 0x00, 0x25, 0x22, 0x51, 0xea, 0xdb, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// HealthStateClient is the client API for HealthState service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HealthStateClient interface {
+	// List provides all load balancers.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*LoadBalancerList, error)
+	// Get provides a single load balancer.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*LoadBalancer, error)
+	// Watch streams an ADDED event for every known load balancer,
+	// followed by all subsequent changes.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HealthState_WatchClient, error)
+}
+
+type healthStateClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHealthStateClient(cc *grpc.ClientConn) HealthStateClient {
+	return &healthStateClient{cc}
+}
+
+func (c *healthStateClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*LoadBalancerList, error) {
+	out := new(LoadBalancerList)
+	err := c.cc.Invoke(ctx, "/dnslb.healthstate.v1.HealthState/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthStateClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*LoadBalancer, error) {
+	out := new(LoadBalancer)
+	err := c.cc.Invoke(ctx, "/dnslb.healthstate.v1.HealthState/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthStateClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HealthState_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HealthState_serviceDesc.Streams[0], "/dnslb.healthstate.v1.HealthState/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &healthStateWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HealthState_WatchClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type healthStateWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *healthStateWatchClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HealthStateServer is the server API for HealthState service.
+type HealthStateServer interface {
+	// List provides all load balancers.
+	List(context.Context, *ListRequest) (*LoadBalancerList, error)
+	// Get provides a single load balancer.
+	Get(context.Context, *GetRequest) (*LoadBalancer, error)
+	// Watch streams an ADDED event for every known load balancer,
+	// followed by all subsequent changes.
+	Watch(*WatchRequest, HealthState_WatchServer) error
+}
+
+func RegisterHealthStateServer(s *grpc.Server, srv HealthStateServer) {
+	s.RegisterService(&_HealthState_serviceDesc, srv)
+}
+
+func _HealthState_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthStateServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dnslb.healthstate.v1.HealthState/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthStateServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthState_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthStateServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dnslb.healthstate.v1.HealthState/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthStateServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthState_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HealthStateServer).Watch(m, &healthStateWatchServer{stream})
+}
+
+type HealthState_WatchServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type healthStateWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *healthStateWatchServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _HealthState_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dnslb.healthstate.v1.HealthState",
+	HandlerType: (*HealthStateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _HealthState_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _HealthState_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _HealthState_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "healthstate.proto",
+}
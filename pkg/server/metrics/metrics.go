@@ -19,10 +19,15 @@ func init() {
 	prometheus.MustRegister(EndpointHealth)
 	prometheus.MustRegister(EndpointHosts)
 	prometheus.MustRegister(EndpointActive)
+	prometheus.MustRegister(EndpointLocation)
 	prometheus.MustRegister(LoadBalancers)
 	prometheus.MustRegister(LoadBalancerDNS)
 	prometheus.MustRegister(DNSReconciler)
 	prometheus.MustRegister(DNSReconcileTime)
+	prometheus.MustRegister(DNSQueries)
+	prometheus.MustRegister(DeferredChanges)
+	prometheus.MustRegister(HealthyEndpoints)
+	prometheus.MustRegister(Degraded)
 
 	server.RegisterHandler("/metrics", promhttp.Handler())
 
@@ -66,6 +71,22 @@ func ReportActiveEndpoint(lb, key resources.ObjectName, active bool) {
 	setActive(EndpointActive.WithLabelValues(lb.String(), key.String()), active)
 }
 
+/////////////////////////////////////////////////////////////////////////////////
+
+var (
+	EndpointLocation = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpoint_location",
+			Help: "Active status of endpoints with source cluster, region and zone",
+		},
+		[]string{"loadbalancer", "endpoint", "cluster", "region", "zone"},
+	)
+)
+
+func ReportEndpointLocation(lb, key resources.ObjectName, cluster, region, zone string, active bool) {
+	setActive(EndpointLocation.WithLabelValues(lb.String(), key.String(), cluster, region, zone), active)
+}
+
 /////////////////////////////////////////////////////////////////////////////////
 var (
 	LoadBalancers = prometheus.NewGaugeVec(
@@ -122,6 +143,62 @@ func ReportDoneDNSReconcile() int {
 
 /////////////////////////////////////////////////////////////////////////////////
 
+var (
+	DNSQueries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gslb_dns_queries",
+			Help: "Queries answered by the embedded DNS server per dnsname, type and response code",
+		},
+		[]string{"dnsname", "type", "rcode"},
+	)
+)
+
+func ReportDNSQuery(dnsname, qtype, rcode string) {
+	DNSQueries.WithLabelValues(dnsname, qtype, rcode).Inc()
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+
+var (
+	DeferredChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loadbalancer_deferred_changes",
+			Help: "Changes of published targets deferred by an exhausted change budget",
+		},
+		[]string{"loadbalancer", "budget"},
+	)
+)
+
+func ReportDeferredChange(lb, budget string) {
+	DeferredChanges.WithLabelValues(lb, budget).Inc()
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+
+var (
+	HealthyEndpoints = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "loadbalancer_healthy_endpoints",
+			Help: "Number of healthy endpoints of dnsname Loadbalancers",
+		},
+		[]string{"loadbalancer"},
+	)
+	Degraded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "loadbalancer_degraded",
+			Help: "Degraded status of dnsname Loadbalancers (fewer healthy endpoints than required)",
+		},
+		[]string{"loadbalancer"},
+	)
+)
+
+func ReportHealthyEndpoints(lb string, healthy int, degraded bool) {
+	HealthyEndpoints.WithLabelValues(lb).Set(float64(healthy))
+	setActive(Degraded.WithLabelValues(lb), degraded)
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+
 func setActive(g prometheus.Gauge, active bool) {
 	if active {
 		g.Set(1)
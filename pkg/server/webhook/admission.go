@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// The admission review types are wire compatible with the versions v1 and
+// v1beta1 of the API group admission.k8s.io, which are not part of the
+// build dependencies.
+
+const (
+	OPERATION_CREATE = "CREATE"
+	OPERATION_UPDATE = "UPDATE"
+	OPERATION_DELETE = "DELETE"
+)
+
+// AdmissionReview describes an admission review request and response.
+type AdmissionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *AdmissionRequest  `json:"request,omitempty"`
+	Response        *AdmissionResponse `json:"response,omitempty"`
+}
+
+// AdmissionRequest describes the object of an admission request.
+type AdmissionRequest struct {
+	UID       string                      `json:"uid"`
+	Kind      metav1.GroupVersionKind     `json:"kind"`
+	Resource  metav1.GroupVersionResource `json:"resource"`
+	Name      string                      `json:"name,omitempty"`
+	Namespace string                      `json:"namespace,omitempty"`
+	Operation string                      `json:"operation"`
+	Object    json.RawMessage             `json:"object,omitempty"`
+	OldObject json.RawMessage             `json:"oldObject,omitempty"`
+	DryRun    *bool                       `json:"dryRun,omitempty"`
+	UserInfo  UserInfo                    `json:"userInfo"`
+}
+
+// UserInfo describes the user requesting an admission.
+type UserInfo struct {
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// AdmissionResponse describes the result of an admission review.
+type AdmissionResponse struct {
+	UID       string         `json:"uid"`
+	Allowed   bool           `json:"allowed"`
+	Result    *metav1.Status `json:"status,omitempty"`
+	Patch     []byte         `json:"patch,omitempty"`
+	PatchType *string        `json:"patchType,omitempty"`
+}
+
+const PATCH_TYPE_JSON = "JSONPatch"
+
+// PatchOperation is an operation of a JSON patch (RFC 6902).
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Validator checks the object of an admission request. An error rejects
+// the request.
+type Validator func(req *AdmissionRequest) error
+
+// Mutator provides the patch for the object of an admission request.
+// An error rejects the request.
+type Mutator func(req *AdmissionRequest) ([]PatchOperation, error)
+
+// ValidationHandler provides an http handler for admission reviews
+// validated by a validator.
+func ValidationHandler(logger logger.LogContext, validator Validator) http.Handler {
+	return MutationHandler(logger, func(req *AdmissionRequest) ([]PatchOperation, error) {
+		return nil, validator(req)
+	})
+}
+
+// MutationHandler provides an http handler for admission reviews
+// handled by a mutator.
+func MutationHandler(logger logger.LogContext, mutator Mutator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review, err := readReview(r)
+		if err != nil {
+			logger.Warnf("invalid admission review: %s", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req := review.Request
+		resp := &AdmissionResponse{UID: req.UID, Allowed: true}
+		patch, err := mutator(req)
+		if err == nil && len(patch) > 0 {
+			resp.Patch, err = json.Marshal(patch)
+			pt := PATCH_TYPE_JSON
+			resp.PatchType = &pt
+		}
+		if err != nil {
+			logger.Infof("rejecting %s of %s %s/%s: %s", req.Operation, req.Kind.Kind, req.Namespace, req.Name, err)
+			resp.Allowed = false
+			resp.Patch = nil
+			resp.PatchType = nil
+			resp.Result = &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Reason:  metav1.StatusReasonInvalid,
+				Message: err.Error(),
+				Code:    http.StatusUnprocessableEntity,
+			}
+		}
+		review.Request = nil
+		review.Response = resp
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(review); err != nil {
+			logger.Warnf("cannot write admission review: %s", err)
+		}
+	})
+}
+
+func readReview(r *http.Request) (*AdmissionReview, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("invalid method %s", r.Method)
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	review := &AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		return nil, err
+	}
+	if review.Request == nil {
+		return nil, fmt.Errorf("no request")
+	}
+	return review, nil
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	CERT_FILE = "tls.crt"
+	KEY_FILE  = "tls.key"
+	CA_FILE   = "ca.crt"
+)
+
+// Certificates provides the serving certificate of the webhook server and
+// the CA bundle used to verify it.
+type Certificates interface {
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	CABundle() ([]byte, error)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// staticCertificates is a generated self-signed certificate.
+type staticCertificates struct {
+	cert   tls.Certificate
+	bundle []byte
+}
+
+// GenerateCertificates generates a CA and a serving certificate for the
+// given host names and ip addresses.
+func GenerateCertificates(hosts []string, validity time.Duration) (Certificates, error) {
+	certPEM, keyPEM, caPEM, err := GenerateCertificatePEMs(hosts, validity)
+	if err != nil {
+		return nil, err
+	}
+	return NewCertificates(certPEM, keyPEM, caPEM)
+}
+
+// NewCertificates provides the certificates for PEM encoded data, for
+// example read from a secret.
+func NewCertificates(certPEM, keyPEM, caPEM []byte) (Certificates, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(caPEM) == 0 {
+		caPEM = certPEM
+	}
+	return &staticCertificates{cert: cert, bundle: caPEM}, nil
+}
+
+func (this *staticCertificates) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return &this.cert, nil
+}
+
+func (this *staticCertificates) CABundle() ([]byte, error) {
+	return this.bundle, nil
+}
+
+// GenerateCertificatePEMs generates a CA and a serving certificate for the
+// given host names and ip addresses. It provides the PEM encoded serving
+// certificate, its key and the CA certificate.
+func GenerateCertificatePEMs(hosts []string, validity time.Duration) (certPEM, keyPEM, caPEM []byte, err error) {
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "dnslb-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "dnslb-webhook"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			cert.IPAddresses = append(cert.IPAddresses, ip)
+		} else {
+			cert.DNSNames = append(cert.DNSNames, h)
+		}
+	}
+	if len(hosts) > 0 {
+		cert.Subject.CommonName = hosts[0]
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, cert, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	return certPEM, keyPEM, caPEM, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fileCertificates reads the certificates from a directory, for example
+// a mounted secret. The files are read again after they have been modified.
+type fileCertificates struct {
+	lock     sync.Mutex
+	dir      string
+	modified time.Time
+	cert     *tls.Certificate
+}
+
+// NewFileCertificates provides the certificates found in a directory
+// (tls.crt, tls.key and optionally ca.crt).
+func NewFileCertificates(dir string) (Certificates, error) {
+	this := &fileCertificates{dir: dir}
+	if _, err := this.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+	return this, nil
+}
+
+func (this *fileCertificates) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	certFile := filepath.Join(this.dir, CERT_FILE)
+	keyFile := filepath.Join(this.dir, KEY_FILE)
+	modified, err := lastModified(certFile, keyFile)
+	if err != nil {
+		if this.cert != nil {
+			return this.cert, nil
+		}
+		return nil, err
+	}
+	if this.cert == nil || modified.After(this.modified) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			if this.cert != nil {
+				// keep the old certificate while the files are updated
+				return this.cert, nil
+			}
+			return nil, fmt.Errorf("cannot load webhook certificate: %s", err)
+		}
+		this.cert = &cert
+		this.modified = modified
+	}
+	return this.cert, nil
+}
+
+func (this *fileCertificates) CABundle() ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(this.dir, CA_FILE))
+	if os.IsNotExist(err) {
+		// self-signed certificate
+		return ioutil.ReadFile(filepath.Join(this.dir, CERT_FILE))
+	}
+	return data, err
+}
+
+func lastModified(files ...string) (time.Time, error) {
+	modified := time.Time{}
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return modified, err
+		}
+		if info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+	}
+	return modified, nil
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// The webhook configurations are written with the version v1 of the API
+// group admissionregistration.k8s.io, which is not part of the build
+// dependencies. Like the custom resource definitions they are registered
+// as unstructured objects.
+
+const ADMISSION_REGISTRATION_GROUP = "admissionregistration.k8s.io"
+
+var ValidatingGroupVersionKind = schema.GroupVersionKind{Group: ADMISSION_REGISTRATION_GROUP, Version: "v1", Kind: "ValidatingWebhookConfiguration"}
+var MutatingGroupVersionKind = schema.GroupVersionKind{Group: ADMISSION_REGISTRATION_GROUP, Version: "v1", Kind: "MutatingWebhookConfiguration"}
+
+const (
+	FAILURE_POLICY_FAIL   = "Fail"
+	FAILURE_POLICY_IGNORE = "Ignore"
+	SIDE_EFFECTS_NONE     = "None"
+)
+
+// AdmissionReviewVersions are the versions of admission reviews handled by
+// the admission handlers.
+var AdmissionReviewVersions = []string{"v1", "v1beta1"}
+
+// Configuration is a validating or mutating webhook configuration.
+type Configuration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Webhooks          []Webhook `json:"webhooks"`
+}
+
+type Webhook struct {
+	Name                    string       `json:"name"`
+	ClientConfig            ClientConfig `json:"clientConfig"`
+	Rules                   []Rule       `json:"rules"`
+	FailurePolicy           string       `json:"failurePolicy"`
+	SideEffects             string       `json:"sideEffects"`
+	AdmissionReviewVersions []string     `json:"admissionReviewVersions"`
+}
+
+type ClientConfig struct {
+	Service  *ServiceReference `json:"service,omitempty"`
+	CABundle []byte            `json:"caBundle,omitempty"`
+}
+
+type ServiceReference struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Path      *string `json:"path,omitempty"`
+}
+
+type Rule struct {
+	Operations  []string `json:"operations"`
+	APIGroups   []string `json:"apiGroups"`
+	APIVersions []string `json:"apiVersions"`
+	Resources   []string `json:"resources"`
+}
+
+// NewConfiguration provides an empty webhook configuration of a kind.
+func NewConfiguration(gvk schema.GroupVersionKind, name string) *Configuration {
+	return &Configuration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+// Register creates or updates a webhook configuration in a cluster.
+func Register(cluster resources.Cluster, config *Configuration) error {
+	res, err := cluster.Resources().GetUnstructuredByGVK(config.GroupVersionKind())
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	old := &unstructured.Unstructured{}
+	_, err = res.GetInto(resources.NewObjectName(config.Name), old)
+	switch {
+	case errors.IsNotFound(err):
+		_, err = res.Create(obj)
+		return err
+	case err != nil:
+		return err
+	}
+	old.Object["webhooks"] = obj.Object["webhooks"]
+	_, err = res.Update(old)
+	return err
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// The conversion review types are wire compatible with the versions v1 and
+// v1beta1 of the conversion review of the API group apiextensions.k8s.io.
+
+// ConversionReview describes a conversion review request and response.
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *ConversionRequest  `json:"request,omitempty"`
+	Response        *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest describes the objects to convert.
+type ConversionRequest struct {
+	UID               string            `json:"uid"`
+	DesiredAPIVersion string            `json:"desiredAPIVersion"`
+	Objects           []json.RawMessage `json:"objects"`
+}
+
+// ConversionResponse describes the result of a conversion review.
+type ConversionResponse struct {
+	UID              string            `json:"uid"`
+	ConvertedObjects []json.RawMessage `json:"convertedObjects"`
+	Result           metav1.Status     `json:"result"`
+}
+
+// Converter converts the JSON representation of an object to the desired
+// api version.
+type Converter func(obj []byte, apiVersion string) ([]byte, error)
+
+// ConversionHandler provides an http handler for conversion reviews
+// handled by a converter. The conversion fails as a whole if one of the
+// objects cannot be converted.
+func ConversionHandler(logger logger.LogContext, converter Converter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review, err := readConversionReview(r)
+		if err != nil {
+			logger.Warnf("invalid conversion review: %s", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req := review.Request
+		resp := &ConversionResponse{UID: req.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+		for _, obj := range req.Objects {
+			converted, err := converter(obj, req.DesiredAPIVersion)
+			if err != nil {
+				logger.Warnf("cannot convert object to %s: %s", req.DesiredAPIVersion, err)
+				resp.ConvertedObjects = nil
+				resp.Result = metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+				}
+				break
+			}
+			resp.ConvertedObjects = append(resp.ConvertedObjects, converted)
+		}
+		review.Request = nil
+		review.Response = resp
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(review); err != nil {
+			logger.Warnf("cannot write conversion review: %s", err)
+		}
+	})
+}
+
+func readConversionReview(r *http.Request) (*ConversionReview, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("invalid method %s", r.Method)
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	review := &ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		return nil, err
+	}
+	if review.Request == nil {
+		return nil, fmt.Errorf("no request")
+	}
+	return review, nil
+}
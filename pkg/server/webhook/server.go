@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// Server is an https server for admission webhooks.
+type Server struct {
+	lock     sync.Mutex
+	logger   logger.LogContext
+	certs    Certificates
+	mux      *http.ServeMux
+	listener net.Listener
+}
+
+func NewServer(logger logger.LogContext, certs Certificates) *Server {
+	return &Server{logger: logger, certs: certs, mux: http.NewServeMux()}
+}
+
+// Certificates provides the certificates used by the server.
+func (this *Server) Certificates() Certificates {
+	return this.certs
+}
+
+// Handle registers a handler for a path.
+func (this *Server) Handle(path string, handler http.Handler) {
+	this.mux.Handle(path, handler)
+}
+
+// Start starts serving requests on the given address.
+func (this *Server) Start(addr string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	config := &tls.Config{
+		GetCertificate: this.certs.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	listener, err := tls.Listen("tcp", addr, config)
+	if err != nil {
+		return err
+	}
+	this.listener = listener
+	this.logger.Infof("serving webhooks on %s", addr)
+	go func() {
+		err := http.Serve(listener, this.mux)
+		this.logger.Infof("webhook server stopped: %s", err)
+	}()
+	return nil
+}
+
+// Addr provides the address the server is listening on.
+func (this *Server) Addr() net.Addr {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.listener == nil {
+		return nil
+	}
+	return this.listener.Addr()
+}
+
+// Close stops serving requests.
+func (this *Server) Close() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.listener != nil {
+		this.listener.Close()
+		this.listener = nil
+	}
+}
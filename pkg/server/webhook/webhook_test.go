@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	. "github.com/gardener/dnslb-controller-manager/pkg/server/webhook"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("webhook", func() {
+	review := func(handler http.Handler, req *AdmissionRequest) *AdmissionResponse {
+		body, err := json.Marshal(&AdmissionReview{Request: req})
+		Expect(err).NotTo(HaveOccurred())
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		result := &AdmissionReview{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), result)).To(Succeed())
+		Expect(result.Request).To(BeNil())
+		return result.Response
+	}
+
+	Context("validation handler", func() {
+		handler := ValidationHandler(logger.New(), func(req *AdmissionRequest) error {
+			if req.Name == "invalid" {
+				return fmt.Errorf("invalid object")
+			}
+			return nil
+		})
+
+		It("accepts valid objects", func() {
+			resp := review(handler, &AdmissionRequest{UID: "1", Name: "valid", Operation: OPERATION_CREATE})
+			Expect(resp.UID).To(Equal("1"))
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Result).To(BeNil())
+		})
+
+		It("rejects invalid objects", func() {
+			resp := review(handler, &AdmissionRequest{UID: "2", Name: "invalid", Operation: OPERATION_CREATE})
+			Expect(resp.UID).To(Equal("2"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal("invalid object"))
+		})
+
+		It("rejects malformed reviews", func() {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader([]byte("{}"))))
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("mutation handler", func() {
+		handler := MutationHandler(logger.New(), func(req *AdmissionRequest) ([]PatchOperation, error) {
+			switch req.Name {
+			case "invalid":
+				return nil, fmt.Errorf("invalid object")
+			case "defaulted":
+				return []PatchOperation{{Op: "add", Path: "/spec/type", Value: "Balanced"}}, nil
+			}
+			return nil, nil
+		})
+
+		It("patches objects", func() {
+			resp := review(handler, &AdmissionRequest{UID: "1", Name: "defaulted", Operation: OPERATION_CREATE})
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(*resp.PatchType).To(Equal(PATCH_TYPE_JSON))
+			Expect(string(resp.Patch)).To(MatchJSON(`[{"op":"add","path":"/spec/type","value":"Balanced"}]`))
+		})
+
+		It("accepts objects without patch", func() {
+			resp := review(handler, &AdmissionRequest{UID: "2", Name: "complete", Operation: OPERATION_UPDATE})
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).To(BeNil())
+			Expect(resp.PatchType).To(BeNil())
+		})
+
+		It("rejects invalid objects", func() {
+			resp := review(handler, &AdmissionRequest{UID: "3", Name: "invalid", Operation: OPERATION_CREATE})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal("invalid object"))
+		})
+	})
+
+	Context("conversion handler", func() {
+		handler := ConversionHandler(logger.New(), func(obj []byte, apiVersion string) ([]byte, error) {
+			if string(obj) == `"invalid"` {
+				return nil, fmt.Errorf("invalid object")
+			}
+			return []byte(fmt.Sprintf(`{"apiVersion":%q}`, apiVersion)), nil
+		})
+		convert := func(objs ...string) *ConversionResponse {
+			req := &ConversionRequest{UID: "1", DesiredAPIVersion: "example.org/v1"}
+			for _, o := range objs {
+				req.Objects = append(req.Objects, json.RawMessage(o))
+			}
+			body, err := json.Marshal(&ConversionReview{Request: req})
+			Expect(err).NotTo(HaveOccurred())
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			result := &ConversionReview{}
+			Expect(json.Unmarshal(rec.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Response.UID).To(Equal("1"))
+			return result.Response
+		}
+
+		It("converts objects", func() {
+			resp := convert(`{"apiVersion":"example.org/v1beta1"}`, `{"apiVersion":"example.org/v1beta1"}`)
+			Expect(resp.Result.Status).To(Equal("Success"))
+			Expect(resp.ConvertedObjects).To(HaveLen(2))
+			Expect(string(resp.ConvertedObjects[1])).To(MatchJSON(`{"apiVersion":"example.org/v1"}`))
+		})
+
+		It("fails for invalid objects", func() {
+			resp := convert(`{"apiVersion":"example.org/v1beta1"}`, `"invalid"`)
+			Expect(resp.Result.Status).To(Equal("Failure"))
+			Expect(resp.Result.Message).To(Equal("invalid object"))
+			Expect(resp.ConvertedObjects).To(BeEmpty())
+		})
+	})
+
+	Context("certificates", func() {
+		verify := func(certs Certificates, host string) error {
+			cert, err := certs.GetCertificate(nil)
+			Expect(err).NotTo(HaveOccurred())
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			Expect(err).NotTo(HaveOccurred())
+			bundle, err := certs.CABundle()
+			Expect(err).NotTo(HaveOccurred())
+			pool := x509.NewCertPool()
+			Expect(pool.AppendCertsFromPEM(bundle)).To(BeTrue())
+			_, err = leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: pool})
+			return err
+		}
+
+		It("generates certificates verifiable by the CA bundle", func() {
+			certs, err := GenerateCertificates([]string{"webhook.default.svc"}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(certs, "webhook.default.svc")).To(Succeed())
+			Expect(verify(certs, "other.default.svc")).NotTo(Succeed())
+		})
+
+		It("restores generated certificates from their PEM encoding", func() {
+			certPEM, keyPEM, caPEM, err := GenerateCertificatePEMs([]string{"webhook.default.svc"}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			certs, err := NewCertificates(certPEM, keyPEM, caPEM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(certs, "webhook.default.svc")).To(Succeed())
+
+			_, err = NewCertificates(certPEM, nil, caPEM)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("reads and reloads certificates from a directory", func() {
+			dir, err := ioutil.TempDir("", "webhook")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			_, err = NewFileCertificates(dir)
+			Expect(err).To(HaveOccurred())
+
+			write := func(host string, modified time.Time) {
+				certs, err := GenerateCertificates([]string{host}, time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				cert, _ := certs.GetCertificate(nil)
+				bundle, _ := certs.CABundle()
+				Expect(ioutil.WriteFile(filepath.Join(dir, CERT_FILE), pemCert(cert), 0600)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dir, KEY_FILE), pemKey(cert), 0600)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dir, CA_FILE), bundle, 0600)).To(Succeed())
+				for _, f := range []string{CERT_FILE, KEY_FILE} {
+					Expect(os.Chtimes(filepath.Join(dir, f), modified, modified)).To(Succeed())
+				}
+			}
+
+			now := time.Now()
+			write("a.default.svc", now.Add(-time.Minute))
+			certs, err := NewFileCertificates(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(certs, "a.default.svc")).To(Succeed())
+
+			write("b.default.svc", now)
+			Expect(verify(certs, "b.default.svc")).To(Succeed())
+		})
+	})
+
+	Context("server", func() {
+		It("serves webhooks with tls", func() {
+			certs, err := GenerateCertificates([]string{"127.0.0.1"}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			server := NewServer(logger.New(), certs)
+			server.Handle("/validate", ValidationHandler(logger.New(), func(req *AdmissionRequest) error { return nil }))
+			Expect(server.Start("127.0.0.1:0")).To(Succeed())
+			defer server.Close()
+
+			bundle, _ := certs.CABundle()
+			pool := x509.NewCertPool()
+			pool.AppendCertsFromPEM(bundle)
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+			body, _ := json.Marshal(&AdmissionReview{Request: &AdmissionRequest{UID: "3", Operation: OPERATION_CREATE}})
+			resp, err := client.Post(fmt.Sprintf("https://%s/validate", server.Addr()), "application/json", bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			result := &AdmissionReview{}
+			Expect(json.NewDecoder(resp.Body).Decode(result)).To(Succeed())
+			Expect(result.Response.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("configuration", func() {
+		It("is encoded with admissionregistration.k8s.io/v1", func() {
+			path := "/validate"
+			config := NewConfiguration(ValidatingGroupVersionKind, "dnslb")
+			config.Webhooks = []Webhook{{
+				Name:                    "validation.example.org",
+				ClientConfig:            ClientConfig{Service: &ServiceReference{Namespace: "default", Name: "dnslb", Path: &path}, CABundle: []byte("ca")},
+				Rules:                   []Rule{{Operations: []string{OPERATION_CREATE}, APIGroups: []string{"example.org"}, APIVersions: []string{"*"}, Resources: []string{"things"}}},
+				FailurePolicy:           FAILURE_POLICY_IGNORE,
+				SideEffects:             SIDE_EFFECTS_NONE,
+				AdmissionReviewVersions: AdmissionReviewVersions,
+			}}
+			data, err := json.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{
+				"apiVersion": "admissionregistration.k8s.io/v1",
+				"kind": "ValidatingWebhookConfiguration",
+				"metadata": {"name": "dnslb", "creationTimestamp": null},
+				"webhooks": [{
+					"name": "validation.example.org",
+					"clientConfig": {"service": {"namespace": "default", "name": "dnslb", "path": "/validate"}, "caBundle": "Y2E="},
+					"rules": [{"operations": ["CREATE"], "apiGroups": ["example.org"], "apiVersions": ["*"], "resources": ["things"]}],
+					"failurePolicy": "Ignore",
+					"sideEffects": "None",
+					"admissionReviewVersions": ["v1", "v1beta1"]
+				}]
+			}`))
+		})
+	})
+})
+
+func pemCert(cert *tls.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+}
+
+func pemKey(cert *tls.Certificate) []byte {
+	der, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}